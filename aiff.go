@@ -0,0 +1,16 @@
+package easyid3
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+// ReadID3FromAIFF is ReadID3FromWAV for the "ID3 " chunk of an AIFF or
+// AIFF-C file
+func ReadID3FromAIFF(r io.ReadSeeker, opts ...Option) (map[string]string, error) {
+	s, err := newIFFScanner(r, binary.BigEndian, "FORM", "AIFF", "AIFC")
+	if err != nil {
+		return nil, err
+	}
+	return readID3Chunk(s, opts, "ID3 ", "id3 ")
+}
@@ -0,0 +1,68 @@
+package easyid3
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+)
+
+// aiffChunk is a big endian chunk with its padding byte
+func aiffChunk(id string, data []byte) []byte {
+	out := append([]byte(id), 0, 0, 0, 0)
+	binary.BigEndian.PutUint32(out[4:], uint32(len(data)))
+	out = append(out, data...)
+	if len(data)%2 == 1 {
+		out = append(out, 0)
+	}
+	return out
+}
+
+func aiffBytes(form string, chunks ...[]byte) []byte {
+	body := []byte(form)
+	for _, c := range chunks {
+		body = append(body, c...)
+	}
+	return aiffChunk("FORM", body)
+}
+
+func TestReadID3FromAIFF(t *testing.T) {
+	tag := tagBytes(4, 0, frameBytes(4, "TIT2", []byte("\x03AIFF Title")), frameBytes(4, "TPE1", []byte("\x03AIFF Artist")))
+	comm := aiffChunk("COMM", make([]byte, 18))
+	// an odd sized chunk in front has a padding byte
+	odd := aiffChunk("NAME", []byte("odd"))
+	sound := aiffChunk("SSND", make([]byte, 64))
+	for _, form := range []string{"AIFF", "AIFC"} {
+		props, err := ReadID3FromAIFF(bytes.NewReader(aiffBytes(form, comm, odd, sound, aiffChunk("ID3 ", tag))))
+		if err != nil {
+			t.Fatalf("%s: failed read: %v", form, err)
+		}
+		if props["TIT2"] != "AIFF Title" || props["TPE1"] != "AIFF Artist" {
+			t.Errorf("%s: wrong tag %v", form, props)
+		}
+	}
+	props, err := ReadID3FromAIFF(bytes.NewReader(aiffBytes("AIFF", aiffChunk("id3 ", tag), comm)), WithFrames("TPE1"))
+	if err != nil || len(props) != 1 || props["TPE1"] != "AIFF Artist" {
+		t.Errorf("Wrong tag %v %v", props, err)
+	}
+
+	for name, file := range map[string][]byte{
+		"no chunk": aiffBytes("AIFF", comm, sound),
+		"wav":      wavBytes(riffChunk("id3 ", tag)),
+		"not aiff": aiffBytes("8SVX", aiffChunk("ID3 ", tag)),
+		"empty":    {},
+	} {
+		if _, err := ReadID3FromAIFF(bytes.NewReader(file)); !errors.Is(err, ErrNoTag) {
+			t.Errorf("%s: expected ErrNoTag got %v", name, err)
+		}
+	}
+
+	// exports that stopped early still say how big the chunks were
+	whole := aiffBytes("AIFF", comm, sound, aiffChunk("ID3 ", tag))
+	for _, cut := range []int{40, len(whole) - 10} {
+		_, err := ReadID3FromAIFF(bytes.NewReader(whole[:cut]))
+		if !errors.Is(err, ErrTruncated) {
+			t.Errorf("Cut at %d: expected ErrTruncated got %v", cut, err)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package easyid3
+
+import (
+	"errors"
+	"io"
+)
+
+// ReadAppendedTag reads a v2.4 tag appended to the end of the file, found
+// through its 3DI footer. An ID3v1 tag after it is skipped over.
+func ReadAppendedTag(rs io.ReadSeeker, opts ...Option) (*Tag, error) {
+	start, err := findAppendedTag(rs)
+	if err != nil {
+		return nil, err
+	}
+	_, err = rs.Seek(start, io.SeekStart)
+	if err != nil {
+		return nil, err
+	}
+	return ReadTag(rs, opts...)
+}
+
+// findAppendedTag returns the offset of the header that goes with the
+// footer at the end of the file
+func findAppendedTag(rs io.ReadSeeker) (int64, error) {
+	end, err := rs.Seek(0, io.SeekEnd)
+	if err != nil {
+		return 0, err
+	}
+	// appended tags go before an ID3v1 tag
+	end, err = id3v1Start(rs, end)
+	if err != nil {
+		return 0, err
+	}
+	footer, err := footerBefore(rs, end)
+	if err != nil {
+		return 0, err
+	}
+	if footer == nil {
+		return 0, notFoundError("ID3 footer")
+	}
+	start := end - 10 - int64(footer.Size) - 10
+	if start < 0 {
+		return 0, errors.New("ID3 footer size is bigger than the file")
+	}
+	return start, nil
+}
+
+// id3v1Start is where the 128 byte ID3v1 tag at the end starts, or end
+// when there isn't one
+func id3v1Start(rs io.ReadSeeker, end int64) (int64, error) {
+	if end < 128 {
+		return end, nil
+	}
+	buf := make([]byte, 3)
+	if _, err := rs.Seek(end-128, io.SeekStart); err != nil {
+		return 0, err
+	}
+	if _, err := io.ReadFull(rs, buf); err != nil {
+		return 0, err
+	}
+	if string(buf) == "TAG" {
+		return end - 128, nil
+	}
+	return end, nil
+}
+
+// footerBefore reads the footer in the 10 bytes before end, nil when
+// there isn't one
+func footerBefore(rs io.ReadSeeker, end int64) (*Header, error) {
+	if end < 20 {
+		return nil, nil
+	}
+	buf := make([]byte, 10)
+	if _, err := rs.Seek(end-10, io.SeekStart); err != nil {
+		return nil, err
+	}
+	if _, err := io.ReadFull(rs, buf); err != nil {
+		return nil, err
+	}
+	footer, err := newID3(buf)
+	if err != nil || !footer.IsFooter() {
+		return nil, nil
+	}
+	return footer, nil
+}
@@ -0,0 +1,61 @@
+package easyid3
+
+import (
+	"bytes"
+	"testing"
+)
+
+var fakeAudio = bytes.Repeat([]byte{0xff, 0xfb, 0x90, 0x64, 0x0, 0x0, 0x0, 0x0}, 64)
+
+func TestAppendedTag(t *testing.T) {
+	appended := appendedTagBytes(
+		frameBytes(4, "TIT2", []byte("\x03Appended\x00")),
+		make([]byte, 32),
+	)
+	file := append(append([]byte{}, fakeAudio...), appended...)
+	parsed, err := ReadAppendedTag(bytes.NewReader(file))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	if len(parsed.frames) != 1 || parsed.frames[0].Decoded() != "Appended" {
+		t.Fatalf("Wrong frames %v", parsed.frames)
+	}
+
+	// still found with an ID3v1 tag after it
+	v1 := append([]byte("TAG"), make([]byte, 125)...)
+	parsed, err = ReadAppendedTag(bytes.NewReader(append(file, v1...)))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	if len(parsed.frames) != 1 || parsed.frames[0].Decoded() != "Appended" {
+		t.Fatalf("Wrong frames %v", parsed.frames)
+	}
+
+	if _, err := ReadAppendedTag(bytes.NewReader(fakeAudio)); err == nil {
+		t.Fatal("Expected no footer error")
+	}
+	if _, err := ReadAppendedTag(bytes.NewReader(appended[len(appended)-12:])); err == nil {
+		t.Fatal("Expected size error")
+	}
+}
+
+func TestPrependedAndAppendedTags(t *testing.T) {
+	file := tagBytes(4, 0, frameBytes(4, "TIT2", []byte("\x03Prepended\x00")))
+	file = append(file, fakeAudio...)
+	file = append(file, appendedTagBytes(frameBytes(4, "TIT2", []byte("\x03Appended\x00")))...)
+
+	vals, err := ReadID3(bytes.NewReader(file))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	if vals["TIT2"] != "Prepended" {
+		t.Fatalf("Wrong prepended title %q", vals["TIT2"])
+	}
+	parsed, err := ReadAppendedTag(bytes.NewReader(file))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	if len(parsed.frames) != 1 || parsed.frames[0].Decoded() != "Appended" {
+		t.Fatalf("Wrong frames %v", parsed.frames)
+	}
+}
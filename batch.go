@@ -0,0 +1,128 @@
+package easyid3
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"sync"
+)
+
+// FileResult is what ReadID3Files got for one file, Err is what ReadID3File
+// would have returned with it
+type FileResult struct {
+	Props map[string]string
+	Err   error
+}
+
+// ReadID3Files reads each of paths the way ReadID3File does, up to
+// concurrency of them at a time, and keys the results by path. A file that
+// fails only fails its own result. Once ctx is done the files that haven't
+// been started get its error, the ones being read are finished. Each worker
+// reads the tags at the start of the files into a buffer it keeps for the
+// next file.
+func ReadID3Files(ctx context.Context, paths []string, concurrency int, opts ...Option) map[string]FileResult {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	results := make([]FileResult, len(paths))
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency && w < len(paths); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var buf []byte
+			for i := range next {
+				results[i].Props, results[i].Err = readBatchFile(paths[i], &buf, opts)
+			}
+		}()
+	}
+	for i := range paths {
+		if ctx.Err() == nil {
+			select {
+			case next <- i:
+				continue
+			case <-ctx.Done():
+			}
+		}
+		results[i].Err = ctx.Err()
+	}
+	close(next)
+	wg.Wait()
+
+	out := make(map[string]FileResult, len(paths))
+	for i, path := range paths {
+		out[path] = results[i]
+	}
+	return out
+}
+
+// readBatchFile is ReadID3File reading a tag at the start of the file into
+// buf, which grows to the biggest tag the worker has seen. Anything else is
+// read the usual way.
+func readBatchFile(path string, buf *[]byte, opts []Option) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	o := newOptions(opts)
+	// following SEEK frames and lazy frames need the file
+	if o.seekDepth > 0 || o.lazySize >= 0 {
+		return readAnyTag(f, opts)
+	}
+	b, ok, err := readTagInto(f, *buf, o.maxFrameSize)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			return nil, err
+		}
+		return readAnyTag(f, opts)
+	}
+	*buf = b[:0]
+	header, frames, err := readTagBytes(b, o)
+	if err != nil {
+		return nil, err
+	}
+	return frameMap(frames, o), header.frameErrors()
+}
+
+// readTagInto reads the tag at the start of r into buf. ok is false when
+// there isn't a whole one of no more than limit bytes, those are left to
+// the readers that stream.
+func readTagInto(r io.Reader, buf []byte, limit int) (b []byte, ok bool, err error) {
+	if cap(buf) < 10 {
+		buf = make([]byte, 4096)
+	}
+	b = buf[:10]
+	_, err = io.ReadFull(r, b)
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return b, false, nil
+	}
+	if err != nil {
+		return b, false, err
+	}
+	if string(b[:3]) != "ID3" {
+		return b, false, nil
+	}
+	header, err := newID3(b)
+	if err != nil {
+		return b, false, nil
+	}
+	size := header.TotalSize()
+	if size > limit {
+		return b, false, nil
+	}
+	if cap(buf) < size {
+		buf = append(buf[:10], make([]byte, size-10)...)
+	}
+	b = buf[:size]
+	_, err = io.ReadFull(r, b[10:])
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return b, false, nil
+	}
+	return b, err == nil, err
+}
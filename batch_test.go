@@ -0,0 +1,107 @@
+package easyid3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestReadID3Files(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string][]byte{
+		"prepended.mp3": append(append([]byte{}, typicalTag()...), fakeAudio...),
+		"appended.mp3":  append(append([]byte{}, fakeAudio...), appendedTagBytes(frameBytes(4, "TIT2", []byte("\x03Appended\x00")))...),
+		"v1.mp3":        append(append([]byte{}, fakeAudio...), id3v1Bytes("ID3v1", "", "", "", "", 0, 255)...),
+		"none.mp3":      fakeAudio,
+		"empty.mp3":     {},
+		"short.mp3":     typicalTag()[:100],
+		"footer.mp3":    appendedTagBytes(frameBytes(4, "TPE1", []byte("\x03Footer\x00"))),
+	}
+	var paths []string
+	for name, contents := range files {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, contents, 0644); err != nil {
+			t.Fatal(err)
+		}
+		paths = append(paths, path)
+	}
+	paths = append(paths, filepath.Join(dir, "missing.mp3"))
+
+	for _, concurrency := range []int{0, 3, 100} {
+		results := ReadID3Files(context.Background(), paths, concurrency)
+		if len(results) != len(paths) {
+			t.Errorf("Expected %d results got %d", len(paths), len(results))
+		}
+		for _, path := range paths {
+			props, err := ReadID3File(path)
+			got := results[path]
+			if !reflect.DeepEqual(got.Props, props) || fmt.Sprint(got.Err) != fmt.Sprint(err) {
+				t.Errorf("%s: got %v %v want %v %v", filepath.Base(path), got.Props, got.Err, props, err)
+			}
+		}
+	}
+	results := ReadID3Files(context.Background(), paths, 2, WithFrames("TIT2"))
+	if r := results[filepath.Join(dir, "prepended.mp3")]; len(r.Props) != 1 || r.Props["TIT2"] != "Aquarium" {
+		t.Errorf("Expected the options used got %v %v", r.Props, r.Err)
+	}
+	if r := results[filepath.Join(dir, "missing.mp3")]; !errors.Is(r.Err, os.ErrNotExist) {
+		t.Errorf("Expected a not exist error got %v", r.Err)
+	}
+}
+
+func TestReadID3FilesCancelled(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tagged.mp3")
+	if err := os.WriteFile(path, typicalTag(), 0644); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	results := ReadID3Files(ctx, []string{path}, 4)
+	if r := results[path]; !errors.Is(r.Err, context.Canceled) || r.Props != nil {
+		t.Errorf("Expected the context error got %v %v", r.Props, r.Err)
+	}
+	if results := ReadID3Files(context.Background(), nil, 4); len(results) != 0 {
+		t.Errorf("Expected nothing got %v", results)
+	}
+}
+
+// batchFiles writes n small tagged files
+func batchFiles(b *testing.B, n int) []string {
+	dir := b.TempDir()
+	paths := make([]string, n)
+	contents := append(append([]byte{}, typicalTag()...), fakeAudio...)
+	for i := range paths {
+		paths[i] = filepath.Join(dir, fmt.Sprintf("%03d.mp3", i))
+		if err := os.WriteFile(paths[i], contents, 0644); err != nil {
+			b.Fatal(err)
+		}
+	}
+	return paths
+}
+
+func BenchmarkReadID3Files(b *testing.B) {
+	paths := batchFiles(b, 300)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ReadID3Files(context.Background(), paths, 8)
+	}
+}
+
+// BenchmarkReadID3FileLoop is the same files one at a time with ReadID3File
+func BenchmarkReadID3FileLoop(b *testing.B) {
+	paths := batchFiles(b, 300)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, path := range paths {
+			if _, err := ReadID3File(path); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
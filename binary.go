@@ -0,0 +1,62 @@
+package easyid3
+
+// MusicBrainzOwner is the UFID owner Picard uses for recording IDs
+const MusicBrainzOwner = "http://musicbrainz.org"
+
+// UniqueFileID is an UFID frame, Identifier is up to 64 bytes of whatever
+// the owner uses to identify the file.
+type UniqueFileID struct {
+	Owner      string
+	Identifier []byte
+}
+
+// parseUniqueFileID reads the terminated owner and then the identifier
+func parseUniqueFileID(data []byte) UniqueFileID {
+	owner, id := splitTerminated(encodingISO88591, data)
+	return UniqueFileID{
+		Owner:      decodeLatin1(owner),
+		Identifier: id,
+	}
+}
+
+// Private is a PRIV frame, Data is only meaningful to the owner
+type Private struct {
+	Owner string
+	Data  []byte
+}
+
+// parsePrivate reads the terminated owner and then the data
+func parsePrivate(data []byte) Private {
+	owner, rest := splitTerminated(encodingISO88591, data)
+	return Private{
+		Owner: decodeLatin1(owner),
+		Data:  rest,
+	}
+}
+
+// Object is a GEOB frame, DJ software keeps cue points and beatgrids in
+// these told apart by Description.
+type Object struct {
+	MIMEType    string
+	Filename    string
+	Description string
+	Data        []byte
+}
+
+// parseObject reads the encoding byte, the terminated MIME type (always
+// ISO-8859-1), filename and description and then the data.
+func parseObject(data []byte) Object {
+	var o Object
+	if len(data) == 0 {
+		return o
+	}
+	enc := data[0]
+	mime, data := splitTerminated(encodingISO88591, data[1:])
+	filename, data := splitTerminated(enc, data)
+	desc, data := splitTerminated(enc, data)
+	o.MIMEType = decodeLatin1(mime)
+	o.Filename = decodeText(enc, filename)
+	o.Description = decodeText(enc, desc)
+	o.Data = data
+	return o
+}
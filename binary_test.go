@@ -0,0 +1,167 @@
+package easyid3
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestUniqueFileIDs(t *testing.T) {
+	binaryID := make([]byte, 64)
+	for i := range binaryID {
+		binaryID[i] = byte(i * 7)
+	}
+	tag := tagBytes(4, 0,
+		frameBytes(4, "UFID", append([]byte("http://www.cddb.com/id3/taginfo1.html\x00"), binaryID...)),
+		frameBytes(4, "UFID", []byte(MusicBrainzOwner+"\x0026f9276e-9c2e-4d4c-9c3c-8a1f3a8d7f3e")),
+	)
+	parsed, err := ReadTag(bytes.NewReader(tag))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	ids := parsed.UniqueFileIDs()
+	if len(ids) != 2 {
+		t.Fatalf("Expected 2 UFIDs got %+v", ids)
+	}
+	if ids[0].Owner != "http://www.cddb.com/id3/taginfo1.html" || !bytes.Equal(ids[0].Identifier, binaryID) {
+		t.Errorf("Wrong UFID %+v", ids[0])
+	}
+	if id := parsed.MusicBrainzRecordingID(); id != "26f9276e-9c2e-4d4c-9c3c-8a1f3a8d7f3e" {
+		t.Errorf("Wrong MusicBrainz ID %q", id)
+	}
+
+	parsed, err = ReadTag(bytes.NewReader(tagBytes(4, 0, frameBytes(4, "TIT2", []byte{3, 'x'}))))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	if id := parsed.MusicBrainzRecordingID(); id != "" {
+		t.Errorf("Expected no MusicBrainz ID got %q", id)
+	}
+}
+
+func TestPrivate(t *testing.T) {
+	waveform := bytes.Repeat([]byte{0x0, 0x1, 0xff, 0x80}, 64*1024)
+	tag := tagBytes(4, 0,
+		frameBytes(4, "PRIV", []byte("WM/MediaClassPrimaryID\x00\xbc\x7d\x60\xd1\x23\xe3\xe2\x4b\x86\xa1\x48\xa4\x2a\x28\x44\x1e")),
+		frameBytes(4, "PRIV", append([]byte("Serato Overview\x00"), waveform...)),
+		frameBytes(4, "PRIV", []byte("WM/MediaClassPrimaryID\x00\x01")),
+	)
+	parsed, err := ReadTag(bytes.NewReader(tag))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	private := parsed.Private()
+	if len(private) != 3 {
+		t.Fatalf("Expected 3 PRIV frames got %d", len(private))
+	}
+	if private[0].Owner != "WM/MediaClassPrimaryID" || len(private[0].Data) != 16 {
+		t.Errorf("Wrong PRIV %+v", private[0])
+	}
+	if private[1].Owner != "Serato Overview" || !bytes.Equal(private[1].Data, waveform) {
+		t.Errorf("Wrong PRIV %s %d bytes", private[1].Owner, len(private[1].Data))
+	}
+	if private[2].Owner != "WM/MediaClassPrimaryID" || !bytes.Equal(private[2].Data, []byte{1}) {
+		t.Errorf("Wrong PRIV %+v", private[2])
+	}
+
+	vals, err := ReadID3(bytes.NewReader(tag))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	if vals["PRIV"] != "WM/MediaClassPrimaryID" {
+		t.Errorf("Expected the owner in the map got %q", vals["PRIV"])
+	}
+
+	if _, err := ReadTag(bytes.NewReader(tag), WithMaxFrameSize(128*1024)); err == nil {
+		t.Errorf("Expected the waveform to go over the frame limit")
+	}
+}
+
+func geobData(enc byte, mime string, filename, desc []byte, data []byte) []byte {
+	out := append([]byte{enc}, mime...)
+	out = append(out, 0)
+	out = append(out, filename...)
+	out = append(out, desc...)
+	return append(out, data...)
+}
+
+func TestObjects(t *testing.T) {
+	markers := []byte{0x1, 0x1, 0x0, 0x0, 0x0, 0x0, 0xff}
+	tag := tagBytes(4, 0,
+		frameBytes(4, "GEOB", geobData(0, "application/octet-stream", []byte{0}, []byte("Serato Markers_\x00"), markers)),
+		frameBytes(4, "GEOB", geobData(1, "application/octet-stream",
+			append(utf16LE("grid.bin"), 0, 0), append(utf16LE("Serato BeatGrid"), 0, 0), []byte{0x1, 0x0, 0x0})),
+	)
+	parsed, err := ReadTag(bytes.NewReader(tag))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	objects := parsed.Objects()
+	if len(objects) != 2 {
+		t.Fatalf("Expected 2 objects got %+v", objects)
+	}
+	o := objects[0]
+	if o.MIMEType != "application/octet-stream" || o.Filename != "" || o.Description != "Serato Markers_" || !bytes.Equal(o.Data, markers) {
+		t.Errorf("Wrong object %+v", o)
+	}
+	grid := parsed.Object("Serato BeatGrid")
+	if grid == nil || grid.Filename != "grid.bin" || !bytes.Equal(grid.Data, []byte{0x1, 0x0, 0x0}) {
+		t.Errorf("Wrong object %+v", grid)
+	}
+	if parsed.Object("missing") != nil {
+		t.Errorf("Expected no object")
+	}
+	for _, data := range [][]byte{{}, {0}, {0, 'a'}, {1, 'a', 0, 'b'}} {
+		parseObject(data)
+	}
+}
+
+func TestOpaqueFrames(t *testing.T) {
+	// a CD TOC with bytes that need unsynchronising and a NUL up front
+	toc := []byte{0x00, 0x01, 0x0c, 0xff, 0x00, 0x01, 0x00, 0xff, 0xe0, 0x00, 0x00, 0x00, 0x96}
+	tag := tagBytes(4, 0,
+		frameBytes(4, "TIT2", []byte("\x03Title\x00")),
+		flaggedFrameBytes(4, "MCDI", 0, 0x02, unsyncBytes(toc)),
+		frameBytes(4, "SIGN", []byte("\x01\x03signature")),
+		frameBytes(4, "PRIV", []byte("owner\x00\x01\x02")),
+		frameBytes(4, "XYZW", []byte("\x03looks like text\x00")),
+	)
+	parsed, err := ReadTag(bytes.NewReader(tag))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	if !bytes.Equal(parsed.MusicCDID(), toc) {
+		t.Errorf("MCDI changed got %x want %x", parsed.MusicCDID(), toc)
+	}
+	if d := parsed.BinaryData("SIGN"); len(d) != 1 || string(d[0]) != "\x01\x03signature" {
+		t.Errorf("Wrong SIGN %q", d)
+	}
+	if d := parsed.BinaryData("TIT2"); d != nil {
+		t.Errorf("TIT2 isn't binary got %q", d)
+	}
+	if parsed.frames[1].Decoded() != "" {
+		t.Errorf("Expected no text for MCDI got %q", parsed.frames[1].Decoded())
+	}
+
+	vals, err := ReadID3(bytes.NewReader(tag))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	want := map[string]string{"TIT2": "Title", "PRIV": "owner"}
+	if len(vals) != len(want) || vals["TIT2"] != "Title" || vals["PRIV"] != "owner" {
+		t.Errorf("Expected %q got %q", want, vals)
+	}
+
+	raw, err := ReadID3Raw(bytes.NewReader(tag))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	kinds := []FrameKind{KindText, KindBinary, KindBinary, KindBinary, KindBinary}
+	for i, rf := range raw {
+		if rf.Kind() != kinds[i] {
+			t.Errorf("%s is kind %d", rf.ID, rf.Kind())
+		}
+	}
+	if f, err := raw[1].Frame(); err != nil || !bytes.Equal(f.Data, toc) {
+		t.Errorf("Wrong MCDI from the raw frame %x %v", f.Data, err)
+	}
+}
@@ -0,0 +1,99 @@
+package easyid3
+
+import (
+	"bytes"
+	"io"
+)
+
+// ReadID3Bytes is ReadID3 for a tag already in memory, b starts with the
+// header. The frames are read straight out of b without copying them so
+// only the strings in the map get allocated. SEEK frames aren't followed.
+func ReadID3Bytes(b []byte, opts ...Option) (map[string]string, error) {
+	o := newOptions(opts)
+	header, frames, err := readTagBytes(b, o)
+	if err != nil {
+		return nil, err
+	}
+	return frameMap(frames, o), header.frameErrors()
+}
+
+// ReadTagBytes is ReadTag for a tag in memory the way ReadID3Bytes is. The
+// Data of the frames is b itself wherever a frame didn't need its format
+// flags undone, so b can't change while the tag is used.
+func ReadTagBytes(b []byte, opts ...Option) (*Tag, error) {
+	o := newOptions(opts)
+	o.tagResult = true
+	header, frames, err := readTagBytes(b, o)
+	if err != nil {
+		return nil, err
+	}
+	return &Tag{header: header, frames: frames, opts: o}, header.frameErrors()
+}
+
+func readTagBytes(b []byte, o *options) (*Header, []*Frame, error) {
+	if !bytes.HasPrefix(b, []byte("ID3")) {
+		return nil, nil, notFoundError("ID3 header")
+	}
+	if len(b) < 10 {
+		return nil, nil, truncated("ID3 header")
+	}
+	header, err := newID3(b[:10])
+	if err != nil {
+		return nil, nil, err
+	}
+	frames, err := readBody(&sliceReader{b: b[10:]}, header, o)
+	if err != nil {
+		return nil, nil, err
+	}
+	return header, frames, nil
+}
+
+// sliceReader reads from a slice and lets the frame loop take sub-slices of
+// it instead of reading into a copy
+type sliceReader struct {
+	b []byte
+}
+
+func (r *sliceReader) Read(p []byte) (int, error) {
+	if len(r.b) == 0 {
+		return 0, io.EOF
+	}
+	n := copy(p, r.b)
+	r.b = r.b[n:]
+	return n, nil
+}
+
+// peeker is a body reader endsFrame can look ahead in without reading what
+// it skips over
+type peeker interface {
+	// peekAt is the n bytes off ahead, nil when there aren't that many
+	peekAt(off int64, n int) ([]byte, error)
+}
+
+func (r *sliceReader) peekAt(off int64, n int) ([]byte, error) {
+	if off+int64(n) > int64(len(r.b)) {
+		return nil, nil
+	}
+	return r.b[off : off+int64(n)], nil
+}
+
+// inMemory is the slice body reads from when it's reading one directly
+// with nothing like unsynchronisation in between, nil otherwise. At most
+// body.N of it belongs to the tag.
+func inMemory(body *io.LimitedReader) *sliceReader {
+	sr, _ := body.R.(*sliceReader)
+	return sr
+}
+
+// take is the next n bytes of body as they are in memory, nil when they
+// have to be read
+func take(body *io.LimitedReader, n int) []byte {
+	sr := inMemory(body)
+	if sr == nil || n > len(sr.b) || int64(n) > body.N {
+		return nil
+	}
+	b := sr.b[:n:n]
+	sr.b = sr.b[n:]
+	body.N -= int64(n)
+	return b
+}
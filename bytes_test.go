@@ -0,0 +1,91 @@
+package easyid3
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestReadID3Bytes(t *testing.T) {
+	unsynced := tagBytes(4, 0x80,
+		frameBytes(4, "TIT2", []byte("\x03Cover\x00")),
+		frameBytes(4, "APIC", unsyncBytes(apicData(0, "image/jpeg", 3, []byte("\x00"), syncyJPEG))),
+	)
+	for name, tag := range map[string][]byte{
+		"ivs":      ivsID3,
+		"v2.2":     v22ID3,
+		"typical":  typicalTag(),
+		"artwork":  artworkTag(),
+		"unsynced": unsynced,
+	} {
+		want, err := ReadID3(bytes.NewReader(tag))
+		if err != nil {
+			t.Fatalf("%s: Failed read: %v", name, err)
+		}
+		got, err := ReadID3Bytes(tag)
+		if err != nil || !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: Wrong values\ngot  %q %v\nwant %q", name, got, err, want)
+		}
+	}
+
+	// the frames are the input, apart from the unsynchronised ones which
+	// are undone in a copy
+	in := append([]byte{}, unsynced...)
+	parsed, err := ReadTagBytes(in)
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	if !bytes.Equal(in, unsynced) {
+		t.Error("Input changed by the read")
+	}
+	if p := parsed.Pictures(); len(p) != 1 || !bytes.Equal(p[0].Data, syncyJPEG) {
+		t.Errorf("Wrong pictures %+v", p)
+	}
+	in = typicalTag()
+	parsed, err = ReadTagBytes(in)
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	copy(in[21:], "B")
+	if parsed.Title() != "Bquarium" {
+		t.Errorf("Expected the frame to alias the input got %q", parsed.Title())
+	}
+
+	if _, err := ReadID3Bytes([]byte("TAG")); !errors.Is(err, ErrNoTag) {
+		t.Errorf("Expected no tag got %v", err)
+	}
+	if _, err := ReadID3Bytes([]byte("ID3\x04")); !errors.Is(err, ErrTruncated) {
+		t.Errorf("Expected a truncated header got %v", err)
+	}
+	if _, err := ReadID3Bytes(typicalTag()[:100]); !errors.Is(err, ErrTruncated) {
+		t.Errorf("Expected a truncated tag got %v", err)
+	}
+}
+
+// bigArtworkTag is about 100KB, mostly the picture
+func bigArtworkTag() []byte {
+	art := frameBytes(4, "APIC", apicData(0, "image/jpeg", PictureTypeFrontCover, []byte("\x00"), bytes.Repeat([]byte{0xaa}, 100<<10)))
+	// the typical frames and their padding go after it
+	return tagBytes(4, 0, art, typicalTag()[10:])
+}
+
+func BenchmarkReadID3Reader(b *testing.B) {
+	tag := bigArtworkTag()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := ReadID3(bytes.NewReader(tag)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkReadID3Bytes(b *testing.B) {
+	tag := bigArtworkTag()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := ReadID3Bytes(tag); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
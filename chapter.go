@@ -0,0 +1,150 @@
+package easyid3
+
+import (
+	"io"
+	"strings"
+	"time"
+)
+
+// Chapter is a CHAP frame. The offsets are bytes from the start of the file,
+// the tag included, and are 0xFFFFFFFF when the times should be used
+// instead. Title and URL come from the TIT2 and WXXX frames embedded in the
+// chapter.
+type Chapter struct {
+	ID          string
+	StartTime   time.Duration
+	EndTime     time.Duration
+	StartOffset uint32
+	EndOffset   uint32
+	Title       string
+	URL         string
+}
+
+// TableOfContents is a CTOC frame, Children are the IDs of chapters or
+// other tables of contents.
+type TableOfContents struct {
+	ID       string
+	TopLevel bool
+	Ordered  bool
+	Children []string
+	Title    string
+}
+
+// parseChapter reads the terminated element ID, the start and end times in
+// milliseconds, the start and end offsets and then the embedded frames.
+func parseChapter(data []byte, version byte, o *options) Chapter {
+	var c Chapter
+	id, data := splitTerminated(encodingISO88591, data)
+	c.ID = decodeLatin1(id)
+	if len(data) < 16 {
+		return c
+	}
+	c.StartTime = time.Duration(beInt(data[0:4])) * time.Millisecond
+	c.EndTime = time.Duration(beInt(data[4:8])) * time.Millisecond
+	c.StartOffset = uint32(beInt(data[8:12]))
+	c.EndOffset = uint32(beInt(data[12:16]))
+	for _, f := range subFrames(data[16:], version, o) {
+		switch {
+		case f.FrameID == "TIT2":
+			c.Title = f.Decoded()
+		case f.FrameID == "WXXX":
+			c.URL = f.Decoded()
+		case strings.HasPrefix(f.FrameID, "W") && c.URL == "":
+			c.URL = f.Decoded()
+		}
+	}
+	return c
+}
+
+// parseTableOfContents reads the terminated element ID, the flags, the
+// count of children and their terminated IDs and then the embedded frames.
+func parseTableOfContents(data []byte, version byte, o *options) TableOfContents {
+	var toc TableOfContents
+	id, data := splitTerminated(encodingISO88591, data)
+	toc.ID = decodeLatin1(id)
+	if len(data) < 2 {
+		return toc
+	}
+	toc.TopLevel = data[0]&0x02 != 0
+	toc.Ordered = data[0]&0x01 != 0
+	count := int(data[1])
+	data = data[2:]
+	for i := 0; i < count && len(data) > 0; i++ {
+		var child []byte
+		child, data = splitTerminated(encodingISO88591, data)
+		toc.Children = append(toc.Children, decodeLatin1(child))
+	}
+	for _, f := range subFrames(data, version, o) {
+		if f.FrameID == "TIT2" {
+			toc.Title = f.Decoded()
+		}
+	}
+	return toc
+}
+
+// subFrames reads the frames embedded in a CHAP or CTOC with the options
+// the tag was read with. WithFrames picks the top level frames so it's left
+// out, otherwise asking for CHAP would lose the chapter titles. Only the top
+// level frames are spilled.
+func subFrames(data []byte, version byte, o *options) []*Frame {
+	body := &io.LimitedReader{R: &sliceReader{b: data}, N: int64(len(data))}
+	sub := *o
+	sub.frames = nil
+	sub.tagResult = false
+	o = &sub
+	frames, _ := readFrames(body, &Header{Version: []byte{version, 0}}, o)
+	frames, _ = decryptFrames(frames, o)
+	return frames
+}
+
+// orderChapters puts the chapters in the order of the top level table of
+// contents, following nested tables. Chapters it doesn't mention go at the
+// end in the order they're in the tag.
+func orderChapters(chapters []Chapter, tocs []TableOfContents) []Chapter {
+	if len(tocs) == 0 {
+		return chapters
+	}
+	root := tocs[0]
+	for _, toc := range tocs {
+		if toc.TopLevel {
+			root = toc
+			break
+		}
+	}
+	byID := map[string]int{}
+	for i, c := range chapters {
+		if _, ok := byID[c.ID]; !ok {
+			byID[c.ID] = i
+		}
+	}
+	tocByID := map[string]TableOfContents{}
+	for _, toc := range tocs {
+		tocByID[toc.ID] = toc
+	}
+
+	ordered := make([]Chapter, 0, len(chapters))
+	used := make([]bool, len(chapters))
+	// visited stops tables that include each other from looping
+	visited := map[string]bool{}
+	var walk func(toc TableOfContents)
+	walk = func(toc TableOfContents) {
+		visited[toc.ID] = true
+		for _, child := range toc.Children {
+			if i, ok := byID[child]; ok {
+				if !used[i] {
+					used[i] = true
+					ordered = append(ordered, chapters[i])
+				}
+			} else if nested, ok := tocByID[child]; ok && !visited[child] {
+				walk(nested)
+			}
+		}
+	}
+	walk(root)
+	for i, c := range chapters {
+		if !used[i] {
+			ordered = append(ordered, c)
+		}
+	}
+	return ordered
+}
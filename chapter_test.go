@@ -0,0 +1,107 @@
+package easyid3
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func chapData(id string, start, end uint32, frames ...[]byte) []byte {
+	out := append([]byte(id), 0)
+	for _, v := range []uint32{start, end, 0xffffffff, 0xffffffff} {
+		out = append(out, byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+	}
+	for _, f := range frames {
+		out = append(out, f...)
+	}
+	return out
+}
+
+func ctocData(id string, flags byte, children []string, frames ...[]byte) []byte {
+	out := append([]byte(id), 0, flags, byte(len(children)))
+	for _, c := range children {
+		out = append(out, c...)
+		out = append(out, 0)
+	}
+	for _, f := range frames {
+		out = append(out, f...)
+	}
+	return out
+}
+
+func TestChapters(t *testing.T) {
+	tag := tagBytes(4, 0,
+		frameBytes(4, "TIT2", []byte("\x03Episode 12\x00")),
+		frameBytes(4, "CHAP", chapData("chp2", 300000, 450000,
+			frameBytes(4, "TIT2", []byte("\x03Outro\x00")),
+		)),
+		frameBytes(4, "CHAP", chapData("chp0", 0, 60000,
+			frameBytes(4, "TIT2", []byte("\x03Intro\x00")),
+			frameBytes(4, "WXXX", []byte("\x03\x00https://example.com/intro")),
+		)),
+		frameBytes(4, "CHAP", chapData("chp1", 60000, 300000,
+			frameBytes(4, "TIT2", []byte("\x01\xff\xfeM\x00a\x00i\x00n\x00\x00\x00")),
+		)),
+		frameBytes(4, "CTOC", ctocData("toc", 0x03, []string{"chp0", "chp1", "chp2"},
+			frameBytes(4, "TIT2", []byte("\x03Chapters\x00")),
+		)),
+	)
+	parsed, err := ReadTag(bytes.NewReader(tag))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	chapters := parsed.Chapters()
+	expected := []Chapter{
+		{ID: "chp0", StartTime: 0, EndTime: time.Minute, StartOffset: 0xffffffff, EndOffset: 0xffffffff, Title: "Intro", URL: "https://example.com/intro"},
+		{ID: "chp1", StartTime: time.Minute, EndTime: 5 * time.Minute, StartOffset: 0xffffffff, EndOffset: 0xffffffff, Title: "Main"},
+		{ID: "chp2", StartTime: 5 * time.Minute, EndTime: 7*time.Minute + 30*time.Second, StartOffset: 0xffffffff, EndOffset: 0xffffffff, Title: "Outro"},
+	}
+	if len(chapters) != len(expected) {
+		t.Fatalf("Expected %d chapters got %+v", len(expected), chapters)
+	}
+	for i, c := range expected {
+		if chapters[i] != c {
+			t.Errorf("Expected %+v got %+v", c, chapters[i])
+		}
+	}
+	tocs := parsed.TablesOfContents()
+	if len(tocs) != 1 || !tocs[0].TopLevel || !tocs[0].Ordered || tocs[0].Title != "Chapters" || len(tocs[0].Children) != 3 {
+		t.Errorf("Wrong table of contents %+v", tocs)
+	}
+}
+
+func TestNestedTablesOfContents(t *testing.T) {
+	chapters := []Chapter{{ID: "c"}, {ID: "a"}, {ID: "b"}, {ID: "loose"}}
+	tocs := []TableOfContents{
+		{ID: "part2", Children: []string{"c", "root"}},
+		{ID: "root", TopLevel: true, Children: []string{"part1", "part2", "missing"}},
+		{ID: "part1", Children: []string{"a", "b", "a"}},
+	}
+	ordered := orderChapters(chapters, tocs)
+	var ids []string
+	for _, c := range ordered {
+		ids = append(ids, c.ID)
+	}
+	if len(ids) != 4 || ids[0] != "a" || ids[1] != "b" || ids[2] != "c" || ids[3] != "loose" {
+		t.Fatalf("Wrong order %v", ids)
+	}
+}
+
+func TestChaptersWithoutTableOfContents(t *testing.T) {
+	tag := tagBytes(3, 0,
+		frameBytes(3, "CHAP", chapData("b", 10, 20)),
+		frameBytes(3, "CHAP", chapData("a", 0, 10, frameBytes(3, "TIT2", []byte("\x00First\x00")))),
+	)
+	parsed, err := ReadTag(bytes.NewReader(tag))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	chapters := parsed.Chapters()
+	if len(chapters) != 2 || chapters[0].ID != "b" || chapters[1].Title != "First" {
+		t.Fatalf("Wrong chapters %+v", chapters)
+	}
+	for _, data := range [][]byte{{}, {'x'}, {'x', 0, 1, 2}} {
+		parseChapter(data, 4, newOptions(nil))
+		parseTableOfContents(data, 4, newOptions(nil))
+	}
+}
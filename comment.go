@@ -0,0 +1,29 @@
+package easyid3
+
+// Comment is a COMM frame. Language is the ISO-639-2 code and Description
+// tells comments apart, iTunes uses iTunNORM for its volume data.
+type Comment struct {
+	Language    Language
+	Description string
+	Text        string
+}
+
+// parseComment reads the encoding byte, 3 byte language, the terminated
+// description and then the text.
+func parseComment(data []byte) Comment {
+	var c Comment
+	if len(data) == 0 {
+		return c
+	}
+	enc := data[0]
+	data = data[1:]
+	if len(data) < 3 {
+		c.Language = LanguageUndetermined
+		return c
+	}
+	c.Language = parseLanguage(data[:3])
+	desc, text := splitTerminated(enc, data[3:])
+	c.Description = decodeText(enc, desc)
+	c.Text = decodeText(enc, text)
+	return c
+}
@@ -0,0 +1,105 @@
+package easyid3
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+// what iTunes writes, the normalization data next to the real comment
+var iTunesComments = tagBytes(3, 0,
+	frameBytes(3, "TIT2", []byte("\x00Title\x00")),
+	frameBytes(3, "COMM", []byte("\x00engiTunNORM\x00 0000044E 00000401 00002A3B 00002C0A 00016B7F\x00")),
+	frameBytes(3, "COMM", []byte("\x01eng\xff\xfe\x00\x00\xff\xfeG\x00r\x00e\x00a\x00t\x00 \x00s\x00o\x00n\x00g\x00\x00\x00")),
+)
+
+func TestCommentsMap(t *testing.T) {
+	vals, err := ReadID3(bytes.NewReader(iTunesComments))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	if v := vals["COMM:eng:"]; v != "Great song" {
+		t.Errorf("Wrong comment %q", v)
+	}
+	if v := vals["COMM:eng:iTunNORM"]; v != " 0000044E 00000401 00002A3B 00002C0A 00016B7F" {
+		t.Errorf("Wrong iTunNORM %q", v)
+	}
+}
+
+func TestComments(t *testing.T) {
+	tag, err := ReadTag(bytes.NewReader(iTunesComments))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	comments := tag.Comments()
+	expected := []Comment{
+		{Language: "eng", Description: "iTunNORM", Text: " 0000044E 00000401 00002A3B 00002C0A 00016B7F"},
+		{Language: "eng", Description: "", Text: "Great song"},
+	}
+	if len(comments) != len(expected) {
+		t.Fatalf("Expected %d comments got %v", len(expected), comments)
+	}
+	for i, c := range expected {
+		if comments[i] != c {
+			t.Errorf("Expected %+v got %+v", c, comments[i])
+		}
+	}
+}
+
+func TestShortComments(t *testing.T) {
+	for _, data := range [][]byte{{}, {0}, {3, 'e', 'n'}, {3, 'e', 'n', 'g'}, {1, 'e', 'n', 'g', 0xff}} {
+		c := parseComment(data)
+		if c.Text != "" || c.Description != "" {
+			t.Errorf("%v: expected empty comment got %+v", data, c)
+		}
+	}
+}
+
+func TestLanguages(t *testing.T) {
+	tag := tagBytes(4, 0,
+		frameBytes(4, "COMM", []byte("\x03ENG\x00Upper")),
+		frameBytes(4, "COMM", []byte("\x03XXX\x00Unknown")),
+		frameBytes(4, "COMM", []byte("\x03\x00\x00\x00\x00Nulls")),
+		frameBytes(4, "COMM", []byte("\x03deu\x00German")),
+		frameBytes(4, "COMM", []byte("\x03e\x00")),
+		frameBytes(4, "USLT", []byte("\x03fr1\x00Junk")),
+		frameBytes(4, "USLT", []byte("\x03fra\x00Paroles")),
+	)
+	parsed, err := ReadTag(bytes.NewReader(tag))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	var langs []Language
+	for _, c := range parsed.Comments() {
+		langs = append(langs, c.Language)
+	}
+	if want := []Language{"eng", "und", "und", "deu", "und"}; !reflect.DeepEqual(langs, want) {
+		t.Errorf("Wrong languages got %q want %q", langs, want)
+	}
+	if c := parsed.Comments("eng"); len(c) != 1 || c[0].Text != "Upper" {
+		t.Errorf("Wrong English comments %+v", c)
+	}
+	if c := parsed.Comments("DEU", LanguageUndetermined); len(c) != 4 {
+		t.Errorf("Wrong comments %+v", c)
+	}
+	if l := parsed.Lyrics("fra"); len(l) != 1 || l[0].Lyrics != "Paroles" {
+		t.Errorf("Wrong French lyrics %+v", l)
+	}
+	if l := parsed.Lyrics(LanguageUndetermined); len(l) != 1 || l[0].Lyrics != "Junk" {
+		t.Errorf("Wrong undetermined lyrics %+v", l)
+	}
+
+	edit := NewTag()
+	if err := edit.SetComment("ENG", "", "x"); err != nil {
+		t.Fatal(err)
+	}
+	if err := edit.SetComment("", "d", "y"); err != nil {
+		t.Fatal(err)
+	}
+	if err := edit.SetComment("en", "", "z"); err == nil {
+		t.Error("Expected an error for a 2 letter language")
+	}
+	if c := rewrite(t, edit).Comments(); len(c) != 2 || c[0].Language != "eng" || c[1].Language != LanguageUndetermined {
+		t.Errorf("Wrong comments %+v", c)
+	}
+}
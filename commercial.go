@@ -0,0 +1,94 @@
+package easyid3
+
+import (
+	"strings"
+	"time"
+)
+
+// COMR received as types
+const (
+	ReceivedAsOther byte = iota
+	ReceivedAsCDAlbum
+	ReceivedAsCompressedCD
+	ReceivedAsFile
+	ReceivedAsStream
+	ReceivedAsNoteSheets
+	ReceivedAsNoteSheetsBook
+	ReceivedAsOtherMedia
+	ReceivedAsMerchandise
+)
+
+// Commercial is a COMR frame. ValidUntil is zero when the frame's date
+// isn't one, Logo is the seller's logo image when there is one.
+type Commercial struct {
+	Prices       []Price
+	ValidUntil   time.Time
+	ContactURL   string
+	ReceivedAs   byte
+	Seller       string
+	Description  string
+	LogoMIMEType string
+	Logo         []byte
+}
+
+// Price is one of the prices in a COMR, a three letter ISO-4217 currency
+// code and the amount as written like "2.00"
+type Price struct {
+	Currency string
+	Amount   string
+}
+
+// parsePrices splits "usd2.00/eur1.80" into its prices
+func parsePrices(s string) []Price {
+	var prices []Price
+	for _, p := range strings.Split(s, "/") {
+		if len(p) < 3 {
+			continue
+		}
+		prices = append(prices, Price{Currency: strings.ToUpper(p[:3]), Amount: p[3:]})
+	}
+	return prices
+}
+
+// parseCommercial reads the encoding, the ISO-8859-1 prices, the 8
+// character date and the contact URL, the received as byte, then the
+// seller and description in the frame's encoding and last the logo's MIME
+// type and the logo
+func parseCommercial(data []byte) Commercial {
+	var c Commercial
+	if len(data) == 0 {
+		return c
+	}
+	enc := data[0]
+	price, data := splitTerminated(encodingISO88591, data[1:])
+	c.Prices = parsePrices(decodeLatin1(price))
+	if len(data) < 8 {
+		return c
+	}
+	c.ValidUntil, _ = time.Parse("20060102", string(data[:8]))
+	url, data := splitTerminated(encodingISO88591, data[8:])
+	c.ContactURL = decodeLatin1(url)
+	if len(data) == 0 {
+		return c
+	}
+	c.ReceivedAs = data[0]
+	seller, data := splitTerminated(enc, data[1:])
+	c.Seller = decodeText(enc, seller)
+	desc, data := splitTerminated(enc, data)
+	c.Description = decodeText(enc, desc)
+	mime, logo := splitTerminated(encodingISO88591, data)
+	c.LogoMIMEType = decodeLatin1(mime)
+	if len(logo) > 0 {
+		c.Logo = logo
+	}
+	return c
+}
+
+// Commercials returns all the COMR frames
+func (t *Tag) Commercials() []Commercial {
+	var commercials []Commercial
+	for _, f := range t.find("COMR") {
+		commercials = append(commercials, parseCommercial(f.Data))
+	}
+	return commercials
+}
@@ -0,0 +1,45 @@
+package easyid3
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCommercials(t *testing.T) {
+	utf16 := func(s string) []byte {
+		out := []byte{0xff, 0xfe}
+		for _, r := range s {
+			out = append(out, byte(r), 0)
+		}
+		return append(out, 0, 0)
+	}
+	full := []byte("\x01usd2.00/EUR1.80\x0020211231http://shop.example.com\x00\x03")
+	full = append(full, utf16("Seller")...)
+	full = append(full, utf16("Promo copy")...)
+	full = append(full, "image/png\x00\x89PNG\x00\x00"...)
+	tag := tagBytes(4, 0,
+		frameBytes(4, "COMR", full),
+		frameBytes(4, "COMR", []byte("\x03gbp0.99\x00someday\x00")),
+	)
+	parsed, err := ReadTag(bytes.NewReader(tag))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	want := []Commercial{{
+		Prices:       []Price{{"USD", "2.00"}, {"EUR", "1.80"}},
+		ValidUntil:   time.Date(2021, 12, 31, 0, 0, 0, 0, time.UTC),
+		ContactURL:   "http://shop.example.com",
+		ReceivedAs:   ReceivedAsFile,
+		Seller:       "Seller",
+		Description:  "Promo copy",
+		LogoMIMEType: "image/png",
+		Logo:         []byte("\x89PNG\x00\x00"),
+	}, {
+		Prices: []Price{{"GBP", "0.99"}},
+	}}
+	if got := parsed.Commercials(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Wrong commercials\ngot  %+v\nwant %+v", got, want)
+	}
+}
@@ -0,0 +1,60 @@
+package easyid3
+
+import "fmt"
+
+// Popularimeter is a POPM frame, each player that rates writes its own
+// keyed by Email.
+type Popularimeter struct {
+	Email   string
+	Rating  uint8
+	Counter uint64
+}
+
+// Stars maps the rating onto 0 to 5 stars the same way Windows Media Player
+// writes them, 1, 64, 128, 196 and 255.
+func (p Popularimeter) Stars() int {
+	switch {
+	case p.Rating == 0:
+		return 0
+	case p.Rating < 64:
+		return 1
+	case p.Rating < 128:
+		return 2
+	case p.Rating < 196:
+		return 3
+	case p.Rating < 255:
+		return 4
+	}
+	return 5
+}
+
+// parsePopularimeter reads the terminated email, the rating byte and the
+// optional counter which takes up the rest of the frame.
+func parsePopularimeter(data []byte) Popularimeter {
+	var p Popularimeter
+	email, data := splitTerminated(encodingISO88591, data)
+	p.Email = decodeLatin1(email)
+	if len(data) == 0 {
+		return p
+	}
+	p.Rating = data[0]
+	p.Counter, _ = parseCounter(data[1:])
+	return p
+}
+
+// parseCounter reads a big endian counter that's at least 4 bytes but
+// grows a byte at a time as it needs to.
+func parseCounter(b []byte) (uint64, error) {
+	// leading zeros don't matter however long it is
+	for len(b) > 8 && b[0] == 0 {
+		b = b[1:]
+	}
+	if len(b) > 8 {
+		return 0, fmt.Errorf("counter of %d bytes doesn't fit in 64 bits", len(b))
+	}
+	var acc uint64
+	for _, c := range b {
+		acc = acc<<8 | uint64(c)
+	}
+	return acc, nil
+}
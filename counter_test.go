@@ -0,0 +1,82 @@
+package easyid3
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestPopularimeters(t *testing.T) {
+	tag := tagBytes(3, 0,
+		frameBytes(3, "POPM", []byte("Windows Media Player 9 Series\x00\xc4\x00\x00\x00\x2a")),
+		frameBytes(3, "POPM", []byte("MusicBee\x00\xff\x01\x00\x00\x00\x00")),
+		frameBytes(3, "POPM", []byte("no@counter\x00\x40")),
+	)
+	parsed, err := ReadTag(bytes.NewReader(tag))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	ratings := parsed.Popularimeters()
+	expected := []Popularimeter{
+		{Email: "Windows Media Player 9 Series", Rating: 196, Counter: 42},
+		{Email: "MusicBee", Rating: 255, Counter: 1 << 32},
+		{Email: "no@counter", Rating: 64},
+	}
+	stars := []int{4, 5, 2}
+	if len(ratings) != len(expected) {
+		t.Fatalf("Expected %d ratings got %+v", len(expected), ratings)
+	}
+	for i, p := range expected {
+		if ratings[i] != p {
+			t.Errorf("Expected %+v got %+v", p, ratings[i])
+		}
+		if ratings[i].Stars() != stars[i] {
+			t.Errorf("Expected %d stars got %d", stars[i], ratings[i].Stars())
+		}
+	}
+}
+
+func TestStars(t *testing.T) {
+	for rating, stars := range map[uint8]int{0: 0, 1: 1, 63: 1, 64: 2, 127: 2, 128: 3, 195: 3, 196: 4, 254: 4, 255: 5} {
+		if got := (Popularimeter{Rating: rating}).Stars(); got != stars {
+			t.Errorf("rating %d expected %d stars got %d", rating, stars, got)
+		}
+	}
+}
+
+func TestPlayCount(t *testing.T) {
+	tests := []struct {
+		data []byte
+		want uint64
+	}{
+		{[]byte{0x7}, 7},
+		{[]byte{0x0, 0x0, 0x1, 0x2}, 258},
+		{[]byte{0x1, 0x0, 0x0, 0x0, 0x0}, 1 << 32},
+		{[]byte{0x0, 0x0, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, 1<<64 - 1},
+	}
+	for _, tc := range tests {
+		parsed, err := ReadTag(bytes.NewReader(tagBytes(4, 0, frameBytes(4, "PCNT", tc.data))))
+		if err != nil {
+			t.Fatalf("Failed read: %v", err)
+		}
+		count, ok, err := parsed.PlayCount()
+		if err != nil || !ok || count != tc.want {
+			t.Errorf("%v: expected %d got %d %v %v", tc.data, tc.want, count, ok, err)
+		}
+	}
+
+	parsed, err := ReadTag(bytes.NewReader(tagBytes(4, 0, frameBytes(4, "PCNT", []byte{1, 0, 0, 0, 0, 0, 0, 0, 0}))))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	if _, _, err := parsed.PlayCount(); err == nil {
+		t.Errorf("Expected error for a 9 byte counter")
+	}
+
+	parsed, err = ReadTag(bytes.NewReader(tagBytes(4, 0, frameBytes(4, "TIT2", []byte{3, 'x'}))))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	if _, ok, err := parsed.PlayCount(); ok || err != nil {
+		t.Errorf("Expected no play count got %v %v", ok, err)
+	}
+}
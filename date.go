@@ -0,0 +1,85 @@
+package easyid3
+
+// mergeDates replaces the v2.3 TYER, TDAT and TIME frames with the TDRC
+// v2.4 has instead, in the place TYER was. Parts that aren't the digits
+// they should be are left out and their frames kept. Nothing changes when
+// there's no TYER or there's a TDRC already.
+func mergeDates(frames []*Frame) []*Frame {
+	var year, date, tm *Frame
+	for _, f := range frames {
+		switch f.FrameID {
+		case "TDRC":
+			return frames
+		case "TYER":
+			if year == nil {
+				year = f
+			}
+		case "TDAT":
+			if date == nil {
+				date = f
+			}
+		case "TIME":
+			if tm == nil {
+				tm = f
+			}
+		}
+	}
+	if year == nil {
+		return frames
+	}
+	y := year.Decoded()
+	if len(y) < 4 || !digits(y[:4]) {
+		return frames
+	}
+	value := y[:4]
+	used := map[*Frame]bool{year: true}
+	// TDAT is DDMM and TIME is HHMM, a time without a date means nothing
+	if d := datePart(date); d != "" {
+		value += "-" + d[2:] + "-" + d[:2]
+		used[date] = true
+		if t := datePart(tm); t != "" {
+			value += "T" + t[:2] + ":" + t[2:]
+			used[tm] = true
+		}
+	}
+	tdrc := &Frame{
+		FrameID:    "TDRC",
+		Flags:      []byte{0, 0},
+		Data:       append([]byte{encodingISO88591}, value...),
+		Version:    year.Version,
+		DataLength: -1,
+		offset:     year.offset,
+	}
+	tdrc.Size = len(tdrc.Data)
+	merged := make([]*Frame, 0, len(frames))
+	for _, f := range frames {
+		switch {
+		case f == year:
+			merged = append(merged, tdrc)
+		case !used[f]:
+			merged = append(merged, f)
+		}
+	}
+	return merged
+}
+
+// datePart is the 4 digits of a TDAT or TIME frame, empty when it isn't
+func datePart(f *Frame) string {
+	if f == nil {
+		return ""
+	}
+	s := f.Decoded()
+	if len(s) != 4 || !digits(s) {
+		return ""
+	}
+	return s
+}
+
+func digits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return len(s) > 0
+}
@@ -0,0 +1,61 @@
+package easyid3
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestMergeDates(t *testing.T) {
+	year := frameBytes(3, "TYER", []byte("\x001999"))
+	date := frameBytes(3, "TDAT", []byte("\x003112"))
+	tm := frameBytes(3, "TIME", []byte("\x002359"))
+	title := frameBytes(3, "TIT2", []byte("\x00Title"))
+	for name, tc := range map[string]struct {
+		frames [][]byte
+		want   map[string]string
+	}{
+		"all":      {[][]byte{title, year, date, tm}, map[string]string{"TIT2": "Title", "TDRC": "1999-12-31T23:59"}},
+		"year":     {[][]byte{year}, map[string]string{"TDRC": "1999"}},
+		"date":     {[][]byte{date, year}, map[string]string{"TDRC": "1999-12-31"}},
+		"noDate":   {[][]byte{year, tm}, map[string]string{"TDRC": "1999", "TIME": "2359"}},
+		"badDate":  {[][]byte{year, frameBytes(3, "TDAT", []byte("\x00Dec"))}, map[string]string{"TDRC": "1999", "TDAT": "Dec"}},
+		"badYear":  {[][]byte{frameBytes(3, "TYER", []byte("\x00'99")), date}, map[string]string{"TYER": "'99", "TDAT": "3112"}},
+		"noYear":   {[][]byte{date, tm}, map[string]string{"TDAT": "3112", "TIME": "2359"}},
+		"hasTDRC":  {[][]byte{frameBytes(3, "TDRC", []byte("\x002001")), year}, map[string]string{"TDRC": "2001", "TYER": "1999"}},
+		"longYear": {[][]byte{frameBytes(3, "TYER", []byte("\x001999\x00"))}, map[string]string{"TDRC": "1999"}},
+	} {
+		props, err := ReadID3(bytes.NewReader(tagBytes(3, 0, tc.frames...)))
+		if err != nil {
+			t.Fatalf("%s: Failed read: %v", name, err)
+		}
+		if !reflect.DeepEqual(props, tc.want) {
+			t.Errorf("%s: expected %v got %v", name, tc.want, props)
+		}
+	}
+
+	tag := tagBytes(3, 0, title, year, date, tm)
+	parsed, err := ReadTag(bytes.NewReader(tag))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	if len(parsed.frames) != 2 || parsed.frames[1].FrameID != "TDRC" || parsed.Year() != 1999 {
+		t.Errorf("Wrong frames %v", parsed.frames)
+	}
+	props, err := ReadID3(bytes.NewReader(tag), WithOriginalFrameIDs())
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	if _, ok := props["TDRC"]; ok || props["TYER"] != "1999" || props["TDAT"] != "3112" || props["TIME"] != "2359" {
+		t.Errorf("Wrong original frames %v", props)
+	}
+	raw, err := ReadID3Raw(bytes.NewReader(tag))
+	if err != nil || len(raw) != 4 || raw[1].ID != "TYER" {
+		t.Errorf("Wrong raw frames %+v %v", raw, err)
+	}
+	// v2.2 dates go through the v2.4 IDs
+	v22 := tagBytes(2, 0, frameBytes(2, "TYE", []byte("\x001987")), frameBytes(2, "TDA", []byte("\x000102")))
+	if props, err := ReadID3(bytes.NewReader(v22)); err != nil || props["TDRC"] != "1987-02-01" {
+		t.Errorf("Wrong v2.2 date %v %v", props, err)
+	}
+}
@@ -0,0 +1,154 @@
+package easyid3
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+// NewTag is an empty tag to add frames to and write with WriteTag
+func NewTag() *Tag {
+	return &Tag{header: &Header{ID3: "ID3", Version: []byte{4, 0}}}
+}
+
+// SetText replaces the text frames with the ID. id can be anything
+// WriteID3 takes as a key so TXXX:description and COMM:lang:description
+// work too and only replace that description.
+func (t *Tag) SetText(id, value string) error {
+	f, err := textFrame(id, value)
+	if err != nil {
+		return err
+	}
+	t.replace(f)
+	return nil
+}
+
+// SetTrack replaces TRCK with "n/total", just "n" when total is 0
+func (t *Tag) SetTrack(n, total int) error {
+	return t.SetText("TRCK", formatPosition(n, total))
+}
+
+// SetDisc replaces TPOS the same way SetTrack does TRCK
+func (t *Tag) SetDisc(n, total int) error {
+	return t.SetText("TPOS", formatPosition(n, total))
+}
+
+func formatPosition(n, total int) string {
+	if total > 0 {
+		return fmt.Sprintf("%d/%d", n, total)
+	}
+	return strconv.Itoa(n)
+}
+
+// SetCompilation marks the album as a compilation with TCMP the way iTunes
+// does, false removes it
+func (t *Tag) SetCompilation(compilation bool) {
+	t.DeleteFrame("TCP")
+	if !compilation {
+		t.DeleteFrame(FrameCompilation)
+		return
+	}
+	t.replace(newFrame(FrameCompilation, utf8Terminated("1")))
+}
+
+// SetLength replaces TLEN with the length in whole milliseconds
+func (t *Tag) SetLength(d time.Duration) error {
+	if d < 0 {
+		return fmt.Errorf("negative length %v", d)
+	}
+	return t.SetText("TLEN", strconv.FormatInt(int64((d+time.Millisecond/2)/time.Millisecond), 10))
+}
+
+// SetBPM replaces TBPM, whole numbers are written without a decimal point
+// like the spec wants and anything else with as few digits as it takes
+func (t *Tag) SetBPM(bpm float64) error {
+	if bpm < 0 || math.IsNaN(bpm) || math.IsInf(bpm, 0) {
+		return fmt.Errorf("invalid BPM %v", bpm)
+	}
+	return t.SetText("TBPM", strconv.FormatFloat(bpm, 'f', -1, 64))
+}
+
+// SetComment replaces the COMM frame with the same language and description
+func (t *Tag) SetComment(lang, desc, text string) error {
+	return t.SetText("COMM:"+lang+":"+desc, text)
+}
+
+// SetPicture replaces the pictures of the same type
+func (t *Tag) SetPicture(p Picture) error {
+	mime, err := encodeLatin1(p.MIMEType)
+	if err != nil {
+		return fmt.Errorf("picture MIME type: %w", err)
+	}
+	data := append([]byte{encodingUTF8}, mime...)
+	data = append(data, 0, p.PictureType)
+	data = append(data, p.Description...)
+	data = append(data, 0)
+	data = append(data, p.Data...)
+
+	var kept []*Frame
+	for _, f := range t.frames {
+		if (f.FrameID == "APIC" || f.FrameID == "PIC") && parsePicture(f.Data, f.FrameID == "PIC").PictureType == p.PictureType {
+			continue
+		}
+		kept = append(kept, f)
+	}
+	t.frames = append(kept, newFrame("APIC", data))
+	return nil
+}
+
+// SetPlayCount replaces the PCNT counter
+func (t *Tag) SetPlayCount(count uint64) {
+	data := make([]byte, 8)
+	binary.BigEndian.PutUint64(data, count)
+	// at least 4 bytes, more only when it needs them
+	for len(data) > 4 && data[0] == 0 {
+		data = data[1:]
+	}
+	t.DeleteFrame("CNT")
+	t.replace(newFrame("PCNT", data))
+}
+
+// DeleteFrame removes every frame with the ID, or with the key when given
+// one like TXXX:description. Nothing happens when there aren't any.
+func (t *Tag) DeleteFrame(id string) {
+	var kept []*Frame
+	for _, f := range t.frames {
+		if f.FrameID != id && f.Key() != id {
+			kept = append(kept, f)
+		}
+	}
+	t.frames = kept
+}
+
+// DeleteAll removes every frame
+func (t *Tag) DeleteAll() {
+	t.frames = nil
+}
+
+// replace swaps the frames with the same key for f, it goes where the
+// first of them was so the order doesn't change for no reason
+func (t *Tag) replace(f *Frame) {
+	key := f.Key()
+	var kept []*Frame
+	added := false
+	for _, old := range t.frames {
+		if old.Key() != key {
+			kept = append(kept, old)
+			continue
+		}
+		if !added {
+			kept = append(kept, f)
+			added = true
+		}
+	}
+	if !added {
+		kept = append(kept, f)
+	}
+	t.frames = kept
+}
+
+func newFrame(id string, data []byte) *Frame {
+	return &Frame{FrameID: id, Size: len(data), Flags: []byte{0, 0}, Data: data, Version: 4, DataLength: -1}
+}
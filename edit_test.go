@@ -0,0 +1,145 @@
+package easyid3
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func rewrite(t *testing.T, tag *Tag) *Tag {
+	t.Helper()
+	var buf bytes.Buffer
+	_, err := WriteTag(&buf, tag)
+	if err != nil {
+		t.Fatalf("Failed write: %v", err)
+	}
+	read, err := ReadTag(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	return read
+}
+
+func TestEditTag(t *testing.T) {
+	tag, err := ReadTag(bytes.NewReader(tagBytes(4, 0,
+		frameBytes(4, "TIT2", []byte("\x03Old title\x00")),
+		frameBytes(4, "TPE1", []byte("\x03Artist\x00")),
+		frameBytes(4, "TIT2", []byte("\x03Other old title\x00")),
+		frameBytes(4, "TXXX", []byte("\x03a\x00one\x00")),
+		frameBytes(4, "TXXX", []byte("\x03b\x00two\x00")),
+		frameBytes(4, "COMM", []byte("\x03eng\x00Old comment")),
+		frameBytes(4, "PCNT", []byte{0, 0, 0, 9}),
+		frameBytes(4, "PRIV", []byte("owner\x00\x01\x02")),
+		frameBytes(4, "APIC", apicData(0, "image/jpeg", PictureTypeFrontCover, []byte("old\x00"), tinyJPEG)),
+		frameBytes(4, "APIC", apicData(0, "image/jpeg", PictureTypeBackCover, []byte("back\x00"), tinyJPEG)),
+	)))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	if err := tag.SetText("TIT2", "New title"); err != nil {
+		t.Fatal(err)
+	}
+	if err := tag.SetText("TXXX:a", "uno"); err != nil {
+		t.Fatal(err)
+	}
+	if err := tag.SetComment("eng", "", "New comment"); err != nil {
+		t.Fatal(err)
+	}
+	if err := tag.SetPicture(Picture{MIMEType: "image/jpeg", PictureType: PictureTypeFrontCover, Description: "new", Data: tinyJPEG}); err != nil {
+		t.Fatal(err)
+	}
+	tag.SetPlayCount(1 << 40)
+	tag.DeleteFrame("PRIV")
+	tag.DeleteFrame("TXXX:b")
+	tag.DeleteFrame("TCOM")
+
+	read := rewrite(t, tag)
+	props := map[string]string{}
+	for _, f := range read.frames {
+		props[f.Key()] = f.Decoded()
+	}
+	want := map[string]string{
+		"TIT2":      "New title",
+		"TPE1":      "Artist",
+		"TXXX:a":    "uno",
+		"COMM:eng:": "New comment",
+		"APIC":      "new",
+	}
+	// two pictures share the APIC key and PCNT isn't text
+	if len(read.frames) != len(want)+2 {
+		t.Errorf("Wrong frames %v", read.frames)
+	}
+	for k, v := range want {
+		if props[k] != v {
+			t.Errorf("Wrong %s got %q want %q", k, props[k], v)
+		}
+	}
+	if read.frames[0].FrameID != "TIT2" {
+		t.Errorf("Replaced frame moved, frames are %v", read.frames)
+	}
+	if n, _, _ := read.PlayCount(); n != 1<<40 {
+		t.Errorf("Wrong play count %d", n)
+	}
+	front := read.Pictures().FrontCover()
+	if front == nil || front.Description != "new" || !bytes.Equal(front.Data, tinyJPEG) {
+		t.Errorf("Wrong front cover %+v", front)
+	}
+	if back := read.Pictures().ByType(PictureTypeBackCover); len(back) != 1 {
+		t.Errorf("Lost the back cover %+v", back)
+	}
+
+	read.DeleteAll()
+	if read = rewrite(t, read); len(read.frames) != 0 {
+		t.Errorf("Frames left after DeleteAll %v", read.frames)
+	}
+}
+
+func TestNewTag(t *testing.T) {
+	tag := NewTag()
+	if err := tag.SetText("TALB", "Album"); err != nil {
+		t.Fatal(err)
+	}
+	tag.SetPlayCount(3)
+	if err := tag.SetText("TIT", "v2.2"); err == nil {
+		t.Error("Expected an error setting a v2.2 ID")
+	}
+	read := rewrite(t, tag)
+	if read.Album() != "Album" {
+		t.Errorf("Wrong album %q", read.Album())
+	}
+	if !reflect.DeepEqual(read.frames[1].Data, []byte{0, 0, 0, 3}) {
+		t.Errorf("Play count should be 4 bytes got %x", read.frames[1].Data)
+	}
+}
+
+func TestWriteTagKeepsFlags(t *testing.T) {
+	tag, err := ReadTag(bytes.NewReader(tagBytes(3, 0,
+		flaggedFrameBytes(3, "TIT2", 0xe0, 0x20, []byte("\x07\x00Title")),
+	)))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	f := rewrite(t, tag).frames[0]
+	if f.Version != 4 || f.TagAlterPreserve() || f.FileAlterPreserve() || !f.ReadOnly() || !f.Grouped() || f.GroupID != 7 {
+		t.Errorf("Lost the flags %x group %d", f.Flags, f.GroupID)
+	}
+	if f.Decoded() != "Title" {
+		t.Errorf("Wrong title %q", f.Decoded())
+	}
+
+	v22, err := ReadTag(bytes.NewReader(v22ID3), WithOriginalFrameIDs())
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	if _, err := WriteTag(&bytes.Buffer{}, v22); err == nil {
+		t.Error("Expected an error writing v2.2 frames")
+	}
+	// with the v2.4 IDs they can be written
+	v22, err = ReadTag(bytes.NewReader(v22ID3))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	if read := rewrite(t, v22); read.Title() != "Long Season" || read.Artist() != "Fishmans" {
+		t.Errorf("Wrong rewritten tag %v", read.frames)
+	}
+}
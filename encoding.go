@@ -0,0 +1,112 @@
+package easyid3
+
+import (
+	"bytes"
+	"strings"
+	"unicode/utf16"
+)
+
+// Text encoding bytes that lead most text frames
+const (
+	encodingISO88591 byte = 0
+	encodingUTF16    byte = 1
+	encodingUTF16BE  byte = 2
+	encodingUTF8     byte = 3
+)
+
+// decodeText turns the bytes following the encoding byte into UTF-8. The
+// null terminator is only stripped when it's actually there.
+func decodeText(enc byte, b []byte) string {
+	switch enc {
+	case encodingISO88591:
+		// a UTF-8 BOM on text that's meant to be ISO-8859-1
+		return decodeLatin1(trimNull(bytes.TrimPrefix(b, utf8BOM)))
+	case encodingUTF16, encodingUTF16BE:
+		// UTF-16BE shouldn't have a BOM, though some taggers write one anyway
+		return trimBOM(decodeUTF16(b))
+	}
+	return trimBOM(string(trimNull(b)))
+}
+
+var utf8BOM = []byte{0xef, 0xbb, 0xbf}
+
+// trimBOM drops the BOMs left at the start of decoded text, UTF-8 ones and
+// UTF-16 ones written twice
+func trimBOM(s string) string {
+	for strings.HasPrefix(s, "\ufeff") {
+		s = s[len("\ufeff"):]
+	}
+	return s
+}
+
+// splitTerminated cuts b at the first null terminator, which is two bytes
+// wide for UTF-16. Without a terminator everything is in the first part.
+func splitTerminated(enc byte, b []byte) ([]byte, []byte) {
+	if enc == encodingUTF16 || enc == encodingUTF16BE {
+		for i := 0; i+1 < len(b); i += 2 {
+			if b[i] == 0 && b[i+1] == 0 {
+				return b[:i], b[i+2:]
+			}
+		}
+		return b, nil
+	}
+	if i := bytes.IndexByte(b, 0); i >= 0 {
+		return b[:i], b[i+1:]
+	}
+	return b, nil
+}
+
+// trimNull drops a single byte terminator
+func trimNull(b []byte) []byte {
+	if len(b) > 0 && b[len(b)-1] == 0 {
+		return b[:len(b)-1]
+	}
+	return b
+}
+
+// decodeLatin1 maps each ISO-8859-1 byte to the code point of the same
+// value so the result is always valid UTF-8.
+func decodeLatin1(b []byte) string {
+	if !hasHighBytes(b) {
+		// ASCII is the same as UTF-8
+		return string(b)
+	}
+	rs := make([]rune, len(b))
+	for i, c := range b {
+		rs[i] = rune(c)
+	}
+	return string(rs)
+}
+
+// decodeUTF16 reads the BOM to figure out the byte order and returns the
+// text as UTF-8. Without a BOM it assumes big endian which also covers
+// the BOM-less UTF-16BE encoding. A trailing null
+// terminator is dropped and a dangling odd byte is ignored.
+func decodeUTF16(b []byte) string {
+	bigEndian := true
+	if len(b) >= 2 {
+		switch {
+		case b[0] == 0xFF && b[1] == 0xFE:
+			bigEndian = false
+			b = b[2:]
+		case b[0] == 0xFE && b[1] == 0xFF:
+			b = b[2:]
+		}
+	}
+	return utf16String(b, bigEndian)
+}
+
+func utf16String(b []byte, bigEndian bool) string {
+	units := make([]uint16, 0, len(b)/2)
+	for i := 0; i+1 < len(b); i += 2 {
+		if bigEndian {
+			units = append(units, uint16(b[i])<<8|uint16(b[i+1]))
+		} else {
+			units = append(units, uint16(b[i+1])<<8|uint16(b[i]))
+		}
+	}
+	if n := len(units); n > 0 && units[n-1] == 0 {
+		units = units[:n-1]
+	}
+	return string(utf16.Decode(units))
+}
@@ -0,0 +1,168 @@
+package easyid3
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+func TestDecodeUTF16(t *testing.T) {
+	tests := []struct {
+		name string
+		id   string
+		data []byte
+		want string
+	}{
+		{"LE title", "TIT2", []byte{0x1, 0xff, 0xfe, 0x48, 0x0, 0x65, 0x0, 0x6c, 0x0, 0x6c, 0x0, 0x6f, 0x0, 0x2c, 0x0, 0x20, 0x0, 0x57, 0x0, 0x6f, 0x0, 0x72, 0x0, 0x6c, 0x0, 0x64, 0x0, 0x0, 0x0}, "Hello, World"},
+		{"BE title", "TIT2", []byte{0x1, 0xfe, 0xff, 0x0, 0x48, 0x0, 0x65, 0x0, 0x6c, 0x0, 0x6c, 0x0, 0x6f, 0x0, 0x2c, 0x0, 0x20, 0x0, 0x57, 0x0, 0x6f, 0x0, 0x72, 0x0, 0x6c, 0x0, 0x64, 0x0, 0x0}, "Hello, World"},
+		{"LE artist", "TPE1", []byte{0x1, 0xff, 0xfe, 0x42, 0x0, 0x6a, 0x0, 0xf6, 0x0, 0x72, 0x0, 0x6b, 0x0, 0x0, 0x0}, "Björk"},
+		{"BE artist", "TPE1", []byte{0x1, 0xfe, 0xff, 0x0, 0x42, 0x0, 0x6a, 0x0, 0xf6, 0x0, 0x72, 0x0, 0x6b, 0x0, 0x0}, "Björk"},
+		{"LE CJK", "TPE1", []byte{0x1, 0xff, 0xfe, 0xe5, 0x65, 0x2c, 0x67, 0x9e, 0x8a, 0x0, 0x0}, "日本語"},
+		{"BE CJK", "TPE1", []byte{0x1, 0xfe, 0xff, 0x65, 0xe5, 0x67, 0x2c, 0x8a, 0x9e, 0x0, 0x0}, "日本語"},
+		{"BOM only", "TIT2", []byte{0x1, 0xff, 0xfe}, ""},
+		{"BOM and terminator", "TIT2", []byte{0x1, 0xff, 0xfe, 0x0, 0x0}, ""},
+		{"odd length", "TIT2", []byte{0x1, 0xff, 0xfe, 0x42, 0x0, 0x6a}, "B"},
+		{"odd BOM", "TIT2", []byte{0x1, 0xff}, ""},
+	}
+	for _, tc := range tests {
+		f := &Frame{FrameID: tc.id, Size: len(tc.data), Data: tc.data}
+		if got := f.Decoded(); got != tc.want {
+			t.Errorf("%s: expected %q got %q", tc.name, tc.want, got)
+		}
+	}
+}
+
+func TestDecodeUTF16BE(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+		want string
+	}{
+		{"terminated", []byte{0x2, 0x0, 0x42, 0x0, 0x6a, 0x0, 0xf6, 0x0, 0x72, 0x0, 0x6b, 0x0, 0x0}, "Björk"},
+		{"unterminated", []byte{0x2, 0x0, 0x42, 0x0, 0x6a, 0x0, 0xf6, 0x0, 0x72, 0x0, 0x6b}, "Björk"},
+		{"surrogate pair", []byte{0x2, 0x0, 0x53, 0x0, 0x6f, 0x0, 0x6e, 0x0, 0x67, 0x0, 0x20, 0xd8, 0x3c, 0xdf, 0xb5, 0x0, 0x0}, "Song 🎵"},
+		{"only surrogates", []byte{0x2, 0xd8, 0x3c, 0xdf, 0xb8, 0xd8, 0x3e, 0xdd, 0x41}, "🎸🥁"},
+		{"stray BE BOM", []byte{0x2, 0xfe, 0xff, 0x0, 0x42, 0x0, 0x6a, 0x0, 0x0}, "Bj"},
+		{"stray LE BOM", []byte{0x2, 0xff, 0xfe, 0x42, 0x0, 0x6a, 0x0, 0x0, 0x0}, "Bj"},
+		{"truncated code unit", []byte{0x2, 0x0, 0x42, 0x0, 0x6a, 0x0}, "Bj"},
+		{"truncated surrogate pair", []byte{0x2, 0x0, 0x42, 0xd8, 0x3c}, "B�"},
+		{"empty", []byte{0x2}, ""},
+	}
+	for _, tc := range tests {
+		f := &Frame{FrameID: "TIT2", Size: len(tc.data), Data: tc.data}
+		if got := f.Decoded(); got != tc.want {
+			t.Errorf("%s: expected %q got %q", tc.name, tc.want, got)
+		}
+	}
+}
+
+func TestDecodeLatin1(t *testing.T) {
+	tests := []struct {
+		data []byte
+		want string
+	}{
+		{[]byte{0x0, 0x42, 0x6a, 0xf6, 0x72, 0x6b, 0x0}, "Björk"},
+		{[]byte{0x0, 0x4d, 0x6f, 0x74, 0xf6, 0x72, 0x68, 0x65, 0x61, 0x64, 0x0}, "Motörhead"},
+		{[]byte{0x0, 0x45, 0x6e, 0x72, 0x69, 0x71, 0x75, 0x65, 0x20, 0x49, 0x67, 0x6c, 0x65, 0x73, 0x69, 0x61, 0x73, 0x20, 0x4d, 0x75, 0xf1, 0x6f, 0x7a, 0x0}, "Enrique Iglesias Muñoz"},
+		{[]byte{0x0, 0x43, 0xe9, 0x6c, 0x69, 0x6e, 0x65, 0x20, 0x44, 0x69, 0x6f, 0x6e, 0x0}, "Céline Dion"},
+		{[]byte{0x0, 0xc6, 0x72, 0xf8, 0x0}, "Ærø"},
+		{[]byte{0x0, 0xff, 0x80, 0xa0, 0x0}, "ÿ\u0080\u00a0"},
+	}
+	for _, tc := range tests {
+		f := &Frame{FrameID: "TPE1", Size: len(tc.data), Data: tc.data}
+		got := f.Decoded()
+		if !utf8.ValidString(got) {
+			t.Errorf("invalid UTF-8 for %q: %q", tc.want, got)
+		}
+		if got != tc.want {
+			t.Errorf("expected %q got %q", tc.want, got)
+		}
+	}
+}
+
+func TestTerminators(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+		want string
+	}{
+		{"latin1 terminated", []byte{0x0, 'C', 0xe9, 0}, "Cé"},
+		{"latin1 unterminated", []byte{0x0, 'C', 0xe9}, "Cé"},
+		{"utf16 terminated", []byte{0x1, 0xff, 0xfe, 'C', 0x0, 0xe9, 0x0, 0x0, 0x0}, "Cé"},
+		{"utf16 unterminated", []byte{0x1, 0xff, 0xfe, 'C', 0x0, 0xe9, 0x0}, "Cé"},
+		{"utf16be terminated", []byte{0x2, 0x0, 'C', 0x0, 0xe9, 0x0, 0x0}, "Cé"},
+		{"utf16be unterminated", []byte{0x2, 0x0, 'C', 0x0, 0xe9}, "Cé"},
+		{"utf8 terminated", []byte{0x3, 'C', 0xc3, 0xa9, 0x0}, "Cé"},
+		{"utf8 unterminated", []byte{0x3, 'C', 0xc3, 0xa9}, "Cé"},
+	}
+	for _, tc := range tests {
+		f := &Frame{FrameID: "TIT2", Size: len(tc.data), Data: tc.data}
+		got := f.Decoded()
+		if got != tc.want {
+			t.Errorf("%s: expected %q got %q", tc.name, tc.want, got)
+		}
+		if !utf8.ValidString(got) {
+			t.Errorf("%s: invalid UTF-8 %q", tc.name, got)
+		}
+	}
+}
+
+// utf16LE encodes s with a BOM the way Windows taggers do, without a terminator
+func utf16LE(s string) []byte {
+	out := []byte{0xff, 0xfe}
+	for _, u := range utf16.Encode([]rune(s)) {
+		out = append(out, byte(u), byte(u>>8))
+	}
+	return out
+}
+
+func TestBOMs(t *testing.T) {
+	le := func(s string) []byte {
+		out := []byte{0xff, 0xfe}
+		for _, r := range s {
+			out = append(out, byte(r), 0)
+		}
+		return append(out, 0, 0)
+	}
+	// what Mp3tag writes, a BOM on the description and another on the text
+	comm := append([]byte("\x01eng"), le("Note")...)
+	comm = append(comm, le("Remastered")...)
+	txxx := append([]byte{0x01}, le("Source")...)
+	txxx = append(txxx, le("Vinyl")...)
+	wxxx := append([]byte{0x01}, le("Shop")...)
+	wxxx = append(wxxx, "http://example.com"...)
+	doubled := append([]byte{0x01, 0xff, 0xfe}, le("Doubled")...)
+	tag := tagBytes(4, 0,
+		frameBytes(4, "COMM", comm),
+		frameBytes(4, "TXXX", txxx),
+		frameBytes(4, "WXXX", wxxx),
+		frameBytes(4, "TIT2", []byte("\x03\xef\xbb\xbfUTF-8 with a BOM\x00")),
+		frameBytes(4, "TALB", []byte("\x00\xef\xbb\xbfNot Latin-1\x00")),
+		frameBytes(4, "TPE1", doubled),
+		frameBytes(4, "TCOM", append(append([]byte{0x01}, le("One")...), le("Two")...)),
+	)
+	vals, err := ReadID3(bytes.NewReader(tag))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	want := map[string]string{
+		"COMM:eng:Note": "Remastered",
+		"TXXX:Source":   "Vinyl",
+		"WXXX:Shop":     "http://example.com",
+		"TIT2":          "UTF-8 with a BOM",
+		"TALB":          "Not Latin-1",
+		"TPE1":          "Doubled",
+		"TCOM":          "One; Two",
+	}
+	if !reflect.DeepEqual(vals, want) {
+		t.Errorf("Got %q want %q", vals, want)
+	}
+	for k, v := range vals {
+		if strings.ContainsRune(k, '\ufeff') || strings.ContainsRune(v, '\ufeff') {
+			t.Errorf("BOM left in %q: %q", k, v)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package easyid3
+
+import (
+	"encoding/binary"
+	"sync"
+	"time"
+)
+
+// Decryptor turns the data of an encrypted frame back into the plain frame
+// data. owner is the ENCR owner registered for the method in the tag, empty
+// when the tag doesn't have one.
+type Decryptor func(owner string, data []byte) ([]byte, error)
+
+var decryptors = struct {
+	sync.RWMutex
+	m map[byte]Decryptor
+}{m: map[byte]Decryptor{}}
+
+// RegisterDecryptor sets the Decryptor used for frames encrypted with the
+// method symbol, nil removes it. Frames with no Decryptor are skipped.
+func RegisterDecryptor(method byte, fn Decryptor) {
+	decryptors.Lock()
+	defer decryptors.Unlock()
+	if fn == nil {
+		delete(decryptors.m, method)
+		return
+	}
+	decryptors.m[method] = fn
+}
+
+func decryptor(method byte) Decryptor {
+	decryptors.RLock()
+	defer decryptors.RUnlock()
+	return decryptors.m[method]
+}
+
+// Encryption is an ENCR frame registering the method symbol the frames
+// encrypted by its owner use.
+type Encryption struct {
+	Owner  string
+	Method byte
+	Data   []byte
+}
+
+// parseEncryption reads the terminated owner, the method symbol and then
+// whatever data the owner wants.
+func parseEncryption(data []byte) Encryption {
+	owner, rest := splitTerminated(encodingISO88591, data)
+	e := Encryption{Owner: decodeLatin1(owner)}
+	if len(rest) > 0 {
+		e.Method = rest[0]
+		e.Data = rest[1:]
+	}
+	return e
+}
+
+// decryptFrames runs the encrypted frames through their Decryptor and pulls
+// out the ones that can't be decrypted so they don't turn up as noise.
+func decryptFrames(frames []*Frame, o *options) ([]*Frame, []SkippedFrame) {
+	encrypted := false
+	for _, f := range frames {
+		encrypted = encrypted || f.Encrypted()
+	}
+	if !encrypted {
+		return frames, nil
+	}
+	owners := map[byte]string{}
+	for _, f := range frames {
+		if f.FrameID == "ENCR" {
+			e := parseEncryption(f.Data)
+			owners[e.Method] = e.Owner
+		}
+	}
+	var kept []*Frame
+	var skipped []SkippedFrame
+	for _, f := range frames {
+		if !f.Encrypted() {
+			kept = append(kept, f)
+			continue
+		}
+		err := f.decrypt(owners[f.EncryptionMethod], o.maxFrameSize)
+		if err != nil {
+			skipped = append(skipped, SkippedFrame{
+				FrameID:          f.FrameID,
+				Size:             f.Size,
+				Offset:           f.offset,
+				EncryptionMethod: f.EncryptionMethod,
+				Data:             f.Data,
+				Err:              err,
+			})
+			continue
+		}
+		kept = append(kept, f)
+	}
+	return kept, skipped
+}
+
+// decrypt replaces the data with the decrypted data and then does the
+// decompressing unformat left for after
+func (f *Frame) decrypt(owner string, maxSize int) error {
+	fn := decryptor(f.EncryptionMethod)
+	if fn == nil {
+		return f.errorf("is encrypted with method %d and there's no decryptor for it", f.EncryptionMethod)
+	}
+	data, err := fn(owner, f.Data)
+	if err != nil {
+		return f.errorf("decrypting: %w", err)
+	}
+	// keep the encrypted data around for the skipped frame if this fails
+	plain := *f
+	plain.Data = data
+	err = plain.decompress(maxSize)
+	if err != nil {
+		return err
+	}
+	*f = plain
+	return nil
+}
+
+// AudioEncryption is an AENC frame saying the audio is encrypted by Owner.
+// The audio frames in the preview aren't, PreviewStart and PreviewLength
+// count MPEG frames and the times are them converted. A length of 0 is no
+// preview. Info is the owner's data as is.
+type AudioEncryption struct {
+	Owner             string
+	PreviewStart      uint16
+	PreviewLength     uint16
+	PreviewStartTime  time.Duration
+	PreviewLengthTime time.Duration
+	Info              []byte
+}
+
+// parseAudioEncryption reads the terminated owner, the 2 byte preview
+// start and length and then the encryption info
+func parseAudioEncryption(data []byte, frameDuration time.Duration) AudioEncryption {
+	owner, rest := splitTerminated(encodingISO88591, data)
+	a := AudioEncryption{Owner: decodeLatin1(owner)}
+	if len(rest) < 4 {
+		return a
+	}
+	a.PreviewStart = binary.BigEndian.Uint16(rest[0:2])
+	a.PreviewLength = binary.BigEndian.Uint16(rest[2:4])
+	a.PreviewStartTime = time.Duration(a.PreviewStart) * frameDuration
+	a.PreviewLengthTime = time.Duration(a.PreviewLength) * frameDuration
+	if len(rest) > 4 {
+		a.Info = rest[4:]
+	}
+	return a
+}
+
+// AudioEncryptions returns all the AENC frames, there's one for each owner
+func (t *Tag) AudioEncryptions() []AudioEncryption {
+	var aenc []AudioEncryption
+	for _, f := range t.find("AENC", "CRA") {
+		aenc = append(aenc, parseAudioEncryption(f.Data, t.options().mpegFrameDuration))
+	}
+	return aenc
+}
+
+// AudioEncrypted is whether an AENC frame says the audio is encrypted,
+// only the preview can be played without the owner's help
+func (t *Tag) AudioEncrypted() bool {
+	return len(t.find("AENC", "CRA")) > 0
+}
@@ -0,0 +1,137 @@
+package easyid3
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func xor(b []byte, key byte) []byte {
+	out := make([]byte, len(b))
+	for i := range b {
+		out[i] = b[i] ^ key
+	}
+	return out
+}
+
+func TestEncryptedFrames(t *testing.T) {
+	var owners []string
+	RegisterDecryptor(0x80, func(owner string, data []byte) ([]byte, error) {
+		owners = append(owners, owner)
+		return xor(data, 0x5a), nil
+	})
+	defer RegisterDecryptor(0x80, nil)
+	RegisterDecryptor(0x82, func(owner string, data []byte) ([]byte, error) {
+		return nil, errors.New("wrong key")
+	})
+	defer RegisterDecryptor(0x82, nil)
+
+	title := []byte("\x03Secret title\x00")
+	lyrics := []byte("\x00eng\x00Secret lyrics")
+	tag := tagBytes(4, 0,
+		flaggedFrameBytes(4, "TIT2", 0, 0x04, append([]byte{0x80}, xor(title, 0x5a)...)),
+		flaggedFrameBytes(4, "TPE1", 0, 0x04, append([]byte{0x81}, "\x03Hidden artist\x00"...)),
+		flaggedFrameBytes(4, "TALB", 0, 0x04, append([]byte{0x82}, "\x03Hidden album\x00"...)),
+		frameBytes(4, "ENCR", []byte("http://example.com/crypto\x00\x80key")),
+		flaggedFrameBytes(4, "USLT", 0, 0x0d, append(append([]byte{0x80}, synsafeBytes(len(lyrics))...), xor(deflate(lyrics), 0x5a)...)),
+	)
+	parsed, err := ReadTag(bytes.NewReader(tag))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	if len(parsed.frames) != 3 || parsed.frames[0].Decoded() != "Secret title" {
+		t.Fatalf("Wrong frames %v", parsed.frames)
+	}
+	if l := parsed.Lyrics(); len(l) != 1 || l[0].Lyrics != "Secret lyrics" {
+		t.Errorf("Wrong lyrics %+v", l)
+	}
+	if len(owners) != 2 || owners[0] != "http://example.com/crypto" {
+		t.Errorf("Decryptor got owners %v", owners)
+	}
+	e := parsed.Encryptions()
+	if len(e) != 1 || e[0].Owner != "http://example.com/crypto" || e[0].Method != 0x80 || string(e[0].Data) != "key" {
+		t.Errorf("Wrong encryptions %+v", e)
+	}
+	skipped := parsed.Skipped()
+	if len(skipped) != 2 {
+		t.Fatalf("Expected 2 skipped frames got %+v", skipped)
+	}
+	if skipped[0].FrameID != "TPE1" || skipped[0].EncryptionMethod != 0x81 || string(skipped[0].Data) != "\x03Hidden artist\x00" || skipped[0].Err == nil {
+		t.Errorf("Wrong skipped frame %+v", skipped[0])
+	}
+	if skipped[1].FrameID != "TALB" || skipped[1].EncryptionMethod != 0x82 || skipped[1].Err == nil {
+		t.Errorf("Wrong skipped frame %+v", skipped[1])
+	}
+
+	props, err := ReadID3(bytes.NewReader(tag))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	if _, ok := props["TPE1"]; ok {
+		t.Errorf("Encrypted frame in the map %q", props["TPE1"])
+	}
+	if props["TIT2"] != "Secret title" || props["ENCR"] != "http://example.com/crypto" {
+		t.Errorf("Wrong props %v", props)
+	}
+}
+
+func TestEncryptedV23Frames(t *testing.T) {
+	RegisterDecryptor(0x90, func(owner string, data []byte) ([]byte, error) {
+		return xor(data, 0xff), nil
+	})
+	defer RegisterDecryptor(0x90, nil)
+
+	text := []byte("\x00Compressed and encrypted")
+	// decompressed size then the method
+	extra := []byte{0, 0, 0, byte(len(text)), 0x90}
+	tag := tagBytes(3, 0,
+		flaggedFrameBytes(3, "TIT2", 0, 0xc0, append(extra, xor(deflate(text), 0xff)...)),
+		flaggedFrameBytes(3, "TPE1", 0, 0x40, append([]byte{0x91}, "\x00Artist"...)),
+	)
+	parsed, err := ReadTag(bytes.NewReader(tag))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	if len(parsed.frames) != 1 || parsed.frames[0].Decoded() != "Compressed and encrypted" {
+		t.Errorf("Wrong frames %v", parsed.frames)
+	}
+	if s := parsed.Skipped(); len(s) != 1 || s[0].FrameID != "TPE1" || s[0].EncryptionMethod != 0x91 {
+		t.Errorf("Wrong skipped frames %+v", s)
+	}
+}
+
+func TestAudioEncryption(t *testing.T) {
+	tag := tagBytes(4, 0,
+		frameBytes(4, "AENC", []byte("http://example.com/drm\x00\x00\x10\x01\x00key data")),
+		frameBytes(4, "AENC", []byte("nopreview@example.com\x00\x00\x00\x00\x00")),
+	)
+	parsed, err := ReadTag(bytes.NewReader(tag), WithMPEGFrameDuration(26*time.Millisecond))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	if !parsed.AudioEncrypted() {
+		t.Error("Expected encrypted audio")
+	}
+	want := []AudioEncryption{{
+		Owner:             "http://example.com/drm",
+		PreviewStart:      16,
+		PreviewLength:     256,
+		PreviewStartTime:  16 * 26 * time.Millisecond,
+		PreviewLengthTime: 256 * 26 * time.Millisecond,
+		Info:              []byte("key data"),
+	}, {
+		Owner: "nopreview@example.com",
+	}}
+	if got := parsed.AudioEncryptions(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Wrong AENC\ngot  %+v\nwant %+v", got, want)
+	}
+	vals, err := ReadID3(bytes.NewReader(tag))
+	if err != nil || vals["AENC"] != "nopreview@example.com" {
+		t.Errorf("Wrong values %q %v", vals, err)
+	}
+	if NewTag().AudioEncrypted() {
+		t.Error("Expected no AENC")
+	}
+}
@@ -0,0 +1,115 @@
+package easyid3
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ErrNoTag is what the not found errors match with errors.Is when there's
+// no tag to read
+var ErrNoTag = errors.New("no ID3 tag")
+
+// ErrUnsupportedVersion is what errors match when the tag is a version
+// other than v2.2 to v2.4
+var ErrUnsupportedVersion = errors.New("unsupported ID3 version")
+
+// ErrTruncated is what errors match when the data ends before the tag says
+// it does. They match io.ErrUnexpectedEOF as well.
+var ErrTruncated = errors.New("ID3 tag truncated")
+
+// ErrTooManyFrames is what errors match when a tag has more frames than
+// WithMaxFrames allows
+var ErrTooManyFrames = errors.New("too many ID3 frames")
+
+// ErrStopWalk stops WalkFrames without it returning an error
+var ErrStopWalk = errors.New("stop walking the frames")
+
+// notFoundError names what wasn't found, they're all ErrNoTag
+type notFoundError string
+
+func (e notFoundError) Error() string {
+	return string(e) + " not found"
+}
+
+func (e notFoundError) Is(target error) bool {
+	return target == ErrNoTag
+}
+
+// truncatedError wraps io.ErrUnexpectedEOF so it's ErrTruncated too
+type truncatedError struct {
+	err error
+}
+
+// truncated says what was cut short
+func truncated(format string, args ...interface{}) error {
+	return truncatedError{fmt.Errorf(format+": %w", append(args, io.ErrUnexpectedEOF)...)}
+}
+
+func (e truncatedError) Error() string {
+	return e.err.Error()
+}
+
+func (e truncatedError) Unwrap() error {
+	return e.err
+}
+
+func (e truncatedError) Is(target error) bool {
+	return target == ErrTruncated
+}
+
+// FrameError is a problem with a single frame. Offset is where the frame
+// header starts counting from the start of the tag.
+type FrameError struct {
+	FrameID string
+	Offset  int64
+	Err     error
+}
+
+func (e *FrameError) Error() string {
+	return fmt.Sprintf("frame %s at offset %d: %v", e.FrameID, e.Offset, e.Err)
+}
+
+func (e *FrameError) Unwrap() error {
+	return e.Err
+}
+
+// errorf is a FrameError for f
+func (f *Frame) errorf(format string, args ...interface{}) error {
+	return f.wrapError(fmt.Errorf(format, args...))
+}
+
+func (f *Frame) wrapError(err error) error {
+	return &FrameError{FrameID: f.FrameID, Offset: f.offset, Err: err}
+}
+
+// ErrorList is the frame problems WithLenient read past. errors.Is and
+// errors.As look through all of them.
+type ErrorList []error
+
+func (l ErrorList) Error() string {
+	msgs := make([]string, len(l))
+	for i, err := range l {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+func (l ErrorList) Is(target error) bool {
+	for _, err := range l {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+func (l ErrorList) As(target interface{}) bool {
+	for _, err := range l {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
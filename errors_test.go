@@ -0,0 +1,52 @@
+package easyid3
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestErrors(t *testing.T) {
+	title := frameBytes(4, "TIT2", []byte("\x03Title\x00"))
+	tag := tagBytes(4, 0, title, frameBytes(4, "TPE1", []byte("\x03Artist\x00")))
+	v5 := tagBytes(4, 0, title)
+	v5[3] = 5
+	// claims more than the tag has left
+	overrun := tagBytes(4, 0, title, frameBytes(4, "TPE1", []byte("\x03Artist\x00")))
+	overrun[10+len(title)+7] = 0x7f
+
+	for name, tc := range map[string]struct {
+		data   []byte
+		target error
+	}{
+		"empty":       {nil, ErrNoTag},
+		"audio":       {fakeAudio, ErrNoTag},
+		"version":     {v5, ErrUnsupportedVersion},
+		"header":      {tag[:6], ErrTruncated},
+		"frameHeader": {tag[:10+len(title)+4], ErrTruncated},
+		"frame":       {tag[:len(tag)-2], ErrTruncated},
+	} {
+		_, err := ReadID3(bytes.NewReader(tc.data))
+		if !errors.Is(err, tc.target) {
+			t.Errorf("%s: expected %v got %v", name, tc.target, err)
+		}
+		if tc.target == ErrTruncated && !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Errorf("%s: expected io.ErrUnexpectedEOF got %v", name, err)
+		}
+		if tc.target != ErrNoTag && errors.Is(err, ErrNoTag) {
+			t.Errorf("%s: %v shouldn't be ErrNoTag", name, err)
+		}
+	}
+
+	for name, data := range map[string][]byte{"frame": tag[:len(tag)-2], "overrun": overrun} {
+		_, err := ReadID3(bytes.NewReader(data))
+		var fe *FrameError
+		if !errors.As(err, &fe) {
+			t.Fatalf("%s: expected a FrameError got %v", name, err)
+		}
+		if fe.FrameID != "TPE1" || fe.Offset != int64(10+len(title)) {
+			t.Errorf("%s: wrong frame error %+v", name, fe)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package easyid3
+
+import (
+	"encoding/binary"
+	"sort"
+	"time"
+)
+
+// EventType is what happens at an ETCO event. Types the spec doesn't name
+// are kept as the number they are.
+type EventType byte
+
+// ETCO event types
+const (
+	EventPadding EventType = iota
+	EventEndOfInitialSilence
+	EventIntroStart
+	EventMainPartStart
+	EventOutroStart
+	EventOutroEnd
+	EventVerseStart
+	EventRefrainStart
+	EventInterludeStart
+	EventThemeStart
+	EventVariationStart
+	EventKeyChange
+	EventTimeChange
+	EventMomentaryNoise
+	EventSustainedNoise
+	EventSustainedNoiseEnd
+	EventIntroEnd
+	EventMainPartEnd
+	EventVerseEnd
+	EventRefrainEnd
+	EventThemeEnd
+	EventProfanity
+	EventProfanityEnd
+)
+
+// EventSync0 to EventSync0+15 are the sync events the spec leaves for
+// whatever the tagger wants
+const (
+	EventSync0    EventType = 0xe0
+	EventAudioEnd EventType = 0xfd
+	EventFileEnd  EventType = 0xfe
+	EventOneMore  EventType = 0xff
+)
+
+// EventTimingCodes is an ETCO frame
+type EventTimingCodes struct {
+	TimestampFormat byte
+	Events          []Event
+	// OutOfOrder is the frame not having the events in time order like the
+	// spec wants, Events is sorted either way
+	OutOfOrder bool
+}
+
+// Event is one ETCO event, Timestamp is the raw value in whatever unit the
+// frame's TimestampFormat says
+type Event struct {
+	Type      EventType
+	Time      time.Duration
+	Timestamp uint32
+}
+
+// parseEventTimingCodes reads the timestamp format and then the type and 4
+// byte timestamp of each event, a broken one at the end is dropped
+func parseEventTimingCodes(data []byte, frameDuration time.Duration) EventTimingCodes {
+	var etco EventTimingCodes
+	if len(data) == 0 {
+		return etco
+	}
+	etco.TimestampFormat = data[0]
+	for data = data[1:]; len(data) >= 5; data = data[5:] {
+		ts := binary.BigEndian.Uint32(data[1:5])
+		e := Event{
+			Type:      EventType(data[0]),
+			Time:      timestampDuration(etco.TimestampFormat, uint64(ts), frameDuration),
+			Timestamp: ts,
+		}
+		if n := len(etco.Events); n > 0 && etco.Events[n-1].Timestamp > ts {
+			etco.OutOfOrder = true
+		}
+		etco.Events = append(etco.Events, e)
+	}
+	if etco.OutOfOrder {
+		sort.SliceStable(etco.Events, func(i, j int) bool {
+			return etco.Events[i].Timestamp < etco.Events[j].Timestamp
+		})
+	}
+	return etco
+}
+
+// EventTimingCodes is the ETCO frame, ok is false when there isn't one
+func (t *Tag) EventTimingCodes() (etco EventTimingCodes, ok bool) {
+	frames := t.find("ETCO", "ETC")
+	if len(frames) == 0 {
+		return etco, false
+	}
+	return parseEventTimingCodes(frames[0].Data, t.options().mpegFrameDuration), true
+}
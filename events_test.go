@@ -0,0 +1,61 @@
+package easyid3
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestEventTimingCodes(t *testing.T) {
+	etco := []byte{TimestampMilliseconds,
+		byte(EventIntroStart), 0, 0, 0, 0,
+		byte(EventMainPartStart), 0, 0, 0x3a, 0x98,
+		0x17, 0, 0, 0x4e, 0x20,
+		byte(EventAudioEnd), 0, 0x03, 0x0d, 0x40,
+		// cut short
+		byte(EventFileEnd), 0, 0,
+	}
+	tag := tagBytes(4, 0, frameBytes(4, "ETCO", etco))
+	parsed, err := ReadTag(bytes.NewReader(tag))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	got, ok := parsed.EventTimingCodes()
+	want := EventTimingCodes{
+		TimestampFormat: TimestampMilliseconds,
+		Events: []Event{
+			{EventIntroStart, 0, 0},
+			{EventMainPartStart, 15 * time.Second, 15000},
+			// reserved types stay as they are
+			{0x17, 20 * time.Second, 20000},
+			{EventAudioEnd, 200 * time.Second, 200000},
+		},
+	}
+	if !ok || !reflect.DeepEqual(got, want) {
+		t.Errorf("Wrong events\ngot  %+v\nwant %+v", got, want)
+	}
+
+	frames := []byte{TimestampMPEGFrames,
+		byte(EventOutroStart), 0, 0, 0x10, 0,
+		byte(EventMainPartStart), 0, 0, 0, 100,
+	}
+	tag = tagBytes(4, 0, frameBytes(4, "ETCO", frames))
+	parsed, err = ReadTag(bytes.NewReader(tag), WithMPEGFrameDuration(26*time.Millisecond))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	got, _ = parsed.EventTimingCodes()
+	if !got.OutOfOrder || got.Events[0].Type != EventMainPartStart || got.Events[0].Time != 2600*time.Millisecond || got.Events[1].Time != 4096*26*time.Millisecond {
+		t.Errorf("Wrong events %+v", got)
+	}
+	var se *SpecError
+	if _, err := ReadTag(bytes.NewReader(tag), WithStrict()); !errors.As(err, &se) {
+		t.Errorf("Expected a SpecError got %v", err)
+	}
+
+	if _, ok := NewTag().EventTimingCodes(); ok {
+		t.Error("Expected no ETCO")
+	}
+}
@@ -0,0 +1,255 @@
+package easyid3
+
+import (
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"unicode/utf8"
+)
+
+// ErrCRCMismatch is returned by WithCRCCheck when the frames don't match the
+// CRC in the extended header
+var ErrCRCMismatch = errors.New("extended header CRC doesn't match the frames")
+
+// ErrRestricted is what errors match when a tag being written breaks the
+// restrictions in its extended header
+var ErrRestricted = errors.New("tag restrictions don't allow it")
+
+const maxExtendedHeaderSize = 64
+
+// ExtendedHeader is the optional header between the tag header and the
+// frames. Size includes the size field itself for both versions.
+type ExtendedHeader struct {
+	Size int
+	// Update is v2.4 only, the tag updates an earlier one in the file
+	Update bool
+	HasCRC bool
+	// CRC is the CRC-32 of the frames, v2.4 counts the padding after them
+	// and v2.3 doesn't
+	CRC             uint32
+	HasRestrictions bool
+	// Restrictions is the raw v2.4 restrictions byte
+	Restrictions byte
+	// PaddingSize is v2.3 only, the size of the padding after the frames
+	PaddingSize int
+}
+
+// readExtendedHeader reads the extended header, v2.3 has a plain size that
+// doesn't include itself and v2.4 a syncsafe size that does. used is how
+// much of the size the fields the flags say are there take up.
+func readExtendedHeader(r *io.LimitedReader, version byte) (*ExtendedHeader, int, error) {
+	buf := make([]byte, 4)
+	_, err := io.ReadAtLeast(r, buf, 4)
+	if err != nil {
+		return nil, 0, err
+	}
+	var size int
+	if version == 3 {
+		size = beInt(buf) + 4
+	} else {
+		size = synsafeInt(buf)
+	}
+	if size < 10 && version == 3 || size < 6 {
+		return nil, 0, fmt.Errorf("extended header size %d too small", size)
+	}
+	if int64(size-4) > r.N {
+		return nil, 0, fmt.Errorf("extended header size %d bigger than the tag", size)
+	}
+	// it's a handful of bytes for everything the spec defines, anything
+	// past that is thrown away rather than trusting the size
+	rawSize := size - 4
+	if rawSize > maxExtendedHeaderSize {
+		rawSize = maxExtendedHeaderSize
+	}
+	raw := make([]byte, rawSize)
+	_, err = io.ReadAtLeast(r, raw, len(raw))
+	if err != nil {
+		return nil, 0, err
+	}
+	_, err = io.CopyN(io.Discard, r, int64(size-4-len(raw)))
+	if err != nil {
+		return nil, 0, err
+	}
+	ext := &ExtendedHeader{Size: size}
+	if version == 3 {
+		// 2 flag bytes, padding size and then the CRC when the flag says so
+		ext.HasCRC = raw[0]&0x80 != 0
+		ext.PaddingSize = beInt(raw[2:6])
+		if ext.HasCRC {
+			if len(raw) < 10 {
+				return nil, 0, fmt.Errorf("extended header size %d too small for a CRC", size)
+			}
+			ext.CRC = uint32(beInt(raw[6:10]))
+		}
+		used := 10
+		if ext.HasCRC {
+			used += 4
+		}
+		return ext, used, nil
+	}
+
+	// number of flag bytes, the flags and then the data for each set flag
+	// which starts with its length
+	flagBytes := int(raw[0])
+	if flagBytes < 1 || 1+flagBytes > len(raw) {
+		return nil, 0, fmt.Errorf("extended header has %d flag bytes in %d bytes", flagBytes, size)
+	}
+	flags := raw[1]
+	data := raw[1+flagBytes:]
+	flagData := func() ([]byte, error) {
+		if len(data) < 1 || len(data) < 1+int(data[0]) {
+			return nil, fmt.Errorf("extended header flag data overruns its %d bytes", size)
+		}
+		d := data[1 : 1+int(data[0])]
+		data = data[1+int(data[0]):]
+		return d, nil
+	}
+	if flags&0x40 != 0 {
+		ext.Update = true
+		if _, err := flagData(); err != nil {
+			return nil, 0, err
+		}
+	}
+	if flags&0x20 != 0 {
+		crc, err := flagData()
+		if err != nil {
+			return nil, 0, err
+		}
+		if len(crc) != 5 {
+			return nil, 0, fmt.Errorf("extended header CRC is %d bytes", len(crc))
+		}
+		ext.HasCRC = true
+		ext.CRC = uint32(synsafeInt(crc))
+	}
+	if flags&0x10 != 0 {
+		restrictions, err := flagData()
+		if err != nil {
+			return nil, 0, err
+		}
+		if len(restrictions) != 1 {
+			return nil, 0, fmt.Errorf("extended header restrictions are %d bytes", len(restrictions))
+		}
+		ext.HasRestrictions = true
+		ext.Restrictions = restrictions[0]
+	}
+	return ext, 4 + len(raw) - len(data), nil
+}
+
+// Restrictions is the decoded v2.4 tag restrictions byte. Zero values mean
+// there's no restriction.
+type Restrictions struct {
+	MaxFrames  int
+	MaxTagSize int
+	// TextEncoding limits text to ISO-8859-1 or UTF-8
+	TextEncoding bool
+	// MaxTextLength is in characters
+	MaxTextLength int
+	// ImageEncoding limits images to PNG or JPEG
+	ImageEncoding bool
+	// MaxImageSize is the max width and height in pixels, when
+	// ExactImageSize is set images have to be exactly that size
+	MaxImageSize   int
+	ExactImageSize bool
+}
+
+// ParseRestrictions decodes the restrictions byte %ppqrrstt
+func ParseRestrictions(b byte) Restrictions {
+	var r Restrictions
+	switch b >> 6 {
+	case 0:
+		r.MaxFrames, r.MaxTagSize = 128, 1<<20
+	case 1:
+		r.MaxFrames, r.MaxTagSize = 64, 128<<10
+	case 2:
+		r.MaxFrames, r.MaxTagSize = 32, 40<<10
+	case 3:
+		r.MaxFrames, r.MaxTagSize = 32, 4<<10
+	}
+	r.TextEncoding = b&0x20 != 0
+	switch (b >> 3) & 0x03 {
+	case 1:
+		r.MaxTextLength = 1024
+	case 2:
+		r.MaxTextLength = 128
+	case 3:
+		r.MaxTextLength = 30
+	}
+	r.ImageEncoding = b&0x04 != 0
+	switch b & 0x03 {
+	case 1:
+		r.MaxImageSize = 256
+	case 2:
+		r.MaxImageSize = 64
+	case 3:
+		r.MaxImageSize = 64
+		r.ExactImageSize = true
+	}
+	return r
+}
+
+// encodeExtendedHeader is the v2.4 extended header for ext with the CRC
+// worked out from the frames and the padding after them. v2.4 has nowhere for the v2.3 padding size
+// so it's dropped, and it's nil when there's nothing left to write.
+func encodeExtendedHeader(ext *ExtendedHeader, padded []byte) []byte {
+	var flags byte
+	var data []byte
+	if ext.Update {
+		flags |= 0x40
+		data = append(data, 0)
+	}
+	if ext.HasCRC {
+		flags |= 0x20
+		c := crc32.ChecksumIEEE(padded)
+		data = append(data, 5, byte(c>>28)&0x0f, byte(c>>21)&0x7f, byte(c>>14)&0x7f, byte(c>>7)&0x7f, byte(c)&0x7f)
+	}
+	if ext.HasRestrictions {
+		flags |= 0x10
+		data = append(data, 1, ext.Restrictions)
+	}
+	if flags == 0 {
+		return nil
+	}
+	b := append(synsafeBytes(6+len(data)), 1, flags)
+	return append(b, data...)
+}
+
+// checkRestrictions checks the frames against everything but the tag size,
+// which isn't known until they're written, and the image sizes which would
+// mean decoding the images
+func checkRestrictions(r Restrictions, frames []*Frame) error {
+	if len(frames) > r.MaxFrames {
+		return fmt.Errorf("tag has %d frames, restricted to %d: %w", len(frames), r.MaxFrames, ErrRestricted)
+	}
+	for _, f := range frames {
+		kind := f.Kind()
+		encoded := kind == KindText || kind == KindPair || kind == KindComment ||
+			f.FrameID == "WXXX" || f.FrameID == "APIC" || f.FrameID == "GEOB"
+		if r.TextEncoding && encoded && len(f.Data) > 0 &&
+			f.Data[0] != encodingISO88591 && f.Data[0] != encodingUTF8 {
+			return fmt.Errorf("frame %s encoding %d isn't ISO-8859-1 or UTF-8: %w", f.FrameID, f.Data[0], ErrRestricted)
+		}
+		if r.MaxTextLength > 0 {
+			var texts []string
+			switch kind {
+			case KindText, KindPair:
+				texts = f.values()
+			case KindComment:
+				c := parseComment(f.Data)
+				texts = []string{c.Description, c.Text}
+			}
+			for _, text := range texts {
+				if n := utf8.RuneCountInString(text); n > r.MaxTextLength {
+					return fmt.Errorf("frame %s has %d characters of text, restricted to %d: %w", f.FrameID, n, r.MaxTextLength, ErrRestricted)
+				}
+			}
+		}
+		if r.ImageEncoding && f.FrameID == "APIC" {
+			mime := parsePicture(f.Data, false).MIMEType
+			if mime != "image/png" && mime != "image/jpeg" {
+				return fmt.Errorf("frame APIC is %q, restricted to PNG or JPEG: %w", mime, ErrRestricted)
+			}
+		}
+	}
+	return nil
+}
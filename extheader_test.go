@@ -0,0 +1,329 @@
+package easyid3
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"testing"
+)
+
+func v23ExtendedHeader(crc *uint32, padding int) []byte {
+	out := []byte{0, 0, 0, 6, 0, 0}
+	if crc != nil {
+		out = []byte{0, 0, 0, 10, 0x80, 0}
+	}
+	out = append(out, byte(padding>>24), byte(padding>>16), byte(padding>>8), byte(padding))
+	if crc != nil {
+		out = append(out, byte(*crc>>24), byte(*crc>>16), byte(*crc>>8), byte(*crc))
+	}
+	return out
+}
+
+func v24ExtendedHeader(update bool, crc *uint32, restrictions *byte) []byte {
+	var flags byte
+	var data []byte
+	if update {
+		flags |= 0x40
+		data = append(data, 0)
+	}
+	if crc != nil {
+		flags |= 0x20
+		c := *crc
+		data = append(data, 5, byte(c>>28)&0x0f, byte(c>>21)&0x7f, byte(c>>14)&0x7f, byte(c>>7)&0x7f, byte(c)&0x7f)
+	}
+	if restrictions != nil {
+		flags |= 0x10
+		data = append(data, 1, *restrictions)
+	}
+	out := append(synsafeBytes(6+len(data)), 1, flags)
+	return append(out, data...)
+}
+
+func TestV23ExtendedHeader(t *testing.T) {
+	frames := append(frameBytes(3, "TIT2", []byte("\x00Title\x00")), frameBytes(3, "TPE1", []byte("\x00Artist\x00"))...)
+	crc := crc32.ChecksumIEEE(frames)
+	for _, ext := range [][]byte{v23ExtendedHeader(nil, 16), v23ExtendedHeader(&crc, 16)} {
+		tag := tagBytes(3, 0x40, ext, frames, make([]byte, 16))
+		parsed, err := ReadTag(bytes.NewReader(tag), WithCRCCheck())
+		if err != nil {
+			t.Fatalf("Failed read: %v", err)
+		}
+		eh := parsed.ExtendedHeader()
+		if eh == nil || eh.Size != len(ext) || eh.PaddingSize != 16 || eh.HasCRC != (len(ext) == 14) {
+			t.Fatalf("Wrong extended header %+v", eh)
+		}
+		if eh.HasCRC && eh.CRC != crc {
+			t.Fatalf("Wrong CRC %x", eh.CRC)
+		}
+		vals, err := ReadID3(bytes.NewReader(tag))
+		if err != nil {
+			t.Fatalf("Failed read: %v", err)
+		}
+		if vals["TIT2"] != "Title" || vals["TPE1"] != "Artist" {
+			t.Fatalf("Wrong values %q", vals)
+		}
+	}
+}
+
+func TestV24ExtendedHeader(t *testing.T) {
+	frames := append(frameBytes(4, "TIT2", []byte("\x03Title\x00")), frameBytes(4, "TPE1", []byte("\x03Artist\x00"))...)
+	// v2.4 takes the CRC over the padding as well as the frames
+	crc := crc32.ChecksumIEEE(append(append([]byte{}, frames...), make([]byte, 100)...))
+	restrictions := byte(0x42)
+	ext := v24ExtendedHeader(true, &crc, &restrictions)
+	tag := tagBytes(4, 0x40, ext, frames, make([]byte, 100))
+	parsed, err := ReadTag(bytes.NewReader(tag), WithCRCCheck())
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	expected := ExtendedHeader{Size: 15, Update: true, HasCRC: true, CRC: crc, HasRestrictions: true, Restrictions: 0x42}
+	if eh := parsed.ExtendedHeader(); eh == nil || *eh != expected {
+		t.Fatalf("Expected %+v got %+v", expected, eh)
+	}
+	vals, err := ReadID3(bytes.NewReader(tag))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	if vals["TIT2"] != "Title" || vals["TPE1"] != "Artist" {
+		t.Fatalf("Wrong values %q", vals)
+	}
+
+	frameCRC := crc32.ChecksumIEEE(frames)
+	tag = tagBytes(4, 0x40, v24ExtendedHeader(false, &frameCRC, nil), frames, make([]byte, 100))
+	if _, err := ReadTag(bytes.NewReader(tag), WithCRCCheck()); !errors.Is(err, ErrCRCMismatch) {
+		t.Fatalf("Expected the frames alone to mismatch got %v", err)
+	}
+
+	// written outside the package with the CRC over the frames and the 256
+	// bytes of padding
+	data, err := os.ReadFile("testdata/crc24.mp3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err = ReadTag(bytes.NewReader(data), WithCRCCheck())
+	if err != nil || parsed.Title() != "CRC over the padding" {
+		t.Fatalf("Failed read: %v", err)
+	}
+	if eh := parsed.ExtendedHeader(); eh == nil || eh.CRC != 0x39fe99c1 {
+		t.Fatalf("Wrong extended header %+v", eh)
+	}
+
+	plain := tagBytes(4, 0x40, v24ExtendedHeader(false, nil, nil), frames)
+	parsed, err = ReadTag(bytes.NewReader(plain))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	if eh := parsed.ExtendedHeader(); eh == nil || eh.Size != 6 || eh.Update || eh.HasCRC || eh.HasRestrictions {
+		t.Fatalf("Wrong extended header %+v", eh)
+	}
+}
+
+func TestCRCMismatch(t *testing.T) {
+	frames := frameBytes(4, "TIT2", []byte("\x03Title\x00"))
+	crc := crc32.ChecksumIEEE(frames) + 1
+	tag := tagBytes(4, 0x40, v24ExtendedHeader(false, &crc, nil), frames)
+	if _, err := ReadID3(bytes.NewReader(tag), WithCRCCheck()); !errors.Is(err, ErrCRCMismatch) {
+		t.Fatalf("Expected CRC mismatch got %v", err)
+	}
+	// only checked when asked for
+	if _, err := ReadID3(bytes.NewReader(tag)); err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+
+	v23 := tagBytes(3, 0x40, v23ExtendedHeader(&crc, 0), frameBytes(3, "TIT2", []byte("\x03Title\x00")))
+	if _, err := ReadID3(bytes.NewReader(v23), WithCRCCheck()); !errors.Is(err, ErrCRCMismatch) {
+		t.Fatalf("Expected CRC mismatch got %v", err)
+	}
+}
+
+func TestBadExtendedHeaders(t *testing.T) {
+	for _, ext := range [][]byte{
+		{0, 0, 0, 2, 0, 0},
+		{0, 0, 0, 6, 0x80, 0, 0, 0, 0, 0},
+		{0, 0, 0, 8, 1, 0x20, 5, 1},
+		{0, 0, 0, 9, 1, 0x10, 2, 1, 1},
+	} {
+		version := byte(4)
+		if ext[3] == 6 && ext[4] == 0x80 {
+			version = 3
+		}
+		tag := tagBytes(version, 0x40, ext, frameBytes(version, "TIT2", []byte("\x03Title\x00")))
+		if _, err := ReadID3(bytes.NewReader(tag)); err == nil {
+			t.Errorf("%v: expected an error", ext)
+		}
+	}
+}
+
+func TestRestrictions(t *testing.T) {
+	tests := []struct {
+		b    byte
+		want Restrictions
+	}{
+		{0x00, Restrictions{MaxFrames: 128, MaxTagSize: 1 << 20}},
+		// 32 frames 4KB, UTF-8 or Latin-1, 30 characters, JPEG or PNG exactly 64x64
+		{0xff, Restrictions{MaxFrames: 32, MaxTagSize: 4 << 10, TextEncoding: true, MaxTextLength: 30, ImageEncoding: true, MaxImageSize: 64, ExactImageSize: true}},
+		// 64 frames 128KB, 1024 characters, 256x256
+		{0x49, Restrictions{MaxFrames: 64, MaxTagSize: 128 << 10, MaxTextLength: 1024, MaxImageSize: 256}},
+		// 32 frames 40KB, UTF-8 or Latin-1, 128 characters, JPEG or PNG 64x64
+		{0xb6, Restrictions{MaxFrames: 32, MaxTagSize: 40 << 10, TextEncoding: true, MaxTextLength: 128, ImageEncoding: true, MaxImageSize: 64}},
+	}
+	for _, tc := range tests {
+		if got := ParseRestrictions(tc.b); got != tc.want {
+			t.Errorf("%08b: expected %+v got %+v", tc.b, tc.want, got)
+		}
+	}
+
+	restrictions := byte(0xb6)
+	tag := tagBytes(4, 0x40, v24ExtendedHeader(false, nil, &restrictions), frameBytes(4, "TIT2", []byte("\x03Title\x00")))
+	parsed, err := ReadTag(bytes.NewReader(tag))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	if r := parsed.Restrictions(); r == nil || *r != tests[3].want {
+		t.Fatalf("Wrong restrictions %+v", r)
+	}
+	parsed, err = ReadTag(bytes.NewReader(ivsID3))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	if r := parsed.Restrictions(); r != nil {
+		t.Fatalf("Expected no restrictions got %+v", r)
+	}
+}
+
+func TestWriteExtendedHeader(t *testing.T) {
+	frames := frameBytes(4, "TIT2", []byte("\x03Title\x00"))
+	crc := crc32.ChecksumIEEE(frames)
+	restrictions := byte(0xb6)
+	tag := tagBytes(4, 0x40, v24ExtendedHeader(true, &crc, &restrictions), frames)
+	parsed, err := ReadTag(bytes.NewReader(tag))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	if err := parsed.SetText("TPE1", "Artist"); err != nil {
+		t.Fatalf("Failed set: %v", err)
+	}
+	var buf bytes.Buffer
+	if _, err := WriteTag(&buf, parsed, WithPadding(16)); err != nil {
+		t.Fatalf("Failed write: %v", err)
+	}
+	// the CRC has to be worked out again for the new frame, and covers the
+	// padding after them
+	written, err := ReadTag(bytes.NewReader(buf.Bytes()), WithCRCCheck())
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	eh := written.ExtendedHeader()
+	if eh == nil || !eh.Update || !eh.HasCRC || !eh.HasRestrictions || eh.Restrictions != restrictions {
+		t.Fatalf("Wrong extended header %+v", eh)
+	}
+	if want := crc32.ChecksumIEEE(buf.Bytes()[10+eh.Size:]); eh.CRC != want || !bytes.HasSuffix(buf.Bytes(), make([]byte, 16)) {
+		t.Fatalf("Expected CRC %x got %x", want, eh.CRC)
+	}
+	if written.Artist() != "Artist" {
+		t.Fatalf("Wrong artist %q", written.Artist())
+	}
+
+	// in place the padding left over is covered too
+	old := tagBytes(4, 0x40, v24ExtendedHeader(false, &crc, nil), frames, make([]byte, 200))
+	f := tempFile(t, append(old, fakeAudio...))
+	parsed, err = ReadTag(f)
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	if err := parsed.SetText("TPE1", "Artist"); err != nil {
+		t.Fatalf("Failed set: %v", err)
+	}
+	if inPlace, err := UpdateTag(f, parsed); err != nil || !inPlace {
+		t.Fatalf("Expected an in place update got %v %v", inPlace, err)
+	}
+	updated := readAll(t, f)[:len(old)]
+	written, err = ReadTag(bytes.NewReader(updated), WithCRCCheck())
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	eh = written.ExtendedHeader()
+	if want := crc32.ChecksumIEEE(updated[10+eh.Size:]); eh == nil || eh.CRC != want {
+		t.Fatalf("Expected CRC %x got %+v", want, eh)
+	}
+
+	// padding that would take it past the restricted size means rewriting
+	small := byte(0xc0)
+	old = tagBytes(4, 0x40, v24ExtendedHeader(false, nil, &small), frames, make([]byte, 5000))
+	f = tempFile(t, append(old, fakeAudio...))
+	parsed, err = ReadTag(f)
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	if inPlace, err := UpdateTag(f, parsed); err != nil || inPlace {
+		t.Fatalf("Expected no in place update got %v %v", inPlace, err)
+	}
+
+	// v2.3's padding size has nowhere to go, the CRC still carries over
+	v23 := tagBytes(3, 0x40, v23ExtendedHeader(nil, 0), frameBytes(3, "TIT2", []byte("\x00Title\x00")))
+	parsed, err = ReadTag(bytes.NewReader(v23))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	buf.Reset()
+	if _, err := WriteTag(&buf, parsed); err != nil {
+		t.Fatalf("Failed write: %v", err)
+	}
+	if buf.Bytes()[5] != 0 {
+		t.Fatalf("Expected no extended header got flags %x", buf.Bytes()[5])
+	}
+}
+
+func TestWriteRestricted(t *testing.T) {
+	long := make([]byte, 5000)
+	for i := range long {
+		long[i] = 'a'
+	}
+	tests := []struct {
+		name         string
+		restrictions byte
+		frame        []byte
+	}{
+		// UTF-8 or Latin-1 only
+		{"utf16", 0x20, frameBytes(4, "TIT2", []byte("\x01\xff\xfeT\x00\x00\x00"))},
+		{"utf16 comment", 0x20, frameBytes(4, "COMM", []byte("\x01eng\xff\xfe\x00\x00\xff\xfeT\x00"))},
+		// 30 characters
+		{"text length", 0x18, frameBytes(4, "TIT2", []byte("\x03a title well over thirty characters"))},
+		// 4KB
+		{"tag size", 0xc0, frameBytes(4, "PRIV", append([]byte("owner\x00"), long...))},
+		// PNG or JPEG
+		{"image", 0x04, frameBytes(4, "APIC", []byte("\x03image/gif\x00\x03\x00GIF89a"))},
+	}
+	for _, tc := range tests {
+		restrictions := tc.restrictions
+		tag := tagBytes(4, 0x40, v24ExtendedHeader(false, nil, &restrictions), tc.frame)
+		parsed, err := ReadTag(bytes.NewReader(tag))
+		if err != nil {
+			t.Fatalf("%s: failed read: %v", tc.name, err)
+		}
+		if _, err := WriteTag(io.Discard, parsed); !errors.Is(err, ErrRestricted) {
+			t.Errorf("%s: expected ErrRestricted got %v", tc.name, err)
+		}
+	}
+
+	// 32 frames
+	restrictions := byte(0xc0)
+	var frames []byte
+	for i := 0; i < 33; i++ {
+		frames = append(frames, frameBytes(4, "TXXX", []byte(fmt.Sprintf("\x03%d\x00x", i)))...)
+	}
+	parsed, err := ReadTag(bytes.NewReader(tagBytes(4, 0x40, v24ExtendedHeader(false, nil, &restrictions), frames)))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	if _, err := WriteTag(io.Discard, parsed); !errors.Is(err, ErrRestricted) {
+		t.Fatalf("Expected ErrRestricted got %v", err)
+	}
+	parsed.DeleteFrame("TXXX")
+	if _, err := WriteTag(io.Discard, parsed); err != nil {
+		t.Fatalf("Failed write: %v", err)
+	}
+}
@@ -0,0 +1,233 @@
+package easyid3
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// text is the decoded value of the first frame with one of the IDs, most
+// fields have a v2.2 ID and a v2.3/v2.4 ID
+func (t *Tag) text(ids ...string) string {
+	frames := t.find(ids...)
+	if len(frames) == 0 {
+		return ""
+	}
+	return frames[0].Decoded()
+}
+
+// Texts is every value of the frames with the ID or key, v2.4 text frames
+// can have more than one like TPE1 with each of the artists. Frames that
+// aren't text come back as their Decoded value.
+func (t *Tag) Texts(id string) []string {
+	var texts []string
+	for _, f := range t.frames {
+		if f.FrameID != id && f.Key() != id {
+			continue
+		}
+		if strings.HasPrefix(f.FrameID, "T") {
+			texts = append(texts, f.values()...)
+		} else {
+			texts = append(texts, f.Decoded())
+		}
+	}
+	return texts
+}
+
+// userText is the value of the first TXXX with the description, matched
+// ignoring case since taggers don't agree on it
+func (t *Tag) userText(desc string) (string, bool) {
+	for _, f := range t.find("TXXX", "TXX") {
+		if d, _ := parseUserText(f.Data); strings.EqualFold(d, desc) {
+			return f.Decoded(), true
+		}
+	}
+	return "", false
+}
+
+// Title is TIT2
+func (t *Tag) Title() string {
+	return t.text("TIT2", "TT2")
+}
+
+// Artist is TPE1, the lead performer
+func (t *Tag) Artist() string {
+	return t.text("TPE1", "TP1")
+}
+
+// Album is TALB
+func (t *Tag) Album() string {
+	return t.text("TALB", "TAL")
+}
+
+// AlbumArtist is TPE2, which is what everything uses it for even if the
+// spec calls it the band
+func (t *Tag) AlbumArtist() string {
+	return t.text("TPE2", "TP2")
+}
+
+// Genre is TCON with the ID3v1 genre numbers turned into names, more than
+// one genre are joined with commas
+func (t *Tag) Genre() string {
+	return t.text("TCON", "TCO")
+}
+
+// Genres is each of the genres in TCON by name
+func (t *Tag) Genres() []string {
+	frames := t.find("TCON", "TCO")
+	if len(frames) == 0 {
+		return nil
+	}
+	return parseGenres(frames[0].text())
+}
+
+// Composer is TCOM
+func (t *Tag) Composer() string {
+	return t.text("TCOM", "TCM")
+}
+
+// Year is the year from TDRC in v2.4 or TYER before that, 0 when there
+// isn't one
+func (t *Tag) Year() int {
+	date := t.text("TDRC", "TYER", "TYE")
+	if len(date) < 4 {
+		return 0
+	}
+	year, err := strconv.Atoi(date[:4])
+	if err != nil {
+		return 0
+	}
+	return year
+}
+
+// Compilation is whether the iTunes TCMP frame marks the album as a
+// compilation, false when there isn't one
+func (t *Tag) Compilation() bool {
+	frames := t.find(FrameCompilation, "TCP")
+	return len(frames) > 0 && compilation(frames[0])
+}
+
+// TitleSort is TSOT, what to sort the title by
+func (t *Tag) TitleSort() string {
+	return t.text("TSOT", "TST", "XSOT")
+}
+
+// ArtistSort is TSOP
+func (t *Tag) ArtistSort() string {
+	return t.text("TSOP", "TSP", "XSOP")
+}
+
+// AlbumSort is TSOA
+func (t *Tag) AlbumSort() string {
+	return t.text("TSOA", "TSA", "XSOA")
+}
+
+// AlbumArtistSort is the iTunes TSO2
+func (t *Tag) AlbumArtistSort() string {
+	return t.text("TSO2", "TS2")
+}
+
+// ComposerSort is the iTunes TSOC
+func (t *Tag) ComposerSort() string {
+	return t.text("TSOC", "TSC")
+}
+
+// Track is the track number and total from TRCK, which is either "3" or
+// "3/12". total is 0 when there isn't one, ok is false when there's no
+// TRCK or it isn't numbers. The text itself is in the ReadID3 map.
+func (t *Tag) Track() (track, total int, ok bool) {
+	return parsePosition(t.text("TRCK", "TRK"))
+}
+
+// Disc is the disc number and total from TPOS, same format as Track. When
+// ok is false disc is 1 so single disc albums still sort, vinyl sides like
+// "A" aren't numbers either.
+func (t *Tag) Disc() (disc, total int, ok bool) {
+	disc, total, ok = parsePosition(t.text("TPOS", "TPA"))
+	if !ok {
+		return 1, 0, false
+	}
+	return disc, total, true
+}
+
+// DiscTotal is how many discs TPOS says there are, ok is false when it
+// doesn't say
+func (t *Tag) DiscTotal() (total int, ok bool) {
+	_, total, ok = t.Disc()
+	return total, ok && total > 0
+}
+
+// Length is TLEN, the length in milliseconds. ok is false when there's no
+// TLEN or it doesn't start with a number, so a TLEN of 0 still says so.
+func (t *Tag) Length() (length time.Duration, ok bool) {
+	ms, ok := leadingNumber(t.text("TLEN", "TLE"))
+	if !ok {
+		return 0, false
+	}
+	return time.Duration(math.Round(ms * float64(time.Millisecond))), true
+}
+
+// BPM is TBPM, the spec says it's an integer but some taggers write
+// "127.5" so it's a float. ok works the same as Length.
+func (t *Tag) BPM() (bpm float64, ok bool) {
+	return leadingNumber(t.text("TBPM", "TBP"))
+}
+
+// Comment is the text of the first COMM without a description, the ones
+// with a description are mostly player data like iTunNORM
+func (t *Tag) Comment() string {
+	for _, c := range t.Comments() {
+		if c.Description == "" {
+			return c.Text
+		}
+	}
+	return ""
+}
+
+// leadingNumber parses the number at the start of s and ignores whatever
+// is after it, like "215000ms" or "120 BPM". A comma works as the decimal
+// point too.
+func leadingNumber(s string) (float64, bool) {
+	s = strings.TrimSpace(s)
+	end, point := 0, false
+	for ; end < len(s); end++ {
+		c := s[end]
+		if (c == '.' || c == ',') && !point {
+			point = true
+			continue
+		}
+		if c < '0' || c > '9' {
+			break
+		}
+	}
+	num := strings.TrimRight(strings.Replace(s[:end], ",", ".", 1), ".")
+	if num == "" || num[0] == '.' {
+		return 0, false
+	}
+	n, err := strconv.ParseFloat(num, 64)
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
+
+// parsePosition splits "n/total", ok is false when either of them isn't a
+// number. An empty total is left as 0.
+func parsePosition(s string) (n, total int, ok bool) {
+	parts := strings.SplitN(s, "/", 2)
+	n, err := strconv.Atoi(strings.TrimSpace(parts[0]))
+	if err != nil || n < 0 {
+		return 0, 0, false
+	}
+	if len(parts) == 1 {
+		return n, 0, true
+	}
+	if t := strings.TrimSpace(parts[1]); t != "" {
+		total, err = strconv.Atoi(t)
+		if err != nil || total < 0 {
+			return 0, 0, false
+		}
+	}
+	return n, total, true
+}
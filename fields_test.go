@@ -0,0 +1,208 @@
+package easyid3
+
+import (
+	"bytes"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestFields(t *testing.T) {
+	text := func(s string) []byte { return []byte("\x03" + s + "\x00") }
+	for _, version := range []byte{3, 4} {
+		year := "TDRC"
+		if version == 3 {
+			year = "TYER"
+		}
+		tag := tagBytes(version, 0,
+			frameBytes(version, "TIT2", text("Title")),
+			frameBytes(version, "TPE1", text("Artist")),
+			frameBytes(version, "TALB", text("Album")),
+			frameBytes(version, "TPE2", text("Album Artist")),
+			frameBytes(version, "TCON", text("Jazz")),
+			frameBytes(version, "TCOM", text("Composer")),
+			frameBytes(version, year, text("1999-03-01")),
+			frameBytes(version, "TRCK", text("3/12")),
+			frameBytes(version, "TPOS", text("2")),
+			frameBytes(version, "COMM", []byte("\x03engiTunNORM\x00 0000044E")),
+			frameBytes(version, "COMM", []byte("\x03eng\x00Great song")),
+		)
+		parsed, err := ReadTag(bytes.NewReader(tag))
+		if err != nil {
+			t.Fatalf("v2.%d failed read: %v", version, err)
+		}
+		checkFields(t, parsed, "Title", "Artist", "Album", "Album Artist", "Jazz", "Composer", "Great song")
+		if y := parsed.Year(); y != 1999 {
+			t.Errorf("v2.%d wrong year %d", version, y)
+		}
+		if n, total, ok := parsed.Track(); !ok || n != 3 || total != 12 {
+			t.Errorf("v2.%d wrong track %d/%d", version, n, total)
+		}
+		if n, total, ok := parsed.Disc(); !ok || n != 2 || total != 0 {
+			t.Errorf("v2.%d wrong disc %d/%d", version, n, total)
+		}
+	}
+}
+
+func TestFieldsV22(t *testing.T) {
+	parsed, err := ReadTag(bytes.NewReader(v22ID3))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	checkFields(t, parsed, "Long Season", "Fishmans", "Seasons", "", "", "", "")
+	if n, total, ok := parsed.Track(); !ok || n != 1 || total != 1 {
+		t.Errorf("Wrong track %d/%d", n, total)
+	}
+}
+
+func TestFieldsMissing(t *testing.T) {
+	parsed, err := ReadTag(bytes.NewReader(tagBytes(4, 0, frameBytes(4, "TRCK", []byte("\x03x/y\x00")))))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	checkFields(t, parsed, "", "", "", "", "", "", "")
+	if parsed.Year() != 0 {
+		t.Errorf("Wrong year %d", parsed.Year())
+	}
+	if n, total, ok := parsed.Track(); ok || n != 0 || total != 0 {
+		t.Errorf("Wrong track %d/%d", n, total)
+	}
+	if _, _, ok := parsed.Disc(); ok {
+		t.Error("Expected no disc")
+	}
+}
+
+func TestParsePosition(t *testing.T) {
+	for s, want := range map[string][3]int{
+		"7":       {7, 0, 1},
+		"7/12":    {7, 12, 1},
+		"07/012":  {7, 12, 1},
+		" 7 / 12": {7, 12, 1},
+		"7/":      {7, 0, 1},
+		"0":       {0, 0, 1},
+		"":        {0, 0, 0},
+		"x":       {0, 0, 0},
+		"7/x":     {0, 0, 0},
+		"-1":      {0, 0, 0},
+		"A1":      {0, 0, 0},
+	} {
+		n, total, ok := parsePosition(s)
+		if n != want[0] || total != want[1] || ok != (want[2] == 1) {
+			t.Errorf("%q got %d/%d %v", s, n, total, ok)
+		}
+	}
+}
+
+func checkFields(t *testing.T, tag *Tag, title, artist, album, albumArtist, genre, composer, comment string) {
+	t.Helper()
+	got := []string{tag.Title(), tag.Artist(), tag.Album(), tag.AlbumArtist(), tag.Genre(), tag.Composer(), tag.Comment()}
+	want := []string{title, artist, album, albumArtist, genre, composer, comment}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Wrong fields got %q want %q", got, want)
+			return
+		}
+	}
+}
+
+func TestDisc(t *testing.T) {
+	tag := NewTag()
+	if disc, total, ok := tag.Disc(); ok || disc != 1 || total != 0 {
+		t.Errorf("Wrong default disc %d/%d", disc, total)
+	}
+	if _, ok := tag.DiscTotal(); ok {
+		t.Error("Expected no disc total")
+	}
+	if err := tag.SetText("TPOS", "A"); err != nil {
+		t.Fatal(err)
+	}
+	if disc, _, ok := tag.Disc(); ok || disc != 1 {
+		t.Errorf("Wrong vinyl side disc %d", disc)
+	}
+
+	if err := tag.SetDisc(2, 3); err != nil {
+		t.Fatal(err)
+	}
+	if err := tag.SetTrack(7, 0); err != nil {
+		t.Fatal(err)
+	}
+	read := rewrite(t, tag)
+	if read.text("TPOS") != "2/3" || read.text("TRCK") != "7" {
+		t.Errorf("Wrong TPOS %q TRCK %q", read.text("TPOS"), read.text("TRCK"))
+	}
+	if disc, total, ok := read.Disc(); !ok || disc != 2 || total != 3 {
+		t.Errorf("Wrong disc %d/%d", disc, total)
+	}
+	if total, ok := read.DiscTotal(); !ok || total != 3 {
+		t.Errorf("Wrong disc total %d", total)
+	}
+	if n, total, ok := read.Track(); !ok || n != 7 || total != 0 {
+		t.Errorf("Wrong track %d/%d", n, total)
+	}
+}
+
+func TestLengthAndBPM(t *testing.T) {
+	tag := NewTag()
+	if _, ok := tag.Length(); ok {
+		t.Error("Expected no length")
+	}
+	if _, ok := tag.BPM(); ok {
+		t.Error("Expected no BPM")
+	}
+	for s, want := range map[string]time.Duration{
+		"215000":    215 * time.Second,
+		" 215000ms": 215 * time.Second,
+		"0":         0,
+		"1500.6":    1500600 * time.Microsecond,
+	} {
+		if err := tag.SetText("TLEN", s); err != nil {
+			t.Fatal(err)
+		}
+		if got, ok := tag.Length(); !ok || got != want {
+			t.Errorf("TLEN %q got %v %v", s, got, ok)
+		}
+	}
+	for s, want := range map[string]float64{
+		"120":    120,
+		"127.5":  127.5,
+		"127,5":  127.5,
+		"98 BPM": 98,
+		"140.":   140,
+		"0":      0,
+	} {
+		if err := tag.SetText("TBPM", s); err != nil {
+			t.Fatal(err)
+		}
+		if got, ok := tag.BPM(); !ok || got != want {
+			t.Errorf("TBPM %q got %v %v", s, got, ok)
+		}
+	}
+	for _, s := range []string{"", "fast", ".5", "-120"} {
+		if err := tag.SetText("TBPM", s); err != nil {
+			t.Fatal(err)
+		}
+		if got, ok := tag.BPM(); ok {
+			t.Errorf("TBPM %q expected not ok got %v", s, got)
+		}
+	}
+
+	if err := tag.SetLength(3*time.Minute + 1500*time.Microsecond); err != nil {
+		t.Fatal(err)
+	}
+	if err := tag.SetBPM(120); err != nil {
+		t.Fatal(err)
+	}
+	read := rewrite(t, tag)
+	if read.text("TLEN") != "180002" || read.text("TBPM") != "120" {
+		t.Errorf("Wrong TLEN %q TBPM %q", read.text("TLEN"), read.text("TBPM"))
+	}
+	if err := tag.SetBPM(127.5); err != nil || tag.text("TBPM") != "127.5" {
+		t.Errorf("Wrong TBPM %q %v", tag.text("TBPM"), err)
+	}
+	if err := tag.SetLength(-time.Second); err == nil {
+		t.Error("Expected an error for a negative length")
+	}
+	if err := tag.SetBPM(math.NaN()); err == nil {
+		t.Error("Expected an error for a NaN BPM")
+	}
+}
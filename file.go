@@ -0,0 +1,45 @@
+package easyid3
+
+import (
+	"errors"
+	"io"
+	"os"
+)
+
+// ReadID3File reads the tag from the file at path. It tries the tag at the
+// start, then a v2.4 tag appended to the end and then ID3v1. When the file
+// has none of them the error is ErrNoTag.
+func ReadID3File(path string, opts ...Option) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return readAnyTag(f, opts)
+}
+
+// readAnyTag tries the tags in the order ReadID3File does
+func readAnyTag(f io.ReadSeeker, opts []Option) (map[string]string, error) {
+	props, err := ReadID3(f, opts...)
+	if !noTag(err) {
+		return props, err
+	}
+	tag, err := ReadAppendedTag(f, opts...)
+	if tag != nil {
+		return frameMap(tag.frames, tag.options()), err
+	}
+	if !noTag(err) {
+		return nil, err
+	}
+	props, err = readID3v1Map(f, newOptions(opts))
+	if noTag(err) {
+		return nil, ErrNoTag
+	}
+	return props, err
+}
+
+// noTag is whether err means there's no tag, files too short to have one
+// run out first
+func noTag(err error) bool {
+	return errors.Is(err, ErrNoTag) || errors.Is(err, io.EOF)
+}
@@ -0,0 +1,50 @@
+package easyid3
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReadID3File(t *testing.T) {
+	dir := t.TempDir()
+	prepended := tagBytes(4, 0, frameBytes(4, "TIT2", []byte("\x03Prepended\x00")))
+	appended := appendedTagBytes(frameBytes(4, "TIT2", []byte("\x03Appended\x00")))
+	files := map[string][]byte{
+		"prepended.mp3": append(append([]byte{}, prepended...), fakeAudio...),
+		"appended.mp3":  append(append([]byte{}, fakeAudio...), appended...),
+		"v1.mp3":        append(append([]byte{}, fakeAudio...), id3v1Bytes("ID3v1", "", "", "", "", 0, 255)...),
+		"none.mp3":      fakeAudio,
+		"empty.mp3":     {},
+	}
+	for name, contents := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), contents, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for name, title := range map[string]string{
+		"prepended.mp3": "Prepended",
+		"appended.mp3":  "Appended",
+		"v1.mp3":        "ID3v1",
+	} {
+		props, err := ReadID3File(filepath.Join(dir, name))
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+		if props["TIT2"] != title {
+			t.Errorf("%s: wrong title %q", name, props["TIT2"])
+		}
+	}
+	for _, name := range []string{"none.mp3", "empty.mp3"} {
+		_, err := ReadID3File(filepath.Join(dir, name))
+		if !errors.Is(err, ErrNoTag) {
+			t.Errorf("%s: expected ErrNoTag got %v", name, err)
+		}
+	}
+	_, err := ReadID3File(filepath.Join(dir, "missing.mp3"))
+	if err == nil || errors.Is(err, ErrNoTag) || !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Expected a not exist error got %v", err)
+	}
+}
@@ -0,0 +1,73 @@
+package easyid3
+
+// frameFlag is where a flag lives in the frame flags for v2.3 and v2.4,
+// status flags are in the first byte and format flags in the second
+type frameFlag struct {
+	index    int
+	v23, v24 byte
+}
+
+var (
+	flagTagAlter   = frameFlag{0, 0x80, 0x40}
+	flagFileAlter  = frameFlag{0, 0x40, 0x20}
+	flagReadOnly   = frameFlag{0, 0x20, 0x10}
+	flagGrouped    = frameFlag{1, 0x20, 0x40}
+	flagCompressed = frameFlag{1, 0x80, 0x08}
+	flagEncrypted  = frameFlag{1, 0x40, 0x04}
+	// only v2.4 has these
+	flagUnsync     = frameFlag{1, 0, 0x02}
+	flagDataLength = frameFlag{1, 0, 0x01}
+)
+
+// hasFlag checks the flag in the layout for the frame's version, v2.2
+// frames have no flags at all and neither do frames built without them
+func (f *Frame) hasFlag(flag frameFlag) bool {
+	if len(f.Flags) <= flag.index {
+		return false
+	}
+	switch f.Version {
+	case 3:
+		return f.Flags[flag.index]&flag.v23 != 0
+	case 4:
+		return f.Flags[flag.index]&flag.v24 != 0
+	}
+	return false
+}
+
+// TagAlterPreserve is whether the frame should be kept when the tag is
+// changed and the frame isn't known, the flag being set means discard it
+func (f *Frame) TagAlterPreserve() bool {
+	return !f.hasFlag(flagTagAlter)
+}
+
+// FileAlterPreserve is whether the frame should be kept when the audio
+// changes, again the flag being set means discard it
+func (f *Frame) FileAlterPreserve() bool {
+	return !f.hasFlag(flagFileAlter)
+}
+
+func (f *Frame) ReadOnly() bool {
+	return f.hasFlag(flagReadOnly)
+}
+
+func (f *Frame) Grouped() bool {
+	return f.hasFlag(flagGrouped)
+}
+
+func (f *Frame) Compressed() bool {
+	return f.hasFlag(flagCompressed)
+}
+
+func (f *Frame) Encrypted() bool {
+	return f.hasFlag(flagEncrypted)
+}
+
+// Unsynchronised is the v2.4 frame flag, it doesn't know about the tag
+// header flag that unsynchronises every frame
+func (f *Frame) Unsynchronised() bool {
+	return f.hasFlag(flagUnsync)
+}
+
+func (f *Frame) HasDataLength() bool {
+	return f.hasFlag(flagDataLength)
+}
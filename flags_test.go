@@ -0,0 +1,64 @@
+package easyid3
+
+import "testing"
+
+func TestFrameFlags(t *testing.T) {
+	type accessor struct {
+		name string
+		get  func(*Frame) bool
+	}
+	accessors := []accessor{
+		{"TagAlterPreserve", func(f *Frame) bool { return !f.TagAlterPreserve() }},
+		{"FileAlterPreserve", func(f *Frame) bool { return !f.FileAlterPreserve() }},
+		{"ReadOnly", (*Frame).ReadOnly},
+		{"Grouped", (*Frame).Grouped},
+		{"Compressed", (*Frame).Compressed},
+		{"Encrypted", (*Frame).Encrypted},
+		{"Unsynchronised", (*Frame).Unsynchronised},
+		{"HasDataLength", (*Frame).HasDataLength},
+	}
+	// the flags that turn each accessor on, zero when the version doesn't
+	// have it
+	layouts := map[byte][][2]byte{
+		3: {{0x80, 0}, {0x40, 0}, {0x20, 0}, {0, 0x20}, {0, 0x80}, {0, 0x40}, {0, 0}, {0, 0}},
+		4: {{0x40, 0}, {0x20, 0}, {0x10, 0}, {0, 0x40}, {0, 0x08}, {0, 0x04}, {0, 0x02}, {0, 0x01}},
+	}
+	for version, layout := range layouts {
+		for i, flags := range layout {
+			if flags == [2]byte{} {
+				continue
+			}
+			f := &Frame{Version: version, Flags: []byte{flags[0], flags[1]}}
+			for j, a := range accessors {
+				if a.get(f) != (i == j) {
+					t.Errorf("v2.%d flags %x %s is %v", version, flags, a.name, a.get(f))
+				}
+			}
+		}
+		none := &Frame{Version: version, Flags: []byte{0, 0}}
+		for _, a := range accessors {
+			if a.get(none) {
+				t.Errorf("v2.%d no flags but %s", version, a.name)
+			}
+		}
+	}
+	// v2.2 has no flags, even if something ends up in there
+	v22 := &Frame{Version: 2, Flags: []byte{0xff, 0xff}}
+	for _, a := range accessors {
+		if a.get(v22) {
+			t.Errorf("v2.2 has %s", a.name)
+		}
+	}
+	// a frame built by hand might not have them all
+	for _, version := range []byte{3, 4} {
+		for _, a := range accessors {
+			if a.get(&Frame{Version: version}) {
+				t.Errorf("v2.%d without flags has %s", version, a.name)
+			}
+		}
+		status := &Frame{Version: version, Flags: []byte{0xff}}
+		if !status.ReadOnly() || status.Compressed() || status.HasDataLength() {
+			t.Errorf("v2.%d wrong flags with only the status byte", version)
+		}
+	}
+}
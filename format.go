@@ -0,0 +1,110 @@
+package easyid3
+
+import (
+	"bytes"
+	"compress/zlib"
+	"io"
+)
+
+// unformat undoes what the format flags did to the data. The group ID,
+// encryption method and data length indicator are taken off the front in
+// the order the version keeps them in. The v2.4 data length indicator gives
+// the size once everything is undone, unsynchronisation is undone before
+// decompressing. v2.3 puts the decompressed size in front. Encrypted frames
+// stop before decompressing, decryptFrames finishes them off once the ENCR
+// frames have all been read.
+func (f *Frame) unformat(tagUnsync bool, maxSize int) error {
+	// v2.3 keeps them in the order size, method, group and v2.4 in the
+	// order group, method, length
+	if f.Version == 3 && f.Compressed() {
+		if len(f.Data) < 4 {
+			return f.errorf("too short for its decompressed size")
+		}
+		f.DataLength = beInt(f.Data[:4])
+		f.Data = f.Data[4:]
+	}
+	if f.Version == 4 && f.Grouped() {
+		err := f.takeGroupID()
+		if err != nil {
+			return err
+		}
+	}
+	if f.Encrypted() {
+		if len(f.Data) < 1 {
+			return f.errorf("too short for its encryption method")
+		}
+		f.EncryptionMethod = f.Data[0]
+		f.Data = f.Data[1:]
+	}
+	if f.Version == 3 && f.Grouped() {
+		err := f.takeGroupID()
+		if err != nil {
+			return err
+		}
+	}
+	if f.HasDataLength() {
+		if len(f.Data) < 4 {
+			return f.errorf("too short for its data length indicator")
+		}
+		f.DataLength = synsafeInt(f.Data[:4])
+		f.Data = f.Data[4:]
+	}
+	if f.Version == 4 && (tagUnsync || f.Unsynchronised()) {
+		f.Data = deunsync(f.Data)
+	}
+	if f.Encrypted() {
+		return nil
+	}
+	return f.decompress(maxSize)
+}
+
+func (f *Frame) takeGroupID() error {
+	if len(f.Data) < 1 {
+		return f.errorf("too short for its group")
+	}
+	f.GroupID = f.Data[0]
+	f.Data = f.Data[1:]
+	return nil
+}
+
+// decompress inflates compressed frames and checks the data came out the
+// size the frame said it would
+func (f *Frame) decompress(maxSize int) error {
+	if f.Compressed() {
+		err := f.inflate(f.DataLength, maxSize)
+		if err != nil {
+			return err
+		}
+	}
+	if f.DataLength >= 0 && f.DataLength != len(f.Data) {
+		return f.errorf("data length indicator says %d bytes but there are %d", f.DataLength, len(f.Data))
+	}
+	return nil
+}
+
+// inflate decompresses the zlib data, stopping at maxSize so a tiny frame
+// can't blow up into gigabytes. size is what the frame says it will be, -1
+// when it doesn't say.
+func (f *Frame) inflate(size, maxSize int) error {
+	if size > maxSize {
+		return f.errorf("decompresses to %d bytes, more than the %d byte limit", size, maxSize)
+	}
+	zr, err := zlib.NewReader(bytes.NewReader(f.Data))
+	if err != nil {
+		return f.errorf("decompressing: %w", err)
+	}
+	defer zr.Close()
+	buf := &bytes.Buffer{}
+	if size > 0 {
+		buf.Grow(size)
+	}
+	n, err := io.Copy(buf, io.LimitReader(zr, int64(maxSize)+1))
+	if err != nil {
+		return f.errorf("decompressing: %w", err)
+	}
+	if n > int64(maxSize) {
+		return f.errorf("decompresses to more than the %d byte limit", maxSize)
+	}
+	f.Data = buf.Bytes()
+	return nil
+}
@@ -0,0 +1,105 @@
+package easyid3
+
+import (
+	"bytes"
+	"compress/zlib"
+	"strings"
+	"testing"
+)
+
+func deflate(b []byte) []byte {
+	var buf bytes.Buffer
+	zw := zlib.NewWriter(&buf)
+	zw.Write(b)
+	zw.Close()
+	return buf.Bytes()
+}
+
+func TestCompressedFrames(t *testing.T) {
+	lyrics := []byte("\x03eng\x00" + strings.Repeat("over and over\n", 500))
+	picture := apicData(0, "image/jpeg", 3, []byte("\x00"), syncyJPEG)
+	v23Size := []byte{0, 0, byte(len(lyrics) >> 8), byte(len(lyrics))}
+
+	v23 := tagBytes(3, 0,
+		flaggedFrameBytes(3, "USLT", 0, 0x80, append(v23Size, deflate(lyrics)...)),
+		frameBytes(3, "TIT2", []byte("\x00Title\x00")),
+	)
+	v24 := tagBytes(4, 0,
+		flaggedFrameBytes(4, "USLT", 0, 0x09, append(synsafeBytes(len(lyrics)), deflate(lyrics)...)),
+		// compressed and unsynchronised
+		flaggedFrameBytes(4, "APIC", 0, 0x0b, append(synsafeBytes(len(picture)), unsyncBytes(deflate(picture))...)),
+		frameBytes(4, "TIT2", []byte("\x03Title\x00")),
+	)
+	for _, tag := range [][]byte{v23, v24} {
+		parsed, err := ReadTag(bytes.NewReader(tag))
+		if err != nil {
+			t.Fatalf("Failed read: %v", err)
+		}
+		l := parsed.Lyrics()
+		if len(l) != 1 || l[0].Lyrics != string(lyrics[5:]) {
+			t.Fatalf("Wrong lyrics %+v", l)
+		}
+		if pictures := parsed.Pictures(); tag[3] == 4 && (len(pictures) != 1 || !bytes.Equal(pictures[0].Data, syncyJPEG)) {
+			t.Fatalf("Wrong pictures %+v", pictures)
+		}
+		vals, err := ReadID3(bytes.NewReader(tag))
+		if err != nil {
+			t.Fatalf("Failed read: %v", err)
+		}
+		if vals["TIT2"] != "Title" {
+			t.Fatalf("Wrong title %q", vals["TIT2"])
+		}
+	}
+}
+
+func TestCompressionLimits(t *testing.T) {
+	bomb := deflate(make([]byte, 1<<20))
+	tag := tagBytes(4, 0, flaggedFrameBytes(4, "PRIV", 0, 0x08, bomb))
+	_, err := ReadID3(bytes.NewReader(tag), WithMaxFrameSize(64<<10))
+	if err == nil || !strings.Contains(err.Error(), "limit") {
+		t.Fatalf("Expected limit error got %v", err)
+	}
+	// declared size over the limit is caught before decompressing
+	tag = tagBytes(4, 0, flaggedFrameBytes(4, "PRIV", 0, 0x09, append(synsafeBytes(1<<20), bomb...)))
+	_, err = ReadID3(bytes.NewReader(tag), WithMaxFrameSize(64<<10))
+	if err == nil || !strings.Contains(err.Error(), "limit") {
+		t.Fatalf("Expected limit error got %v", err)
+	}
+	if _, err := ReadID3(bytes.NewReader(tag)); err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+
+	tag = tagBytes(4, 0, flaggedFrameBytes(4, "TIT2", 0, 0x08, []byte("not zlib at all")))
+	_, err = ReadID3(bytes.NewReader(tag))
+	if err == nil || !strings.Contains(err.Error(), "TIT2") {
+		t.Fatalf("Expected a TIT2 error got %v", err)
+	}
+}
+
+func TestDataLengthIndicator(t *testing.T) {
+	title := []byte("\x03Title\x00")
+	tag := tagBytes(4, 0,
+		// some taggers set it without compressing anything
+		flaggedFrameBytes(4, "TIT2", 0, 0x01, append(synsafeBytes(len(title)), title...)),
+		flaggedFrameBytes(4, "TPE1", 0, 0x03, append(synsafeBytes(4), unsyncBytes([]byte("\x00\xff\xe0A"))...)),
+	)
+	parsed, err := ReadTag(bytes.NewReader(tag))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	if parsed.frames[0].DataLength != len(title) || parsed.frames[0].Decoded() != "Title" {
+		t.Fatalf("Wrong frame %+v", parsed.frames[0])
+	}
+	if parsed.frames[1].DataLength != 4 || parsed.frames[1].Decoded() != "ÿàA" {
+		t.Fatalf("Wrong frame %+v", parsed.frames[1])
+	}
+
+	bad := tagBytes(4, 0, flaggedFrameBytes(4, "TIT2", 0, 0x01, append(synsafeBytes(100), title...)))
+	if _, err := ReadID3(bytes.NewReader(bad)); err == nil || !strings.Contains(err.Error(), "data length") {
+		t.Fatalf("Expected a data length error got %v", err)
+	}
+	short := tagBytes(4, 0, flaggedFrameBytes(4, "TIT2", 0, 0x01, []byte{0, 0}))
+	if _, err := ReadID3(bytes.NewReader(short)); err == nil {
+		t.Fatal("Expected an error for a short data length indicator")
+	}
+}
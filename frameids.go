@@ -0,0 +1,281 @@
+package easyid3
+
+import "strings"
+
+// The frame IDs from the v2.3 and v2.4 specs
+const (
+	FrameAudioEncryption     = "AENC"
+	FramePicture             = "APIC"
+	FrameAudioSeekPoint      = "ASPI"
+	FrameComment             = "COMM"
+	FrameCommercial          = "COMR"
+	FrameEncryption          = "ENCR"
+	FrameEqualisation2       = "EQU2"
+	FrameEqualisation        = "EQUA"
+	FrameEventTiming         = "ETCO"
+	FrameObject              = "GEOB"
+	FrameGroup               = "GRID"
+	FrameInvolvedPeople      = "IPLS"
+	FrameLink                = "LINK"
+	FrameMusicCDID           = "MCDI"
+	FrameLocationLookup      = "MLLT"
+	FrameOwnership           = "OWNE"
+	FramePlayCount           = "PCNT"
+	FramePopularimeter       = "POPM"
+	FramePositionSync        = "POSS"
+	FramePrivate             = "PRIV"
+	FrameBufferSize          = "RBUF"
+	FrameVolume2             = "RVA2"
+	FrameVolume              = "RVAD"
+	FrameReverb              = "RVRB"
+	FrameSeek                = "SEEK"
+	FrameSignature           = "SIGN"
+	FrameSyncedLyrics        = "SYLT"
+	FrameSyncedTempo         = "SYTC"
+	FrameAlbum               = "TALB"
+	FrameBPM                 = "TBPM"
+	FrameComposer            = "TCOM"
+	FrameGenre               = "TCON"
+	FrameCopyright           = "TCOP"
+	FrameDate                = "TDAT"
+	FrameEncodingTime        = "TDEN"
+	FramePlaylistDelay       = "TDLY"
+	FrameOriginalReleaseTime = "TDOR"
+	FrameRecordingTime       = "TDRC"
+	FrameReleaseTime         = "TDRL"
+	FrameTaggingTime         = "TDTG"
+	FrameEncodedBy           = "TENC"
+	FrameLyricist            = "TEXT"
+	FrameFileType            = "TFLT"
+	FrameTime                = "TIME"
+	FrameInvolvedPeopleList  = "TIPL"
+	FrameContentGroup        = "TIT1"
+	FrameTitle               = "TIT2"
+	FrameSubtitle            = "TIT3"
+	FrameInitialKey          = "TKEY"
+	FrameLanguage            = "TLAN"
+	FrameLength              = "TLEN"
+	FrameMusicianCredits     = "TMCL"
+	FrameMediaType           = "TMED"
+	FrameMood                = "TMOO"
+	FrameOriginalAlbum       = "TOAL"
+	FrameOriginalFilename    = "TOFN"
+	FrameOriginalLyricist    = "TOLY"
+	FrameOriginalArtist      = "TOPE"
+	FrameOriginalYear        = "TORY"
+	FrameFileOwner           = "TOWN"
+	FrameArtist              = "TPE1"
+	FrameAlbumArtist         = "TPE2"
+	FrameConductor           = "TPE3"
+	FrameRemixer             = "TPE4"
+	FrameDisc                = "TPOS"
+	FrameProducedNotice      = "TPRO"
+	FramePublisher           = "TPUB"
+	FrameTrack               = "TRCK"
+	FrameRecordingDates      = "TRDA"
+	FrameRadioStation        = "TRSN"
+	FrameRadioStationOwner   = "TRSO"
+	FrameSize                = "TSIZ"
+	FrameAlbumSortOrder      = "TSOA"
+	FramePerformerSortOrder  = "TSOP"
+	FrameTitleSortOrder      = "TSOT"
+	FrameISRC                = "TSRC"
+	FrameEncoderSettings     = "TSSE"
+	FrameSetSubtitle         = "TSST"
+	FrameUserText            = "TXXX"
+	FrameYear                = "TYER"
+	FrameUniqueFileID        = "UFID"
+	FrameTermsOfUse          = "USER"
+	FrameLyrics              = "USLT"
+	FrameCommercialURL       = "WCOM"
+	FrameCopyrightURL        = "WCOP"
+	FrameAudioFileURL        = "WOAF"
+	FrameArtistURL           = "WOAR"
+	FrameAudioSourceURL      = "WOAS"
+	FrameRadioStationURL     = "WORS"
+	FramePaymentURL          = "WPAY"
+	FramePublisherURL        = "WPUB"
+	FrameUserURL             = "WXXX"
+)
+
+// FrameKind is how a frame's data is laid out
+type FrameKind int
+
+const (
+	// KindBinary frames have a layout of their own
+	KindBinary FrameKind = iota
+	// KindText frames are an encoding byte and then text, TXXX has a
+	// description in front of it
+	KindText
+	// KindURL frames are an ISO-8859-1 URL, WXXX has a description in front
+	// of it
+	KindURL
+	// KindPair frames are text frames that alternate between a role and who
+	// had it
+	KindPair
+	// KindComment frames are an encoding byte, a language and then text,
+	// some with a description in between
+	KindComment
+)
+
+// FrameInfo is what the specs say about a frame ID
+type FrameInfo struct {
+	ID          string
+	Description string
+	Kind        FrameKind
+	// V23 and V24 are the versions the frame is in
+	V23, V24 bool
+}
+
+// frameInfos is all the declared frames from both specs
+var frameInfos = map[string]FrameInfo{
+	FrameAudioEncryption:     {ID: FrameAudioEncryption, Description: "Audio encryption", Kind: KindBinary, V23: true, V24: true},
+	FramePicture:             {ID: FramePicture, Description: "Attached picture", Kind: KindBinary, V23: true, V24: true},
+	FrameAudioSeekPoint:      {ID: FrameAudioSeekPoint, Description: "Audio seek point index", Kind: KindBinary, V24: true},
+	FrameComment:             {ID: FrameComment, Description: "Comments", Kind: KindComment, V23: true, V24: true},
+	FrameCommercial:          {ID: FrameCommercial, Description: "Commercial frame", Kind: KindBinary, V23: true, V24: true},
+	FrameEncryption:          {ID: FrameEncryption, Description: "Encryption method registration", Kind: KindBinary, V23: true, V24: true},
+	FrameEqualisation2:       {ID: FrameEqualisation2, Description: "Equalisation (2)", Kind: KindBinary, V24: true},
+	FrameEqualisation:        {ID: FrameEqualisation, Description: "Equalisation", Kind: KindBinary, V23: true},
+	FrameEventTiming:         {ID: FrameEventTiming, Description: "Event timing codes", Kind: KindBinary, V23: true, V24: true},
+	FrameObject:              {ID: FrameObject, Description: "General encapsulated object", Kind: KindBinary, V23: true, V24: true},
+	FrameGroup:               {ID: FrameGroup, Description: "Group identification registration", Kind: KindBinary, V23: true, V24: true},
+	FrameInvolvedPeople:      {ID: FrameInvolvedPeople, Description: "Involved people list", Kind: KindPair, V23: true},
+	FrameLink:                {ID: FrameLink, Description: "Linked information", Kind: KindBinary, V23: true, V24: true},
+	FrameMusicCDID:           {ID: FrameMusicCDID, Description: "Music CD identifier", Kind: KindBinary, V23: true, V24: true},
+	FrameLocationLookup:      {ID: FrameLocationLookup, Description: "MPEG location lookup table", Kind: KindBinary, V23: true, V24: true},
+	FrameOwnership:           {ID: FrameOwnership, Description: "Ownership frame", Kind: KindBinary, V23: true, V24: true},
+	FramePlayCount:           {ID: FramePlayCount, Description: "Play counter", Kind: KindBinary, V23: true, V24: true},
+	FramePopularimeter:       {ID: FramePopularimeter, Description: "Popularimeter", Kind: KindBinary, V23: true, V24: true},
+	FramePositionSync:        {ID: FramePositionSync, Description: "Position synchronisation frame", Kind: KindBinary, V23: true, V24: true},
+	FramePrivate:             {ID: FramePrivate, Description: "Private frame", Kind: KindBinary, V23: true, V24: true},
+	FrameBufferSize:          {ID: FrameBufferSize, Description: "Recommended buffer size", Kind: KindBinary, V23: true, V24: true},
+	FrameVolume2:             {ID: FrameVolume2, Description: "Relative volume adjustment (2)", Kind: KindBinary, V24: true},
+	FrameVolume:              {ID: FrameVolume, Description: "Relative volume adjustment", Kind: KindBinary, V23: true},
+	FrameReverb:              {ID: FrameReverb, Description: "Reverb", Kind: KindBinary, V23: true, V24: true},
+	FrameSeek:                {ID: FrameSeek, Description: "Seek frame", Kind: KindBinary, V24: true},
+	FrameSignature:           {ID: FrameSignature, Description: "Signature frame", Kind: KindBinary, V24: true},
+	FrameSyncedLyrics:        {ID: FrameSyncedLyrics, Description: "Synchronised lyric/text", Kind: KindBinary, V23: true, V24: true},
+	FrameSyncedTempo:         {ID: FrameSyncedTempo, Description: "Synchronised tempo codes", Kind: KindBinary, V23: true, V24: true},
+	FrameAlbum:               {ID: FrameAlbum, Description: "Album/Movie/Show title", Kind: KindText, V23: true, V24: true},
+	FrameBPM:                 {ID: FrameBPM, Description: "BPM (beats per minute)", Kind: KindText, V23: true, V24: true},
+	FrameComposer:            {ID: FrameComposer, Description: "Composer", Kind: KindText, V23: true, V24: true},
+	FrameGenre:               {ID: FrameGenre, Description: "Content type", Kind: KindText, V23: true, V24: true},
+	FrameCopyright:           {ID: FrameCopyright, Description: "Copyright message", Kind: KindText, V23: true, V24: true},
+	FrameDate:                {ID: FrameDate, Description: "Date", Kind: KindText, V23: true},
+	FrameEncodingTime:        {ID: FrameEncodingTime, Description: "Encoding time", Kind: KindText, V24: true},
+	FramePlaylistDelay:       {ID: FramePlaylistDelay, Description: "Playlist delay", Kind: KindText, V23: true, V24: true},
+	FrameOriginalReleaseTime: {ID: FrameOriginalReleaseTime, Description: "Original release time", Kind: KindText, V24: true},
+	FrameRecordingTime:       {ID: FrameRecordingTime, Description: "Recording time", Kind: KindText, V24: true},
+	FrameReleaseTime:         {ID: FrameReleaseTime, Description: "Release time", Kind: KindText, V24: true},
+	FrameTaggingTime:         {ID: FrameTaggingTime, Description: "Tagging time", Kind: KindText, V24: true},
+	FrameEncodedBy:           {ID: FrameEncodedBy, Description: "Encoded by", Kind: KindText, V23: true, V24: true},
+	FrameLyricist:            {ID: FrameLyricist, Description: "Lyricist/Text writer", Kind: KindText, V23: true, V24: true},
+	FrameFileType:            {ID: FrameFileType, Description: "File type", Kind: KindText, V23: true, V24: true},
+	FrameTime:                {ID: FrameTime, Description: "Time", Kind: KindText, V23: true},
+	FrameInvolvedPeopleList:  {ID: FrameInvolvedPeopleList, Description: "Involved people list", Kind: KindPair, V24: true},
+	FrameContentGroup:        {ID: FrameContentGroup, Description: "Content group description", Kind: KindText, V23: true, V24: true},
+	FrameTitle:               {ID: FrameTitle, Description: "Title/songname/content description", Kind: KindText, V23: true, V24: true},
+	FrameSubtitle:            {ID: FrameSubtitle, Description: "Subtitle/Description refinement", Kind: KindText, V23: true, V24: true},
+	FrameInitialKey:          {ID: FrameInitialKey, Description: "Initial key", Kind: KindText, V23: true, V24: true},
+	FrameLanguage:            {ID: FrameLanguage, Description: "Language(s)", Kind: KindText, V23: true, V24: true},
+	FrameLength:              {ID: FrameLength, Description: "Length", Kind: KindText, V23: true, V24: true},
+	FrameMusicianCredits:     {ID: FrameMusicianCredits, Description: "Musician credits list", Kind: KindPair, V24: true},
+	FrameMediaType:           {ID: FrameMediaType, Description: "Media type", Kind: KindText, V23: true, V24: true},
+	FrameMood:                {ID: FrameMood, Description: "Mood", Kind: KindText, V24: true},
+	FrameOriginalAlbum:       {ID: FrameOriginalAlbum, Description: "Original album/movie/show title", Kind: KindText, V23: true, V24: true},
+	FrameOriginalFilename:    {ID: FrameOriginalFilename, Description: "Original filename", Kind: KindText, V23: true, V24: true},
+	FrameOriginalLyricist:    {ID: FrameOriginalLyricist, Description: "Original lyricist(s)/text writer(s)", Kind: KindText, V23: true, V24: true},
+	FrameOriginalArtist:      {ID: FrameOriginalArtist, Description: "Original artist(s)/performer(s)", Kind: KindText, V23: true, V24: true},
+	FrameOriginalYear:        {ID: FrameOriginalYear, Description: "Original release year", Kind: KindText, V23: true},
+	FrameFileOwner:           {ID: FrameFileOwner, Description: "File owner/licensee", Kind: KindText, V23: true, V24: true},
+	FrameArtist:              {ID: FrameArtist, Description: "Lead performer(s)/Soloist(s)", Kind: KindText, V23: true, V24: true},
+	FrameAlbumArtist:         {ID: FrameAlbumArtist, Description: "Band/orchestra/accompaniment", Kind: KindText, V23: true, V24: true},
+	FrameConductor:           {ID: FrameConductor, Description: "Conductor/performer refinement", Kind: KindText, V23: true, V24: true},
+	FrameRemixer:             {ID: FrameRemixer, Description: "Interpreted, remixed, or otherwise modified by", Kind: KindText, V23: true, V24: true},
+	FrameDisc:                {ID: FrameDisc, Description: "Part of a set", Kind: KindText, V23: true, V24: true},
+	FrameProducedNotice:      {ID: FrameProducedNotice, Description: "Produced notice", Kind: KindText, V24: true},
+	FramePublisher:           {ID: FramePublisher, Description: "Publisher", Kind: KindText, V23: true, V24: true},
+	FrameTrack:               {ID: FrameTrack, Description: "Track number/Position in set", Kind: KindText, V23: true, V24: true},
+	FrameRecordingDates:      {ID: FrameRecordingDates, Description: "Recording dates", Kind: KindText, V23: true},
+	FrameRadioStation:        {ID: FrameRadioStation, Description: "Internet radio station name", Kind: KindText, V23: true, V24: true},
+	FrameRadioStationOwner:   {ID: FrameRadioStationOwner, Description: "Internet radio station owner", Kind: KindText, V23: true, V24: true},
+	FrameSize:                {ID: FrameSize, Description: "Size", Kind: KindText, V23: true},
+	FrameAlbumSortOrder:      {ID: FrameAlbumSortOrder, Description: "Album sort order", Kind: KindText, V24: true},
+	FramePerformerSortOrder:  {ID: FramePerformerSortOrder, Description: "Performer sort order", Kind: KindText, V24: true},
+	FrameTitleSortOrder:      {ID: FrameTitleSortOrder, Description: "Title sort order", Kind: KindText, V24: true},
+	FrameISRC:                {ID: FrameISRC, Description: "ISRC (international standard recording code)", Kind: KindText, V23: true, V24: true},
+	FrameEncoderSettings:     {ID: FrameEncoderSettings, Description: "Software/Hardware and settings used for encoding", Kind: KindText, V23: true, V24: true},
+	FrameSetSubtitle:         {ID: FrameSetSubtitle, Description: "Set subtitle", Kind: KindText, V24: true},
+	FrameUserText:            {ID: FrameUserText, Description: "User defined text information frame", Kind: KindText, V23: true, V24: true},
+	FrameYear:                {ID: FrameYear, Description: "Year", Kind: KindText, V23: true},
+	FrameUniqueFileID:        {ID: FrameUniqueFileID, Description: "Unique file identifier", Kind: KindBinary, V23: true, V24: true},
+	FrameTermsOfUse:          {ID: FrameTermsOfUse, Description: "Terms of use", Kind: KindComment, V23: true, V24: true},
+	FrameLyrics:              {ID: FrameLyrics, Description: "Unsynchronised lyric/text transcription", Kind: KindComment, V23: true, V24: true},
+	FrameCommercialURL:       {ID: FrameCommercialURL, Description: "Commercial information", Kind: KindURL, V23: true, V24: true},
+	FrameCopyrightURL:        {ID: FrameCopyrightURL, Description: "Copyright/Legal information", Kind: KindURL, V23: true, V24: true},
+	FrameAudioFileURL:        {ID: FrameAudioFileURL, Description: "Official audio file webpage", Kind: KindURL, V23: true, V24: true},
+	FrameArtistURL:           {ID: FrameArtistURL, Description: "Official artist/performer webpage", Kind: KindURL, V23: true, V24: true},
+	FrameAudioSourceURL:      {ID: FrameAudioSourceURL, Description: "Official audio source webpage", Kind: KindURL, V23: true, V24: true},
+	FrameRadioStationURL:     {ID: FrameRadioStationURL, Description: "Official Internet radio station homepage", Kind: KindURL, V23: true, V24: true},
+	FramePaymentURL:          {ID: FramePaymentURL, Description: "Payment", Kind: KindURL, V23: true, V24: true},
+	FramePublisherURL:        {ID: FramePublisherURL, Description: "Publishers official webpage", Kind: KindURL, V23: true, V24: true},
+	FrameUserURL:             {ID: FrameUserURL, Description: "User defined URL link frame", Kind: KindURL, V23: true, V24: true},
+}
+
+// frameKind is the kind the registry has for the ID, v2.2 and XSO IDs are
+// looked up as the ID they turn into. IDs it doesn't have are text or URL frames
+// going by the first letter like the spec has it and binary otherwise.
+func frameKind(id string) FrameKind {
+	if v23, ok := v22FrameIDs[id]; ok {
+		id = v23
+	}
+	if v24, ok := xsoFrameIDs[id]; ok {
+		id = v24
+	}
+	if info, ok := frameInfos[id]; ok {
+		return info.Kind
+	}
+	switch {
+	case strings.HasPrefix(id, "T"):
+		return KindText
+	case strings.HasPrefix(id, "W"):
+		return KindURL
+	}
+	return KindBinary
+}
+
+// Kind is how the frame's data is laid out
+func (f *Frame) Kind() FrameKind {
+	return frameKind(f.FrameID)
+}
+
+// Kind is how the frame's data is laid out
+func (rf RawFrame) Kind() FrameKind {
+	return frameKind(rf.ID)
+}
+
+// describedFrames are the binary frames with some text Decoded uses for
+// them, the rest are left out of the ReadID3 map
+var describedFrames = map[string]bool{
+	"APIC": true, "PIC": true,
+	"GEOB": true, "GEO": true,
+	"PRIV": true,
+	"ENCR": true,
+	"GRID": true,
+	"PCST": true,
+	"LINK": true, "LNK": true,
+	"AENC": true, "CRA": true,
+}
+
+// opaque is whether the frame is binary with nothing to show as text
+func (f *Frame) opaque() bool {
+	return f.Kind() == KindBinary && !describedFrames[f.FrameID]
+}
+
+// LookupFrame is what the specs say about the frame ID, ok is false for
+// IDs neither of them declares
+func LookupFrame(id string) (info FrameInfo, ok bool) {
+	info, ok = frameInfos[id]
+	return info, ok
+}
@@ -0,0 +1,60 @@
+package easyid3
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLookupFrame(t *testing.T) {
+	for id, info := range frameInfos {
+		if info.ID != id || len(id) != 4 || !validFrameID(id) || !(info.V23 || info.V24) {
+			t.Errorf("Wrong frame info %+v for %s", info, id)
+		}
+		if strings.HasPrefix(id, "W") != (info.Kind == KindURL) {
+			t.Errorf("%s is kind %d", id, info.Kind)
+		}
+	}
+	for id, want := range map[string]FrameKind{
+		FrameTitle:           KindText,
+		FrameUserText:        KindText,
+		FrameUserURL:         KindURL,
+		FrameMusicianCredits: KindPair,
+		FrameLyrics:          KindComment,
+		FramePicture:         KindBinary,
+	} {
+		if info, ok := LookupFrame(id); !ok || info.Kind != want {
+			t.Errorf("%s got %+v", id, info)
+		}
+	}
+	if info, _ := LookupFrame(FrameYear); !info.V23 || info.V24 {
+		t.Errorf("Wrong versions for TYER %+v", info)
+	}
+	if _, ok := LookupFrame("XYZ1"); ok {
+		t.Error("Found a frame that isn't declared")
+	}
+	// the v2.2 IDs turn into declared ones, apart from the iTunes extras
+	for old, id := range v22FrameIDs {
+		if _, ok := LookupFrame(id); !ok && !strings.HasPrefix(old, "TS") && old != "TCP" {
+			t.Errorf("%s turns into %s which isn't declared", old, id)
+		}
+	}
+}
+
+func TestFrameKind(t *testing.T) {
+	for id, want := range map[string]FrameKind{
+		"TIT2": KindText,
+		"TT2":  KindText,
+		"TCMP": KindText,
+		"XSOA": KindText,
+		"WFED": KindURL,
+		"COMM": KindComment,
+		"COM":  KindComment,
+		"MCDI": KindBinary,
+		"MCI":  KindBinary,
+		"CHAP": KindBinary,
+	} {
+		if got := frameKind(id); got != want {
+			t.Errorf("%s got kind %d want %d", id, got, want)
+		}
+	}
+}
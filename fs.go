@@ -0,0 +1,32 @@
+package easyid3
+
+import (
+	"bytes"
+	"io"
+	"io/fs"
+)
+
+// ReadID3FS is ReadID3File for a file in fsys. Files that can't seek have to
+// be read all the way through to look for the tags at the end, that only
+// happens when there isn't one at the start.
+func ReadID3FS(fsys fs.FS, name string, opts ...Option) (map[string]string, error) {
+	f, err := fsys.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	if rs, ok := f.(io.ReadSeeker); ok {
+		return readAnyTag(rs, opts)
+	}
+	// keep what the first try reads so it can be read again
+	var read bytes.Buffer
+	props, err := ReadID3(io.TeeReader(f, &read), opts...)
+	if !noTag(err) {
+		return props, err
+	}
+	_, err = io.Copy(&read, f)
+	if err != nil {
+		return nil, err
+	}
+	return readAnyTag(bytes.NewReader(read.Bytes()), opts)
+}
@@ -0,0 +1,61 @@
+package easyid3
+
+import (
+	"embed"
+	"errors"
+	"io/fs"
+	"testing"
+	"testing/fstest"
+)
+
+//go:embed testdata/tagged.mp3
+var testdata embed.FS
+
+// noSeekFS hides Seek the way some archive readers do
+type noSeekFS struct {
+	fs.FS
+}
+
+type noSeekFile struct {
+	fs.File
+}
+
+func (n noSeekFS) Open(name string) (fs.File, error) {
+	f, err := n.FS.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	return noSeekFile{f}, nil
+}
+
+func TestReadID3FS(t *testing.T) {
+	props, err := ReadID3FS(testdata, "testdata/tagged.mp3")
+	if err != nil || props["TIT2"] != "Embedded" {
+		t.Errorf("embed got %v %v", props, err)
+	}
+
+	mapFS := fstest.MapFS{
+		"prepended.mp3": {Data: append(tagBytes(4, 0, frameBytes(4, "TIT2", []byte("\x03Prepended\x00"))), fakeAudio...)},
+		"appended.mp3":  {Data: append(append([]byte{}, fakeAudio...), appendedTagBytes(frameBytes(4, "TIT2", []byte("\x03Appended\x00")))...)},
+		"v1.mp3":        {Data: append(append([]byte{}, fakeAudio...), id3v1Bytes("ID3v1", "", "", "", "", 0, 255)...)},
+		"none.mp3":      {Data: fakeAudio},
+	}
+	for _, fsys := range []fs.FS{mapFS, noSeekFS{mapFS}} {
+		for name, title := range map[string]string{
+			"prepended.mp3": "Prepended",
+			"appended.mp3":  "Appended",
+			"v1.mp3":        "ID3v1",
+		} {
+			props, err := ReadID3FS(fsys, name)
+			if err != nil || props["TIT2"] != title {
+				t.Errorf("%T %s: got %v %v", fsys, name, props, err)
+			}
+		}
+		if _, err := ReadID3FS(fsys, "none.mp3"); !errors.Is(err, ErrNoTag) {
+			t.Errorf("%T: expected ErrNoTag got %v", fsys, err)
+		}
+		if _, err := ReadID3FS(fsys, "missing.mp3"); !errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("%T: expected ErrNotExist got %v", fsys, err)
+		}
+	}
+}
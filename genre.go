@@ -0,0 +1,167 @@
+package easyid3
+
+import (
+	"strconv"
+	"strings"
+)
+
+// genres is the ID3v1 genre list, 0 to 79 are from the spec and the rest
+// are the Winamp extensions everything else picked up
+var genres = []string{
+	"Blues", "Classic Rock", "Country", "Dance", "Disco", "Funk", "Grunge", "Hip-Hop",
+	"Jazz", "Metal", "New Age", "Oldies", "Other", "Pop", "R&B", "Rap",
+	"Reggae", "Rock", "Techno", "Industrial", "Alternative", "Ska", "Death Metal", "Pranks",
+	"Soundtrack", "Euro-Techno", "Ambient", "Trip-Hop", "Vocal", "Jazz+Funk", "Fusion", "Trance",
+	"Classical", "Instrumental", "Acid", "House", "Game", "Sound Clip", "Gospel", "Noise",
+	"AlternRock", "Bass", "Soul", "Punk", "Space", "Meditative", "Instrumental Pop", "Instrumental Rock",
+	"Ethnic", "Gothic", "Darkwave", "Techno-Industrial", "Electronic", "Pop-Folk", "Eurodance", "Dream",
+	"Southern Rock", "Comedy", "Cult", "Gangsta", "Top 40", "Christian Rap", "Pop/Funk", "Jungle",
+	"Native American", "Cabaret", "New Wave", "Psychadelic", "Rave", "Showtunes", "Trailer", "Lo-Fi",
+	"Tribal", "Acid Punk", "Acid Jazz", "Polka", "Retro", "Musical", "Rock & Roll", "Hard Rock",
+	// Winamp
+	"Folk", "Folk-Rock", "National Folk", "Swing", "Fast Fusion", "Bebob", "Latin", "Revival",
+	"Celtic", "Bluegrass", "Avantgarde", "Gothic Rock", "Progressive Rock", "Psychedelic Rock", "Symphonic Rock", "Slow Rock",
+	"Big Band", "Chorus", "Easy Listening", "Acoustic", "Humour", "Speech", "Chanson", "Opera",
+	"Chamber Music", "Sonata", "Symphony", "Booty Bass", "Primus", "Porn Groove", "Satire", "Slow Jam",
+	"Club", "Tango", "Samba", "Folklore", "Ballad", "Power Ballad", "Rhythmic Soul", "Freestyle",
+	"Duet", "Punk Rock", "Drum Solo", "A capella", "Euro-House", "Dance Hall", "Goa", "Drum & Bass",
+	"Club-House", "Hardcore", "Terror", "Indie", "BritPop", "Negerpunk", "Polsk Punk", "Beat",
+	"Christian Gangsta Rap", "Heavy Metal", "Black Metal", "Crossover", "Contemporary Christian", "Christian Rock", "Merengue", "Salsa",
+	"Thrash Metal", "Anime", "JPop", "Synthpop", "Abstract", "Art Rock", "Baroque", "Bhangra",
+	"Big Beat", "Breakbeat", "Chillout", "Downtempo", "Dub", "EBM", "Eclectic", "Electro",
+	"Electroclash", "Emo", "Experimental", "Garage", "Global", "IDM", "Illbient", "Industro-Goth",
+	"Jam Band", "Krautrock", "Leftfield", "Lounge", "Math Rock", "New Romantic", "Nu-Breakz", "Post-Punk",
+	"Post-Rock", "Psytrance", "Shoegaze", "Space Rock", "Trop Rock", "World Music", "Neoclassical", "Audiobook",
+	"Audio Theatre", "Neue Deutsche Welle", "Podcast", "Indie Rock", "G-Funk", "Dubstep", "Garage Rock", "Psybient",
+}
+
+// genreAliases are other spellings people use for the genres, keyed the
+// way genreKey has them
+var genreAliases = map[string]int{
+	"psychedelic":     67,
+	"rockandroll":     78,
+	"rocknroll":       78,
+	"rock'n'roll":     78,
+	"randb":           14,
+	"rnb":             14,
+	"rhythmandblues":  14,
+	"drumandbass":     127,
+	"drumnbass":       127,
+	"dnb":             127,
+	"acappella":       123,
+	"bebop":           85,
+	"alternativerock": 40,
+	"humor":           100,
+	"jazzfunk":        29,
+	"popfunk":         62,
+}
+
+var genreIndexes = func() map[string]int {
+	m := make(map[string]int, len(genres)+len(genreAliases))
+	for alias, i := range genreAliases {
+		m[alias] = i
+	}
+	for i, name := range genres {
+		m[genreKey(name)] = i
+	}
+	return m
+}()
+
+// genreKey is the name lower case without the spaces and dashes people
+// can't agree on, "Hip Hop" is "Hip-Hop"
+func genreKey(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '-', '_':
+			return -1
+		}
+		return r
+	}, strings.ToLower(strings.TrimSpace(name)))
+}
+
+// GenreName is the name of an ID3v1 genre, 0 to 79 are from the spec and
+// up to 191 are the Winamp extensions
+func GenreName(index int) (string, bool) {
+	if index < 0 || index >= len(genres) {
+		return "", false
+	}
+	return genres[index], true
+}
+
+// GenreIndex is the ID3v1 number for a genre name. Case, spaces and dashes
+// don't matter and the usual other spellings are known, the spec's own
+// "Psychadelic" is found as "Psychedelic" too.
+func GenreIndex(name string) (int, bool) {
+	i, ok := genreIndexes[genreKey(name)]
+	return i, ok
+}
+
+// GenreNames is every ID3v1 genre in index order
+func GenreNames() []string {
+	return append([]string(nil), genres...)
+}
+
+// genreName is the name for an ID3v1 genre index, empty when it's not one
+func genreName(i int) string {
+	name, _ := GenreName(i)
+	return name
+}
+
+// parseGenres turns a TCON into genre names. v2.3 has the ID3v1 genres as
+// references like "(17)" or "(9)Psybient" followed by text refining them,
+// a text that starts with "(" has it doubled. v2.4 has a bare "17" and
+// splits values with nulls. RX and CR are remix and cover. Anything else
+// is a genre of its own.
+func parseGenres(s string) []string {
+	var out []string
+	add := func(g string) {
+		for _, seen := range out {
+			if strings.EqualFold(seen, g) {
+				return
+			}
+		}
+		out = append(out, g)
+	}
+	for _, part := range strings.Split(s, "\x00") {
+		for strings.HasPrefix(part, "(") && !strings.HasPrefix(part, "((") {
+			end := strings.IndexByte(part, ')')
+			if end < 0 {
+				break
+			}
+			name, ok := genreRef(part[1:end])
+			if !ok {
+				break
+			}
+			add(name)
+			part = part[end+1:]
+		}
+		if strings.HasPrefix(part, "((") {
+			part = part[1:]
+		} else if name, ok := genreRef(part); ok {
+			part = name
+		}
+		if part != "" {
+			add(part)
+		}
+	}
+	return out
+}
+
+// genreRef is the name for a genre number or RX or CR
+func genreRef(ref string) (string, bool) {
+	switch ref {
+	case "RX":
+		return "Remix", true
+	case "CR":
+		return "Cover", true
+	}
+	if ref == "" || strings.Trim(ref, "0123456789") != "" {
+		// saves Atoi making an error for every genre name
+		return "", false
+	}
+	n, err := strconv.Atoi(ref)
+	if err != nil || genreName(n) == "" {
+		return "", false
+	}
+	return genreName(n), true
+}
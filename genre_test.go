@@ -0,0 +1,96 @@
+package easyid3
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestParseGenres(t *testing.T) {
+	for tcon, want := range map[string][]string{
+		"Rock":                {"Rock"},
+		"(17)":                {"Rock"},
+		"17":                  {"Rock"},
+		"(9)Psybient":         {"Metal", "Psybient"},
+		"(17)(9)":             {"Rock", "Metal"},
+		"(17)Rock":            {"Rock"},
+		"(RX)(CR)":            {"Remix", "Cover"},
+		"RX":                  {"Remix"},
+		"((Live) Rock":        {"(Live) Rock"},
+		"(31)((Anything)":     {"Trance", "(Anything)"},
+		"(Live) Rock":         {"(Live) Rock"},
+		"(300)":               {"(300)"},
+		"Rock\x00Dance\x0013": {"Rock", "Dance", "Pop"},
+		"":                    nil,
+	} {
+		if got := parseGenres(tcon); !reflect.DeepEqual(got, want) {
+			t.Errorf("%q got %q want %q", tcon, got, want)
+		}
+	}
+}
+
+func TestGenres(t *testing.T) {
+	tag := tagBytes(3, 0, frameBytes(3, "TCON", []byte("\x00(9)(RX)Psybient")))
+	parsed, err := ReadTag(bytes.NewReader(tag))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	if g := parsed.Genres(); !reflect.DeepEqual(g, []string{"Metal", "Remix", "Psybient"}) {
+		t.Errorf("Wrong genres %q", g)
+	}
+	if g := parsed.Genre(); g != "Metal, Remix, Psybient" {
+		t.Errorf("Wrong genre %q", g)
+	}
+	props, err := ReadID3(bytes.NewReader(tagBytes(4, 0, frameBytes(4, "TCON", []byte("\x0321\x00Dub\x00")))))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	if props["TCON"] != "Ska, Dub" {
+		t.Errorf("Wrong TCON %q", props["TCON"])
+	}
+	if g := NewTag().Genres(); g != nil {
+		t.Errorf("Expected no genres got %q", g)
+	}
+}
+
+func TestGenreTable(t *testing.T) {
+	if len(genres) != 192 {
+		t.Fatalf("Expected 192 genres got %d", len(genres))
+	}
+	// every name and alias has its own key
+	if len(genreIndexes) != len(genres)+len(genreAliases) {
+		t.Errorf("Genre keys collide, %d keys for %d names and %d aliases", len(genreIndexes), len(genres), len(genreAliases))
+	}
+	for i, want := range map[int]string{0: "Blues", 79: "Hard Rock", 80: "Folk", 191: "Psybient"} {
+		if name, ok := GenreName(i); !ok || name != want {
+			t.Errorf("%d got %q want %q", i, name, want)
+		}
+	}
+	for _, i := range []int{-1, 192} {
+		if _, ok := GenreName(i); ok {
+			t.Errorf("%d shouldn't be a genre", i)
+		}
+	}
+	for name, want := range map[string]int{
+		"Blues":         0,
+		"hard rock":     79,
+		"Psychadelic":   67,
+		"Psychedelic":   67,
+		"hip hop":       7,
+		"Drum and Bass": 127,
+		"Synth-Pop":     147,
+		" JPOP ":        146,
+	} {
+		if i, ok := GenreIndex(name); !ok || i != want {
+			t.Errorf("%q got %d want %d", name, i, want)
+		}
+	}
+	if _, ok := GenreIndex("Not a genre"); ok {
+		t.Error("Found a genre that isn't one")
+	}
+	names := GenreNames()
+	names[0] = "Changed"
+	if genres[0] != "Blues" {
+		t.Error("GenreNames gave out the table itself")
+	}
+}
@@ -1,3 +1,5 @@
 module github.com/tonalfitness/easyid3
 
 go 1.17
+
+require golang.org/x/text v0.3.8
@@ -0,0 +1,21 @@
+package easyid3
+
+// Group is a GRID frame registering the group symbol that frames belonging
+// together carry as their GroupID.
+type Group struct {
+	Owner  string
+	Symbol byte
+	Data   []byte
+}
+
+// parseGroup reads the terminated owner, the group symbol and then
+// whatever data the owner wants.
+func parseGroup(data []byte) Group {
+	owner, rest := splitTerminated(encodingISO88591, data)
+	g := Group{Owner: decodeLatin1(owner)}
+	if len(rest) > 0 {
+		g.Symbol = rest[0]
+		g.Data = rest[1:]
+	}
+	return g
+}
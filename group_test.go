@@ -0,0 +1,54 @@
+package easyid3
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestGroupedFrames(t *testing.T) {
+	for _, version := range []byte{3, 4} {
+		grouped := byte(0x40)
+		if version == 3 {
+			grouped = 0x20
+		}
+		tag := tagBytes(version, 0,
+			frameBytes(version, "GRID", []byte("http://example.com/groups\x00\x81extra")),
+			flaggedFrameBytes(version, "TIT2", 0, grouped, []byte("\x81\x03Grouped title\x00")),
+			frameBytes(version, "TPE1", []byte("\x03Artist\x00")),
+		)
+		parsed, err := ReadTag(bytes.NewReader(tag))
+		if err != nil {
+			t.Fatalf("v2.%d failed read: %v", version, err)
+		}
+		title := parsed.frames[1]
+		if title.Decoded() != "Grouped title" || title.GroupID != 0x81 {
+			t.Errorf("v2.%d wrong title %q group %x", version, title.Decoded(), title.GroupID)
+		}
+		g := parsed.Group(title.GroupID)
+		if g == nil || g.Owner != "http://example.com/groups" || string(g.Data) != "extra" {
+			t.Errorf("v2.%d wrong group %+v", version, g)
+		}
+		if parsed.Group(0x82) != nil {
+			t.Errorf("v2.%d found a group that isn't there", version)
+		}
+	}
+}
+
+func TestGroupedEncryptedFrame(t *testing.T) {
+	RegisterDecryptor(0x80, func(owner string, data []byte) ([]byte, error) {
+		return xor(data, 0x5a), nil
+	})
+	defer RegisterDecryptor(0x80, nil)
+
+	// v2.4 has the group byte before the encryption method
+	tag := tagBytes(4, 0,
+		flaggedFrameBytes(4, "TIT2", 0, 0x44, append([]byte{0x81, 0x80}, xor([]byte("\x03Title\x00"), 0x5a)...)),
+	)
+	props, err := ReadID3(bytes.NewReader(tag))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	if props["TIT2"] != "Title" {
+		t.Errorf("Wrong title %q", props["TIT2"])
+	}
+}
@@ -0,0 +1,197 @@
+package easyid3
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ReadID3v1 reads the 128 byte ID3v1 tag at the end of the file. The fields
+// come back under the same keys ReadID3 uses, TIT2, TPE1, TALB, TYER,
+// COMM:und:, TRCK and TCON with the genre index turned into its name.
+func ReadID3v1(rs io.ReadSeeker) (map[string]string, error) {
+	return readID3v1Map(rs, newOptions(nil))
+}
+
+// readID3v1Map is ReadID3v1 keyed the way the options say
+func readID3v1Map(rs io.ReadSeeker, o *options) (map[string]string, error) {
+	tag, err := readID3v1(rs)
+	if err != nil {
+		return nil, err
+	}
+	return frameMap(tag.frames, o), nil
+}
+
+// ReadAnyID3 reads the ID3v2 tag at the start like ReadID3 and falls back
+// to the ID3v1 tag when there isn't one, the way players do.
+func ReadAnyID3(rs io.ReadSeeker, opts ...Option) (map[string]string, error) {
+	props, err := ReadID3(rs, opts...)
+	if props != nil {
+		// WithLenient gives back what it could read with the errors
+		return props, err
+	}
+	v1, v1Err := readID3v1Map(rs, newOptions(opts))
+	if v1Err != nil {
+		// the v2 error says more about what was wrong
+		return nil, err
+	}
+	return v1, nil
+}
+
+// readID3v1 turns the fixed width fields into v2.4 frames so a Tag can hold
+// them. v1.1 puts the track in the last byte of the comment after a null.
+func readID3v1(rs io.ReadSeeker) (*Tag, error) {
+	end, err := rs.Seek(0, io.SeekEnd)
+	if err != nil {
+		return nil, err
+	}
+	if end < 128 {
+		return nil, notFoundError("ID3v1 tag")
+	}
+	_, err = rs.Seek(end-128, io.SeekStart)
+	if err != nil {
+		return nil, err
+	}
+	raw := make([]byte, 128)
+	_, err = io.ReadFull(rs, raw)
+	if err != nil {
+		return nil, err
+	}
+	if string(raw[:3]) != "TAG" {
+		return nil, notFoundError("ID3v1 tag")
+	}
+	tag := NewTag()
+	set := func(key string, field []byte) {
+		value := v1String(field)
+		if value != "" {
+			// v2.4 frames always take the text
+			f, _ := textFrame(key, value)
+			tag.frames = append(tag.frames, f)
+		}
+	}
+	set("TIT2", raw[3:33])
+	set("TPE1", raw[33:63])
+	set("TALB", raw[63:93])
+	set("TYER", raw[93:97])
+	comment := raw[97:127]
+	if comment[28] == 0 && comment[29] != 0 {
+		set("TRCK", []byte(strconv.Itoa(int(comment[29]))))
+		comment = comment[:28]
+	}
+	set("COMM:und:", comment)
+	set("TCON", []byte(genreName(int(raw[127]))))
+	return tag, nil
+}
+
+// v1String is the field up to the first null without the space padding
+func v1String(b []byte) string {
+	if i := bytes.IndexByte(b, 0); i >= 0 {
+		b = b[:i]
+	}
+	return strings.TrimRight(decodeLatin1(b), " ")
+}
+
+// WriteID3v1 writes an ID3v1.1 tag made from the tag's fields to the end of
+// rws, over the one that's already there if there is one. Text that
+// ISO-8859-1 doesn't have is transliterated where it can be and the fields
+// are cut to fit.
+func WriteID3v1(rws io.ReadWriteSeeker, t *Tag) error {
+	end, err := rws.Seek(0, io.SeekEnd)
+	if err != nil {
+		return err
+	}
+	start, err := id3v1Start(rws, end)
+	if err != nil {
+		return err
+	}
+	_, err = rws.Seek(start, io.SeekStart)
+	if err != nil {
+		return err
+	}
+	_, err = rws.Write(encodeID3v1(t))
+	return err
+}
+
+// encodeID3v1 is the 128 byte v1.1 tag
+func encodeID3v1(t *Tag) []byte {
+	raw := make([]byte, 128)
+	copy(raw, "TAG")
+	copy(raw[3:33], v1Field(t.Title(), 30))
+	copy(raw[33:63], v1Field(t.Artist(), 30))
+	copy(raw[63:93], v1Field(t.Album(), 30))
+	if year := t.Year(); year > 0 && year < 10000 {
+		copy(raw[93:97], fmt.Sprintf("%04d", year))
+	}
+	track, _, _ := t.Track()
+	if track > 0 && track < 256 {
+		// v1.1 takes the last 2 bytes of the comment for the track
+		copy(raw[97:125], v1Field(t.Comment(), 28))
+		raw[126] = byte(track)
+	} else {
+		copy(raw[97:127], v1Field(t.Comment(), 30))
+	}
+	raw[127] = 255
+	if genres := t.Genres(); len(genres) > 0 {
+		raw[127] = genreIndex(genres[0])
+	}
+	return raw
+}
+
+// v1Field is s as ISO-8859-1 cut to n bytes
+func v1Field(s string, n int) []byte {
+	var b []byte
+	for _, r := range s {
+		b = append(b, transliterate(r)...)
+	}
+	if len(b) > n {
+		b = b[:n]
+	}
+	return b
+}
+
+// latin1Substitutes are stand ins for the characters that turn up in tags
+// most that ISO-8859-1 doesn't have
+var latin1Substitutes = map[rune]string{
+	'‘': "'", '’': "'", '‚': ",", '‛': "'",
+	'“': "\"", '”': "\"", '„': "\"",
+	'‐': "-", '‑': "-", '‒': "-", '–': "-", '—': "-", '―': "-",
+	'…': "...", '•': "*", '€': "EUR", '™': "TM",
+	'Œ': "OE", 'œ': "oe", 'Š': "S", 'š': "s", 'Ž': "Z", 'ž': "z", 'Ÿ': "Y",
+	'Ł': "L", 'ł': "l", 'Ń': "N", 'ń': "n", 'Ś': "S", 'ś': "s", 'Ź': "Z", 'ź': "z", 'Ż': "Z", 'ż': "z",
+	'Ą': "A", 'ą': "a", 'Ę': "E", 'ę': "e", 'Ć': "C", 'ć': "c", 'Č': "C", 'č': "c", 'Ř': "R", 'ř': "r",
+	'Ě': "E", 'ě': "e", 'Ů': "U", 'ů': "u", 'Ő': "O", 'ő': "o", 'Ű': "U", 'ű': "u", 'Ğ': "G", 'ğ': "g",
+	'İ': "I", 'ı': "i", 'Ş': "S", 'ş': "s",
+}
+
+// transliterate is the ISO-8859-1 for r, something close when it doesn't
+// have it or ? when there's nothing close
+func transliterate(r rune) []byte {
+	if r <= 0xff {
+		return []byte{byte(r)}
+	}
+	if sub, ok := latin1Substitutes[r]; ok {
+		return []byte(sub)
+	}
+	return []byte{'?'}
+}
+
+// genreIndex is the ID3v1 index for a genre name or a v2 "(17)" style
+// reference, 255 when it isn't one of them
+func genreIndex(genre string) byte {
+	if strings.HasPrefix(genre, "(") {
+		if end := strings.IndexByte(genre, ')'); end > 0 {
+			if n, err := strconv.Atoi(genre[1:end]); err == nil && genreName(n) != "" {
+				return byte(n)
+			}
+		}
+	}
+	if n, err := strconv.Atoi(genre); err == nil && genreName(n) != "" {
+		return byte(n)
+	}
+	if i, ok := GenreIndex(genre); ok {
+		return byte(i)
+	}
+	return 255
+}
@@ -0,0 +1,180 @@
+package easyid3
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// id3v1Bytes builds a v1.1 tag, track 0 makes it plain v1
+func id3v1Bytes(title, artist, album, year, comment string, track, genre byte) []byte {
+	field := func(s string, n int) []byte {
+		b := make([]byte, n)
+		copy(b, s)
+		return b
+	}
+	out := []byte("TAG")
+	out = append(out, field(title, 30)...)
+	out = append(out, field(artist, 30)...)
+	out = append(out, field(album, 30)...)
+	out = append(out, field(year, 4)...)
+	c := field(comment, 30)
+	if track != 0 {
+		c[28], c[29] = 0, track
+	}
+	out = append(out, c...)
+	return append(out, genre)
+}
+
+func TestReadID3v1(t *testing.T) {
+	file := append(append([]byte{}, fakeAudio...), id3v1Bytes("Title   ", "Art\xefst", "Album", "1999", "A comment", 7, 17)...)
+	props, err := ReadID3v1(bytes.NewReader(file))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	want := map[string]string{
+		"TIT2":      "Title",
+		"TPE1":      "Artïst",
+		"TALB":      "Album",
+		"TYER":      "1999",
+		"COMM:und:": "A comment",
+		"TRCK":      "7",
+		"TCON":      "Rock",
+	}
+	if !reflect.DeepEqual(props, want) {
+		t.Errorf("Got %v", props)
+	}
+
+	// v1.0 uses the whole comment and 255 is no genre
+	long := "A comment that is 30 chars lon"
+	props, err = ReadID3v1(bytes.NewReader(id3v1Bytes("Title", "", "", "", long, 0, 255)))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	if !reflect.DeepEqual(props, map[string]string{"TIT2": "Title", "COMM:und:": long}) {
+		t.Errorf("Got %v", props)
+	}
+
+	if _, err := ReadID3v1(bytes.NewReader(fakeAudio)); err == nil {
+		t.Error("Expected an error without a tag")
+	}
+	if _, err := ReadID3v1(bytes.NewReader([]byte("TAG"))); err == nil {
+		t.Error("Expected an error for a short file")
+	}
+}
+
+func TestReadAnyID3(t *testing.T) {
+	v1 := id3v1Bytes("v1 title", "", "", "", "", 0, 255)
+	v2 := tagBytes(4, 0, frameBytes(4, "TIT2", []byte("\x03v2 title\x00")))
+
+	props, err := ReadAnyID3(bytes.NewReader(append(append(v2, fakeAudio...), v1...)))
+	if err != nil || props["TIT2"] != "v2 title" {
+		t.Errorf("Wanted the v2 tag got %v %v", props, err)
+	}
+	props, err = ReadAnyID3(bytes.NewReader(append(append([]byte{}, fakeAudio...), v1...)))
+	if err != nil || props["TIT2"] != "v1 title" {
+		t.Errorf("Wanted the v1 tag got %v %v", props, err)
+	}
+	if _, err := ReadAnyID3(bytes.NewReader(fakeAudio)); err == nil {
+		t.Error("Expected an error without a tag")
+	}
+}
+
+func TestWriteID3v1(t *testing.T) {
+	tag := NewTag()
+	for id, value := range map[string]string{
+		"TIT2": "A title that is much longer than thirty characters",
+		"TPE1": "Motörhead — “Ace” €",
+		"TALB": "日本",
+		"TDRC": "1980-11-08",
+		"TRCK": "3/12",
+		"TCON": "heavy metal",
+	} {
+		if err := tag.SetText(id, value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tag.SetComment("eng", "", "A comment"); err != nil {
+		t.Fatal(err)
+	}
+	f := tempFile(t, fakeAudio)
+	// the second time replaces the first
+	for i := 0; i < 2; i++ {
+		if err := WriteID3v1(f, tag); err != nil {
+			t.Fatalf("Failed write: %v", err)
+		}
+	}
+	contents := readAll(t, f)
+	if len(contents) != len(fakeAudio)+128 || !bytes.Equal(contents[:len(fakeAudio)], fakeAudio) {
+		t.Fatalf("Wrong file size %d", len(contents))
+	}
+	props, err := ReadID3v1(bytes.NewReader(contents))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	want := map[string]string{
+		"TIT2":      "A title that is much longer th",
+		"TPE1":      "Motörhead - \"Ace\" EUR",
+		"TALB":      "??",
+		"TYER":      "1980",
+		"COMM:und:": "A comment",
+		"TRCK":      "3",
+		"TCON":      "Heavy Metal",
+	}
+	if !reflect.DeepEqual(props, want) {
+		t.Errorf("Got %v", props)
+	}
+}
+
+func TestGenreIndex(t *testing.T) {
+	for genre, want := range map[string]byte{
+		"Rock":      17,
+		"(17)":      17,
+		"(9)Metal":  9,
+		"31":        31,
+		"psybient":  191,
+		"Not a one": 255,
+		"(300)":     255,
+		"":          255,
+	} {
+		if got := genreIndex(genre); got != want {
+			t.Errorf("%q got %d want %d", genre, got, want)
+		}
+	}
+}
+
+func TestSaveTagID3v1(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.mp3")
+	orig := append(append(tagBytes(4, 0), fakeAudio...), id3v1Bytes("Old", "", "", "", "", 0, 255)...)
+	if err := os.WriteFile(path, orig, 0644); err != nil {
+		t.Fatal(err)
+	}
+	tag := NewTag()
+	if err := tag.SetText("TIT2", "New"); err != nil {
+		t.Fatal(err)
+	}
+	// first rewritten, then in place
+	for i := 0; i < 2; i++ {
+		if err := SaveTag(path, tag, WithPadding(100), WithID3v1()); err != nil {
+			t.Fatalf("Failed save: %v", err)
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		props, err := ReadID3v1(f)
+		f.Close()
+		if err != nil || props["TIT2"] != "New" {
+			t.Errorf("Wrong v1 tag %v %v", props, err)
+		}
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(contents) != 10+10+len("\x03New\x00")+100+len(fakeAudio)+128 {
+			t.Errorf("Wrong file size %d", len(contents))
+		}
+	}
+}
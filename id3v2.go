@@ -2,122 +2,965 @@ package easyid3
 
 import (
 	"bufio"
+	"bytes"
 	"errors"
 	"fmt"
+	"hash/crc32"
 	"io"
+	"strings"
+	"sync"
 )
 
 // ReadID3 takes a reader that assumes is the start of an ID3 block and
-// reads all the frames and data. It only supports v2 and UTF-8 (and likely
-// ISO-8859-1 though not tested).
+// reads all the frames and data. It supports v2.2 through v2.4 and text in
+// ISO-8859-1, UTF-16 and UTF-8 all comes back as UTF-8. v2.2 frames are
+// keyed by their v2.4 IDs and the v2.3 date frames are merged into TDRC
+// unless WithOriginalFrameIDs is used. When a frame ID repeats only the
+// last one is kept, ReadID3All keeps all of them.
 // https://id3.org/id3v2.4.0-structure
-func ReadID3(rdr io.Reader) (map[string]string, error) {
-	r := bufio.NewReader(rdr)
+func ReadID3(rdr io.Reader, opts ...Option) (map[string]string, error) {
+	o := newOptions(opts)
+	header, frames, err := readTag(rdr, o)
+	if err != nil {
+		return nil, err
+	}
+	return frameMap(frames, o), header.frameErrors()
+}
+
+// frameMap keys the decoded frames, later ones win. Text frames with more
+// than one value have them joined with the text separator.
+func frameMap(frames []*Frame, o *options) map[string]string {
+	props := make(map[string]string, len(frames))
+	for _, frame := range frames {
+		if !frame.opaque() {
+			props[o.key(frame)] = frame.decoded(o.textSeparator)
+		}
+	}
+	return props
+}
+
+// ReadID3All is ReadID3 but every occurrence of a frame ID is returned in
+// the order they appear in the tag.
+func ReadID3All(rdr io.Reader, opts ...Option) (map[string][]string, error) {
+	o := newOptions(opts)
+	header, frames, err := readTag(rdr, o)
+	if err != nil {
+		return nil, err
+	}
+	props := map[string][]string{}
+	for _, frame := range frames {
+		if frame.opaque() {
+			continue
+		}
+		key := o.key(frame)
+		props[key] = append(props[key], frame.decoded(o.textSeparator))
+	}
+	return props, header.frameErrors()
+}
+
+// readTag reads the header and all the frames in the order they appear,
+// following SEEK frames to update tags when asked to.
+func readTag(rdr io.Reader, o *options) (*Header, []*Frame, error) {
+	rs, canSeek := rdr.(io.ReadSeeker)
+	if o.seekDepth <= 0 || !canSeek {
+		return readOneTag(rdr, o)
+	}
+	start, err := rs.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil, nil, err
+	}
+	header, frames, err := readOneTag(rdr, o)
+	if err != nil {
+		return nil, nil, err
+	}
+	return followSeek(rs, start, header, frames, o)
+}
+
+// readOneTag reads the header and frames of a single tag
+func readOneTag(rdr io.Reader, o *options) (*Header, []*Frame, error) {
+	if rs, ok := rdr.(io.ReadSeeker); ok && o.lazySize >= 0 {
+		return readLazyTag(rs, o)
+	}
+	r := bufferedReader(rdr)
+	defer releaseReader(r, rdr)
+	header, err := readHeader(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	sr := newStreamReader(r, rdr)
+	frames, err := readBody(sr, header, o)
+	if err != nil {
+		return nil, nil, err
+	}
+	// Footer just read off the last 10 bytes
+	if header.HasFooter() {
+		_, err = io.ReadAtLeast(sr, make([]byte, 10), 10)
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, nil, truncated("ID3 footer")
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return header, frames, nil
+}
+
+// readHeader checks for ID3 without reading anything else first
+func readHeader(r *bufio.Reader) (*Header, error) {
 	prefix, err := r.Peek(3)
+	if errors.Is(err, io.EOF) {
+		return nil, notFoundError("ID3 header")
+	}
 	if err != nil {
 		return nil, err
 	}
 	if string(prefix) != "ID3" {
-		return nil, fmt.Errorf("ID3 header not found")
+		return nil, notFoundError("ID3 header")
 	}
 
-	// Header is 10 bytes per spec
-	buf := make([]byte, 10)
-	_, err = io.ReadAtLeast(r, buf, 10)
+	// Header is 10 bytes per spec, newID3 copies what it needs out of the
+	// peeked bytes
+	buf, err := r.Peek(10)
+	if errors.Is(err, io.EOF) {
+		return nil, truncated("ID3 header")
+	}
 	if err != nil {
 		return nil, err
 	}
-
 	header, err := newID3(buf)
 	if err != nil {
 		return nil, err
 	}
+	_, err = r.Discard(10)
+	return header, err
+}
 
-	// limit to the body size
-	rdr = io.LimitReader(r, int64(header.Size))
-
-	/* TODO Maybe parse ExtendedHeader */
-	if header.ExtendedHeader() {
-		_, err = io.ReadAtLeast(rdr, buf, 4)
+// readBody reads what comes after the header up to the footer, the
+// extended header and the frames. It reads no more than header.Size bytes
+// from r.
+func readBody(r io.Reader, header *Header, o *options) (_ []*Frame, err error) {
+	defer func() {
+		if err != nil {
+			// there's no Tag to Close
+			header.removeSpilled()
+		}
+	}()
+	body, err := bodyReader(r, header, o)
+	if err != nil {
+		return nil, err
+	}
+	var crcData *bytes.Buffer
+	frameBody := body
+	if o.checkCRC && header.extended != nil && header.extended.HasCRC {
+		// keep a copy of what the frames were read from to check it after
+		crcData = &bytes.Buffer{}
+		frameBody = &io.LimitedReader{R: io.TeeReader(body, crcData), N: body.N}
+	}
+	frames, err := readFrames(frameBody, header, o)
+	if err != nil {
+		return nil, err
+	}
+	if crcData != nil {
+		// v2.4 takes the CRC over the padding too, v2.3 stops where it starts
+		checked := crcData.Bytes()
+		if header.Version[0] == 3 {
+			checked = checked[:header.framesSize]
+		}
+		if crc32.ChecksumIEEE(checked) != header.extended.CRC {
+			return nil, ErrCRCMismatch
+		}
+	}
+	// the ENCR frames can come after the frames they're for
+	frames, undecrypted := decryptFrames(frames, o)
+	header.skipped = append(header.skipped, undecrypted...)
+	if header.Version[0] < 4 && !o.originalIDs {
+		frames = mergeDates(frames)
+		renameSortFrames(frames)
+	}
+	if o.detectUTF8 {
+		header.reinterpreted = append(header.reinterpreted, detectUTF8(frames)...)
+	}
+	if o.legacyEncoding != nil {
+		decodeLegacy(frames, o.legacyEncoding)
+	}
+	if o.resolveLink != nil {
+		frames, err = resolveLinks(frames, header, o)
 		if err != nil {
 			return nil, err
 		}
-		extendedSize := synsafeInt(buf[:4])
-		// throw away the extended header
-		_, err = io.CopyN(io.Discard, rdr, int64(extendedSize-4))
+	}
+	return frames, nil
+}
+
+// bodyReader limits r to the body and reads the extended header, what's
+// left is the frames and padding
+func bodyReader(r io.Reader, header *Header, o *options) (*io.LimitedReader, error) {
+	if v := header.Version[0]; v < 2 || v > 4 {
+		return nil, fmt.Errorf("ID3v2.%d: %w", v, ErrUnsupportedVersion)
+	}
+	o.trace(TraceEvent{Kind: TraceHeader, Header: header})
+	// limit to the body size, N is what's left of the tag
+	body := &io.LimitedReader{R: r, N: int64(header.Size)}
+	if header.Unsynchronisation() && header.Version[0] < 4 {
+		// before v2.4 the whole tag is unsynchronised and the sizes inside are
+		// from before that, N stays the escaped size remaining which is at
+		// least as much as there is once it's undone
+		body = &io.LimitedReader{R: &unsyncReader{r: body}, N: body.N}
+	}
+
+	// v2.2 has no extended header, that bit means compression there
+	if header.Version[0] > 2 && header.ExtendedHeader() {
+		var err error
+		var used int
+		header.extended, used, err = readExtendedHeader(body, header.Version[0])
 		if err != nil {
 			return nil, err
 		}
+		o.trace(TraceEvent{Kind: TraceExtendedHeader, Offset: 10, Size: used})
+		if o.strict {
+			if err := o.tolerate(header, checkExtendedHeader(header.extended, used)); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return body, nil
+}
+
+// readFrames reads frames until the body runs out or hits padding. It's
+// used for the tag itself and for frames embedded in other frames like CHAP.
+func readFrames(body *io.LimitedReader, header *Header, o *options) ([]*Frame, error) {
+	// enough for most tags without growing
+	frames := make([]*Frame, 0, 32)
+	err := walkFrames(body, header, o, func(f *Frame) error {
+		frames = append(frames, f)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return frames, nil
+}
+
+// walkFrames is the frame reading loop, fn gets each frame as it's read and
+// an error from it stops the walk and comes straight back
+func walkFrames(body *io.LimitedReader, header *Header, o *options, fn func(*Frame) error) (err error) {
+	version := header.Version[0]
+	// in v2.4 the header flag just means every frame is unsynchronised
+	tagUnsync := version == 4 && header.Unsynchronisation()
+	// v2.2 frame headers are only 6 bytes
+	frameHeader := make([]byte, frameHeaderSize(version))
+	start := body.N
+	lenient := func(err error) error {
+		return o.tolerate(header, err)
+	}
+	stop := func(offset int64, reason string) {
+		o.trace(TraceEvent{Kind: TraceStop, Offset: offset, Reason: reason})
 	}
-	props := map[string]string{}
+	defer func() {
+		if err != nil {
+			o.trace(TraceEvent{Kind: TraceStop, Offset: int64(10+header.Size) - body.N, Err: err})
+		}
+	}()
+	// set once a v2.4 tag turns out to use plain sizes
+	plainSizes := false
+	var data slab
+	frames := 0
 	// Read frame Header
 	for {
-		_, err = io.ReadAtLeast(rdr, buf, 10)
+		// the body starts after the header and the extended header
+		offset := int64(10+header.Size) - body.N
+		n, err := io.ReadAtLeast(body, frameHeader, len(frameHeader))
 		if err != nil {
 			if errors.Is(err, io.EOF) {
+				stop(offset, "end of the tag")
 				break
 			}
-			return nil, err
+			// padding shorter than a frame header
+			if errors.Is(err, io.ErrUnexpectedEOF) && frameHeader[0] == 0 {
+				o.trace(TraceEvent{Kind: TracePadding, Offset: offset, Size: n})
+				stop(offset, "padding")
+				break
+			}
+			if errors.Is(err, io.ErrUnexpectedEOF) {
+				if err := lenient(truncated("frame header at offset %d", offset)); err != nil {
+					return err
+				}
+				stop(offset, "frame header cut short")
+				break
+			}
+			return err
+		}
+		frame := newFrameHeader(frameHeader, version)
+		frame.offset = offset
+		if o.strict && frameHeader[0] != 0 && !validFrameID(frame.FrameID) {
+			if err := lenient(frame.violation("frame ID %q has characters other than A-Z and 0-9", frame.FrameID)); err != nil {
+				return err
+			}
+		}
+		if frameHeader[0] == 0 || !validFrameID(frame.FrameID) {
+			if frameHeader[0] == 0 {
+				o.trace(TraceEvent{Kind: TracePadding, Offset: offset, Size: n + int(body.N)})
+				stop(offset, "padding")
+			} else {
+				stop(offset, "not a frame ID, taken as junk padding")
+			}
+			// hit the padding, throw the rest of it away
+			_, err = io.Copy(io.Discard, body)
+			if err != nil {
+				return err
+			}
+			break
+		}
+		frames++
+		if o.maxFrames > 0 && frames > o.maxFrames {
+			err = lenient(fmt.Errorf("frame at offset %d is past the %d frame limit: %w", offset, o.maxFrames, ErrTooManyFrames))
+			if err != nil {
+				return err
+			}
+			stop(offset, "past the frame limit")
+			_, err = io.Copy(io.Discard, body)
+			if err != nil {
+				return err
+			}
+			break
+		}
+		if version == 4 {
+			// lots of taggers write v2.4 tags with v2.3's plain sizes
+			frame.Size, plainSizes, err = frameSize(body, frameHeader, plainSizes, o.maxFrameSize)
+			if err != nil {
+				return err
+			}
+		}
+		o.trace(TraceEvent{Kind: TraceFrame, Offset: offset, Frame: frame})
+		skip := func(reason string) {
+			o.trace(TraceEvent{Kind: TraceSkip, Offset: offset, Frame: frame, Reason: reason})
+		}
+		normalize := version == 2 && !o.raw && !o.originalIDs
+		if normalize {
+			// the ID is all the filters need, the data is done once it's read
+			if id, ok := v22FrameIDs[frame.FrameID]; ok {
+				frame.FrameID = id
+			}
+		}
+		if o.strict {
+			if err := lenient(checkFrameHeader(frame, frameHeader)); err != nil {
+				return err
+			}
+		}
+		if int64(frame.Size) > body.N {
+			err = lenient(frame.errorf("declares %d bytes but only %d remain in the tag", frame.Size, body.N))
+			if err != nil {
+				return err
+			}
+			// keep what there is, it's the last frame either way
+			frame.Size = int(body.N)
+			frame.truncated = true
+		}
+		if frame.Size == 0 && !o.raw {
+			// the spec wants at least a byte, there's nothing to keep
+			if o.strict {
+				if err := lenient(frame.violation("has no data")); err != nil {
+					return err
+				}
+			}
+			skip("no data")
+			header.framesSize = int(start - body.N)
+			continue
+		}
+		if o.frames != nil && !o.frames[frame.FrameID] {
+			// not wanted, skip the size it takes up in the tag
+			skip("not wanted")
+			_, err = io.CopyN(io.Discard, body, int64(frame.Size))
+			if err != nil {
+				return err
+			}
+			header.framesSize = int(start - body.N)
+			continue
+		}
+		if o.lazySize >= 0 && frame.Size > o.lazySize {
+			rawID := string(frameHeader[:4])
+			if version == 2 {
+				rawID = rawID[:3]
+			}
+			left, err := leaveLazy(body, header, frame, rawID, o)
+			if err != nil {
+				return err
+			}
+			if left {
+				skip("left to load later")
+				header.framesSize = int(start - body.N)
+				continue
+			}
+		}
+		if o.spill != nil && o.tagResult && !o.raw && frame.Size > o.spillSize && binaryFrames[frame.FrameID] {
+			spilled, err := spillFrame(body, header, frame, o)
+			if err != nil {
+				return err
+			}
+			if spilled {
+				skip("spilled")
+				header.framesSize = int(start - body.N)
+				continue
+			}
+		}
+		if o.maxInlineSize >= 0 && frame.Size > o.maxInlineSize && binaryFrames[frame.FrameID] {
+			skip("over the inline size")
+			_, err = io.CopyN(io.Discard, body, int64(frame.Size))
+			if err != nil {
+				return err
+			}
+			header.framesSize = int(start - body.N)
+			header.skipped = append(header.skipped, SkippedFrame{FrameID: frame.FrameID, Size: frame.Size, Offset: offset})
+			continue
+		}
+		if frame.Size > o.maxFrameSize {
+			err = lenient(frame.errorf("declares %d bytes, more than the %d byte limit", frame.Size, o.maxFrameSize))
+			if err != nil {
+				return err
+			}
+			skip("over the size limit")
+			_, err = io.CopyN(io.Discard, body, int64(frame.Size))
+			if err != nil {
+				return err
+			}
+			header.framesSize = int(start - body.N)
+			continue
+		}
+		// deunsync works in place so it needs a copy
+		unsynced := version == 4 && !o.raw && (tagUnsync || frame.Unsynchronised())
+		if !unsynced {
+			frame.Data = take(body, frame.Size)
+		}
+		err = nil
+		switch {
+		case frame.Data != nil:
+		case frame.Size > maxUpfront:
+			err = frame.readGrowing(body)
+		default:
+			err = frame.readData(body, data.alloc(frame.Size))
+		}
+		if errors.Is(err, ErrTruncated) {
+			// the data ran out, what was read is still worth having
+			err = lenient(err)
 		}
-		frame := newFrameHeader(buf)
-		frame.ReadData(r)
-		//fmt.Printf("Frame: %v\n", frame)
-		props[frame.FrameID] = frame.Decoded()
-	}
-	// Footer just read off the last 10 bytes
-	if header.HasFooter() {
-		_, err = io.ReadAtLeast(r, buf, 10)
 		if err != nil {
-			return nil, err
+			return err
+		}
+		if !o.raw {
+			err = frame.unformat(tagUnsync, o.maxFrameSize)
+		}
+		if err != nil {
+			if err := lenient(err); err != nil {
+				return err
+			}
+			skip("format flags couldn't be undone")
+			header.framesSize = int(start - body.N)
+			continue
+		}
+		header.framesSize = int(start - body.N)
+		if normalize && frame.FrameID == "APIC" {
+			frame.Data = picToAPIC(frame.Data)
+		}
+		if o.strict && !o.raw {
+			if err := lenient(checkFrame(frame)); err != nil {
+				return err
+			}
+		}
+		err = fn(frame)
+		if err != nil {
+			return err
 		}
 	}
-	return props, nil
+	return nil
 }
 
-type frame struct {
+// Frame is a single frame. Data has had everything the format flags did
+// undone, Decoded is the text in it.
+type Frame struct {
 	FrameID string
 	Size    int
 	Flags   []byte // 2
 	Data    []byte
+	// Version is the major version of the containing tag, the flag bit
+	// layout differs between v2.3 and v2.4
+	Version byte
+	// DataLength is the size of Data once everything the format flags did
+	// is undone, from the v2.4 data length indicator or the v2.3
+	// decompressed size. -1 when there isn't one.
+	DataLength int
+	// EncryptionMethod is the ENCR method symbol of an encrypted frame
+	EncryptionMethod byte
+	// GroupID is the GRID group symbol of a grouped frame
+	GroupID byte
+
+	// offset is where the frame starts from the start of the tag
+	offset int64
+	// truncated is set when the frame was cut short
+	truncated bool
 }
 
-func (f *frame) String() string {
+func (f *Frame) String() string {
 	return fmt.Sprintf("%s:%s", f.FrameID, f.Decoded())
 }
 
-func (f *frame) Decoded() string {
-	if f.Data == nil {
+// Key is what the frame is stored under in the map, mostly the frame ID
+// but frames that carry a description get it appended like TXXX:description,
+// WXXX:description and COMM:eng:description, USLT is keyed the same as COMM
+func (f *Frame) Key() string {
+	switch f.FrameID {
+	case "TXXX", "TXX":
+		desc, _ := parseUserText(f.Data)
+		return f.FrameID + ":" + desc
+	case "WXXX", "WXX":
+		desc, _ := parseUserURL(f.Data)
+		return f.FrameID + ":" + desc
+	case "COMM", "COM", "USLT", "ULT":
+		c := parseComment(f.Data)
+		return f.FrameID + ":" + string(c.Language) + ":" + c.Description
+	}
+	return f.FrameID
+}
+
+func (f *Frame) Decoded() string {
+	return f.decoded(DefaultTextSeparator)
+}
+
+func (f *Frame) decoded(sep string) string {
+	if len(f.Data) == 0 || f.opaque() {
+		// the bytes of binary frames are in Data as they are
 		return ""
 	}
+	switch f.FrameID {
+	case "WXXX", "WXX":
+		_, url := parseUserURL(f.Data)
+		return url
+	case "COMM", "COM", "USLT", "ULT":
+		return parseComment(f.Data).Text
+	case "APIC", "PIC":
+		// the image itself isn't text, use Tag.Pictures for that
+		return parsePicture(f.Data, f.FrameID == "PIC").Description
+	case "PRIV":
+		// same for the private data, use Tag.Private
+		return parsePrivate(f.Data).Owner
+	case "GEOB", "GEO":
+		// and Tag.Objects
+		return parseObject(f.Data).Description
+	case "ENCR":
+		return parseEncryption(f.Data).Owner
+	case "AENC", "CRA":
+		return parseAudioEncryption(f.Data, 0).Owner
+	case "GRID":
+		return parseGroup(f.Data).Owner
+	case "LINK", "LNK":
+		return parseLink(f.Data, f.Version).URL
+	case FramePodcast:
+		// only there to say it's a podcast
+		return "1"
+	case "TCON", "TCO":
+		return strings.Join(parseGenres(f.text()), ", ")
+	}
+	if strings.HasPrefix(f.FrameID, "T") {
+		if enc, b, ok := f.valueData(); ok {
+			// most frames only have one, no need for the slice
+			if value, ok := singleValue(enc, b); ok {
+				return value
+			}
+		}
+		return strings.Join(f.values(), sep)
+	}
+	return f.text()
+}
+
+// values splits a text frame into the values v2.4 separates with
+// terminators, a terminator at the end isn't another value
+func (f *Frame) values() []string {
+	if len(f.Data) == 0 {
+		return nil
+	}
+	if enc, b, ok := f.valueData(); ok {
+		return splitValues(enc, b)
+	}
+	if f.FrameID == "TCON" || f.FrameID == "TCO" {
+		return parseGenres(f.text())
+	}
+	return []string{string(f.Data)}
+}
+
+// valueData is the encoding and the bytes the values are split out of, ok
+// is false for genres and data without an encoding byte
+func (f *Frame) valueData() (enc byte, b []byte, ok bool) {
+	if len(f.Data) == 0 {
+		return 0, nil, false
+	}
+	switch f.FrameID {
+	case "TXXX", "TXX":
+		_, value := splitTerminated(f.Data[0], f.Data[1:])
+		return f.Data[0], value, true
+	case "TCON", "TCO":
+		return 0, nil, false
+	}
+	switch f.Data[0] {
+	case encodingISO88591, encodingUTF16, encodingUTF16BE, encodingUTF8:
+		return f.Data[0], f.Data[1:], true
+	}
+	return 0, nil, false
+}
+
+// text decodes the data as a text or URL frame
+func (f *Frame) text() string {
+	if len(f.Data) == 0 {
+		return ""
+	}
+	if strings.HasPrefix(f.FrameID, "W") && !textURL(f) {
+		// URL frames have no encoding byte, they're always ISO-8859-1
+		return decodeLatin1(trimNull(f.Data))
+	}
 	switch f.Data[0] {
-	case 0:
-		//ISO-8859-1 FIXME?
-		return string(f.Data[1 : len(f.Data)-1])
-	case 1:
-		// UTF-16 TODO
-	case 2:
-		// UTF-16BE TODO
-	case 3:
-		// UTF-8 remove first and last bytes
-		return string(f.Data[1 : len(f.Data)-1])
+	case encodingISO88591, encodingUTF16, encodingUTF16BE, encodingUTF8:
+		return decodeText(f.Data[0], f.Data[1:])
 	}
 	return string(f.Data)
 }
 
-func (f *frame) ReadData(r io.Reader) error {
-	f.Data = make([]byte, f.Size)
-	_, err := io.ReadAtLeast(r, f.Data, f.Size)
-	return err
+func (f *Frame) ReadData(r io.Reader) error {
+	return f.readData(r, make([]byte, f.Size))
+}
+
+// readData is ReadData into data, which is f.Size long
+func (f *Frame) readData(r io.Reader, data []byte) error {
+	f.Data = data
+	n, err := io.ReadAtLeast(r, f.Data, f.Size)
+	if err != nil {
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			size := f.Size
+			f.Data, f.Size, f.truncated = f.Data[:n], n, true
+			return f.wrapError(truncated("expected %d bytes read %d", size, n))
+		}
+		return err
+	}
+	return nil
+}
+
+// maxUpfront is the biggest frame whose data is allocated before it's read,
+// a 25 byte file can say it has a 16MB frame
+const maxUpfront = 1 << 20
+
+// growStart is how much readGrowing reads before the data has shown it's
+// really there, after that it grows by as much as it has read
+const growStart = 64 << 10
+
+// readGrowing is readData for the frames too big to allocate on the say so
+// of their header, the data grows as it's read
+func (f *Frame) readGrowing(r io.Reader) error {
+	var data []byte
+	for len(data) < f.Size {
+		chunk := growStart
+		if len(data) > chunk {
+			chunk = len(data)
+		}
+		if left := f.Size - len(data); chunk > left {
+			chunk = left
+		}
+		start := len(data)
+		data = append(data, make([]byte, chunk)...)
+		n, err := io.ReadFull(r, data[start:])
+		data = data[: start+n : start+n]
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			size := f.Size
+			f.Data, f.Size, f.truncated = data, len(data), true
+			return f.wrapError(truncated("expected %d bytes read %d", size, len(data)))
+		}
+		if err != nil {
+			return err
+		}
+	}
+	f.Data = data
+	return nil
+}
+
+// Truncated is whether the frame was cut short by the end of the tag or of
+// the data, WithLenient keeps those frames with what was there
+func (f *Frame) Truncated() bool {
+	return f.truncated
 }
 
-// NewFrameHeader takes a raw 10 bytes to parse the frame header
-// pass the reader directly to ReadData to get the data
-func newFrameHeader(raw []byte) *frame {
-	return &frame{
-		FrameID: string(raw[:4]),
-		Size:    synsafeInt(raw[4:8]),
-		Flags:   raw[8:],
+// binaryFrames are the frames WithMaxInlineFrameSize skips
+var binaryFrames = map[string]bool{
+	"APIC": true, "PIC": true,
+	"GEOB": true, "GEO": true,
+	"PRIV": true,
+}
+
+// validFrameID is only capital letters and digits
+func validFrameID(id string) bool {
+	for i := 0; i < len(id); i++ {
+		c := id[i]
+		if (c < 'A' || c > 'Z') && (c < '0' || c > '9') {
+			return false
+		}
 	}
+	return len(id) > 0
+}
+
+func frameHeaderSize(version byte) int {
+	if version == 2 {
+		return 6
+	}
+	return 10
+}
+
+// NewFrameHeader takes a raw 10 bytes (6 for v2.2) to parse the frame header
+// pass the reader directly to ReadData to get the data.
+// v2.2 and v2.3 frame sizes are plain big endian, v2.4 made them syncsafe.
+func newFrameHeader(raw []byte, version byte) *Frame {
+	// the flags come along in the same allocation
+	alloc := &struct {
+		frame Frame
+		flags [2]byte
+	}{}
+	f := &alloc.frame
+	f.Flags = alloc.flags[:]
+	f.Version = version
+	f.DataLength = -1
+	if version == 2 {
+		// 3 character IDs, 3 byte sizes and no flags
+		f.FrameID = internID(raw[:3])
+		f.Size = beInt(raw[3:6])
+		return f
+	}
+	f.FrameID = internID(raw[:4])
+	f.Size = synsafeInt(raw[4:8])
+	if version == 3 {
+		f.Size = beInt(raw[4:8])
+	}
+	copy(f.Flags, raw[8:10])
+	return f
+}
+
+// knownIDs are the frame IDs internID hands out instead of new strings
+var knownIDs = func() map[string]string {
+	ids := map[string]string{}
+	for id := range frameInfos {
+		ids[id] = id
+	}
+	for id := range v22FrameIDs {
+		ids[id] = id
+	}
+	for id := range xsoFrameIDs {
+		ids[id] = id
+	}
+	return ids
+}()
+
+// internID is the ID as a string, the known ones don't allocate
+func internID(raw []byte) string {
+	if id, ok := knownIDs[string(raw)]; ok {
+		return id
+	}
+	return string(raw)
+}
+
+// slab hands out the data of small frames from one bigger allocation. Each
+// one's capacity is its length so appending to it can't run into the next.
+type slab []byte
+
+const slabSize = 4096
+
+func (s *slab) alloc(n int) []byte {
+	if n > slabSize/8 {
+		return make([]byte, n)
+	}
+	if len(*s) < n {
+		*s = make([]byte, slabSize)
+	}
+	b := (*s)[:n:n]
+	*s = (*s)[n:]
+	return b
+}
+
+// readers are the bufio.Readers put around readers that aren't one, kept
+// for reuse since each has a 4KB buffer
+var readers = sync.Pool{New: func() interface{} { return bufio.NewReader(nil) }}
+
+// bufferedReader is r as a bufio.Reader, one that already is gets used as
+// it is. Nothing read through it can be kept past releaseReader.
+func bufferedReader(r io.Reader) *bufio.Reader {
+	if br, ok := r.(*bufio.Reader); ok {
+		return br
+	}
+	br := readers.Get().(*bufio.Reader)
+	br.Reset(r)
+	return br
+}
+
+// releaseReader puts br back in the pool when bufferedReader made it for r
+func releaseReader(br *bufio.Reader, r io.Reader) {
+	if io.Reader(br) == r {
+		return
+	}
+	br.Reset(nil)
+	readers.Put(br)
+}
+
+// streamReader is the bufio.Reader a tag is streamed through along with
+// what it reads from, so endsFrame can look further ahead than the buffer
+type streamReader struct {
+	br  *bufio.Reader
+	src io.Reader
+	// ahead is what peekAt read past the buffer of a source it couldn't
+	// seek, it's read before the rest of br
+	ahead []byte
+}
+
+func newStreamReader(br *bufio.Reader, src io.Reader) *streamReader {
+	return &streamReader{br: br, src: src}
+}
+
+func (r *streamReader) Read(p []byte) (int, error) {
+	if len(r.ahead) == 0 {
+		return r.br.Read(p)
+	}
+	n := copy(p, r.ahead)
+	r.ahead = r.ahead[n:]
+	if len(r.ahead) == 0 {
+		r.ahead = nil
+	}
+	return n, nil
+}
+
+// peekAt peeks in the buffer when it's far enough, otherwise it seeks over
+// to read the bytes and back. A source that can't seek has what's in
+// between read into ahead.
+func (r *streamReader) peekAt(off int64, n int) ([]byte, error) {
+	end := off + int64(n)
+	if r.ahead == nil && end <= int64(r.br.Size()) {
+		b, err := r.br.Peek(int(end))
+		if errors.Is(err, io.EOF) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		return b[off:], nil
+	}
+	if rs, ok := r.src.(io.ReadSeeker); ok && r.ahead == nil {
+		if cur, err := rs.Seek(0, io.SeekCurrent); err == nil {
+			return r.seekPeek(rs, cur, off, n)
+		}
+	}
+	for int64(len(r.ahead)) < end {
+		chunk := end - int64(len(r.ahead))
+		if chunk > growStart {
+			chunk = growStart
+		}
+		start := len(r.ahead)
+		r.ahead = append(r.ahead, make([]byte, chunk)...)
+		read, err := io.ReadFull(r.br, r.ahead[start:])
+		r.ahead = r.ahead[:start+read]
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return r.ahead[off:end], nil
+}
+
+// seekPeek reads n bytes off past where br is up to, cur is where rs is
+// which is past whatever br has buffered
+func (r *streamReader) seekPeek(rs io.ReadSeeker, cur, off int64, n int) ([]byte, error) {
+	if _, err := rs.Seek(cur-int64(r.br.Buffered())+off, io.SeekStart); err != nil {
+		return nil, err
+	}
+	b := make([]byte, n)
+	_, err := io.ReadFull(rs, b)
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		b, err = nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if _, err := rs.Seek(cur, io.SeekStart); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// frameSize picks between the syncsafe and the plain reading of a v2.4
+// frame size, whichever one ends where the next frame or the padding
+// starts. plain tries the plain size first since a tag that used it once
+// uses it everywhere. When neither fits the first one tried wins.
+func frameSize(body *io.LimitedReader, raw []byte, plain bool, limit int) (int, bool, error) {
+	safe := synsafeInt(raw[4:8])
+	whole := beInt(raw[4:8])
+	if safe == whole {
+		return safe, plain, nil
+	}
+	fits := func(size int) bool {
+		return int64(size) <= body.N && size <= limit
+	}
+	if !fits(whole) {
+		return safe, plain, nil
+	}
+	if !fits(safe) {
+		return whole, true, nil
+	}
+	sizes := []int{safe, whole}
+	for _, b := range raw[4:8] {
+		// a byte with the top bit set can't be syncsafe
+		plain = plain || b&0x80 != 0
+	}
+	if plain {
+		sizes = []int{whole, safe}
+	}
+	for _, size := range sizes {
+		ok, err := endsFrame(body, size, limit)
+		if err != nil {
+			return 0, false, err
+		}
+		if ok {
+			return size, size == whole, nil
+		}
+	}
+	return sizes[0], sizes[0] == whole, nil
+}
+
+// endsFrame is whether a frame of size bytes ends right at the end of the
+// tag, the padding or another frame header. It only looks, nothing is read
+// from the body. One it can't look ahead in is false.
+func endsFrame(body *io.LimitedReader, size int, limit int) (bool, error) {
+	if int64(size) > body.N || size > limit {
+		return false, nil
+	}
+	if int64(size) == body.N {
+		return true, nil
+	}
+	n := int64(size) + 10
+	if n > body.N {
+		n = body.N
+	}
+	p, ok := body.R.(peeker)
+	if !ok {
+		return false, nil
+	}
+	next, err := p.peekAt(int64(size), int(n)-size)
+	if err != nil {
+		return false, err
+	}
+	if next == nil {
+		// cut short, the truncated frame is dealt with when it's read
+		return false, nil
+	}
+	return startsFrame(next), nil
+}
+
+// startsFrame is whether next is the start of a frame header or padding
+func startsFrame(next []byte) bool {
+	if next[0] == 0 {
+		return len(bytes.Trim(next, "\x00")) == 0
+	}
+	return len(next) == 10 && validFrameID(string(next[:4]))
 }
 
 // this is some ridiculous shit about only using 7 bits
@@ -131,44 +974,115 @@ func synsafeInt(bs []byte) int {
 	return acc
 }
 
-type iD3Header struct {
+// beInt is a plain big endian integer of any width
+func beInt(bs []byte) int {
+	var acc int
+	for _, b := range bs {
+		acc = acc<<8 | int(b)
+	}
+	return acc
+}
+
+// Header is the 10 byte tag header, Size is the tag without the header or
+// footer and Version the major and revision numbers
+type Header struct {
 	ID3     string
 	Version []byte // 2
 	Flags   byte
 	Size    int
+
+	extended *ExtendedHeader
+	// skipped are the frames that couldn't be decrypted
+	skipped []SkippedFrame
+	// framesSize is how much of the body the frames take up
+	framesSize int
+	// errors are the frame problems WithLenient read past
+	errors []error
+	// reinterpreted are the keys of the frames WithDetectUTF8 found were
+	// UTF-8
+	reinterpreted []string
+	// lazy are the frames WithLazyFrames left to load
+	lazy []*LazyFrame
+	// spilled are the frames WithSpill wrote out
+	spilled []SpilledFrame
+}
+
+// ReadID3Header reads just the 10 byte header so the size is known before
+// reading the rest of the tag. Nothing past the header is read. A header
+// that's cut short is ErrTruncated.
+func ReadID3Header(r io.Reader) (*Header, error) {
+	buf := make([]byte, 10)
+	n, err := io.ReadFull(r, buf)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, err
+	}
+	if n < 3 || string(buf[:3]) != "ID3" {
+		return nil, notFoundError("ID3 header")
+	}
+	if err != nil {
+		return nil, truncated("ID3 header, read %d of 10 bytes", n)
+	}
+	return newID3(buf)
+}
+
+// HasID3 is whether r starts with an ID3v2 header
+func HasID3(r io.Reader) bool {
+	_, err := ReadID3Header(r)
+	return err == nil
 }
 
 // NewID3 takes a raw 10 bytes to parse the header
-func newID3(raw []byte) (*iD3Header, error) {
+func newID3(raw []byte) (*Header, error) {
 	if string(raw[:3]) != "ID3" && string(raw[:3]) != "3DI" {
-		return nil, errors.New("not an ID3 block")
+		return nil, notFoundError("ID3 header")
+	}
+	if raw[6]|raw[7]|raw[8]|raw[9] >= 0x80 {
+		return nil, fmt.Errorf("%s header size %x isn't syncsafe", raw[:3], raw[6:10])
 	}
-	return &iD3Header{
+	return &Header{
 		ID3:     string(raw[:3]),
-		Version: raw[3:5],
+		Version: []byte{raw[3], raw[4]},
 		Flags:   raw[5],
 		Size:    synsafeInt(raw[6:]),
 	}, nil
 }
 
-func (ih *iD3Header) VersionString() string {
+// frameErrors is the problems WithLenient read past as one error, nil when
+// there weren't any
+func (ih *Header) frameErrors() error {
+	if len(ih.errors) == 0 {
+		return nil
+	}
+	return ErrorList(ih.errors)
+}
+
+// TotalSize is the size of the whole tag including the header and footer
+func (ih *Header) TotalSize() int {
+	size := 10 + ih.Size
+	if ih.HasFooter() {
+		size += 10
+	}
+	return size
+}
+
+func (ih *Header) VersionString() string {
 	return fmt.Sprintf("2.%d.%d", ih.Version[0], ih.Version[1])
 }
 
-func (ih *iD3Header) ExtendedHeader() bool {
-	return ih.Flags&1<<6 != 0
+func (ih *Header) ExtendedHeader() bool {
+	return ih.Flags&(1<<6) != 0
 }
 
-func (ih *iD3Header) Unsynchronisation() bool {
-	return ih.Flags&1<<7 != 0
+func (ih *Header) Unsynchronisation() bool {
+	return ih.Flags&(1<<7) != 0
 }
-func (ih *iD3Header) Experimental() bool {
-	return ih.Flags&1<<5 != 0
+func (ih *Header) Experimental() bool {
+	return ih.Flags&(1<<5) != 0
 }
-func (ih *iD3Header) HasFooter() bool {
-	return ih.Flags&1<<4 != 0
+func (ih *Header) HasFooter() bool {
+	return ih.Flags&(1<<4) != 0
 }
 
-func (ih *iD3Header) IsFooter() bool {
+func (ih *Header) IsFooter() bool {
 	return ih.ID3 == "3DI"
 }
@@ -1,7 +1,15 @@
 package easyid3
 
 import (
+	"bufio"
 	"bytes"
+	"errors"
+	"io"
+	"math/rand"
+	"os"
+	"reflect"
+	"runtime"
+	"strings"
 	"testing"
 )
 
@@ -19,7 +27,599 @@ func TestIVSID3(t *testing.T) {
 	} else {
 		t.Fatal("missing key TRCK")
 	}
-	if _, ok := vals["TXXX"]; !ok {
-		t.Fatal("missing key TXXX")
+	if v, ok := vals["TXXX:segmentmetadata"]; ok {
+		if !strings.HasPrefix(v, `{"broadc_r":0,`) {
+			t.Fatalf("Wrong value for TXXX got %v", v)
+		}
+	} else {
+		t.Fatal("missing key TXXX:segmentmetadata")
+	}
+}
+
+// frameBytes builds a single frame with the size in the layout the version uses
+func frameBytes(version byte, id string, data []byte) []byte {
+	return flaggedFrameBytes(version, id, 0, 0, data)
+}
+
+// flaggedFrameBytes is frameBytes with the status and format flag bytes
+func flaggedFrameBytes(version byte, id string, status, format byte, data []byte) []byte {
+	out := []byte(id)
+	switch version {
+	case 2:
+		n := len(data)
+		out = append(out, byte(n>>16), byte(n>>8), byte(n))
+		return append(out, data...)
+	case 3:
+		n := len(data)
+		out = append(out, byte(n>>24), byte(n>>16), byte(n>>8), byte(n))
+	default:
+		out = append(out, synsafeBytes(len(data))...)
+	}
+	out = append(out, status, format)
+	return append(out, data...)
+}
+
+// tagBytes wraps the frames in an ID3 header of the given major version
+func tagBytes(version byte, flags byte, frames ...[]byte) []byte {
+	var body []byte
+	for _, f := range frames {
+		body = append(body, f...)
+	}
+	out := []byte{'I', 'D', '3', version, 0, flags}
+	out = append(out, synsafeBytes(len(body))...)
+	return append(out, body...)
+}
+
+// appendedTagBytes is a v2.4 tag with the footer flag and the footer
+func appendedTagBytes(frames ...[]byte) []byte {
+	tag := tagBytes(4, 0x10, frames...)
+	footer := append([]byte("3DI"), tag[3:10]...)
+	return append(tag, footer...)
+}
+
+func TestV23FrameSizes(t *testing.T) {
+	long := bytes.Repeat([]byte("la "), 100)
+	text := append(append([]byte{3}, long...), 0)
+	for _, version := range []byte{3, 4} {
+		tag := tagBytes(version, 0,
+			frameBytes(version, "TIT2", text),
+			frameBytes(version, "TPE1", []byte{3, 'A', 'r', 't', 0}),
+		)
+		vals, err := ReadID3(bytes.NewReader(tag))
+		if err != nil {
+			t.Fatalf("v2.%d: Failed read: %v", version, err)
+		}
+		if vals["TIT2"] != string(long) {
+			t.Fatalf("v2.%d: wrong TIT2 %q", version, vals["TIT2"])
+		}
+		if vals["TPE1"] != "Art" {
+			t.Fatalf("v2.%d: wrong TPE1 %q", version, vals["TPE1"])
+		}
+	}
+}
+
+// onlyReader hides everything but Read
+type onlyReader struct {
+	io.Reader
+}
+
+func TestFrameSizeLookAhead(t *testing.T) {
+	// 12.5MB plainly, there's nowhere near that much
+	tiny := []byte("ID3\x04\x00\x00\x7f\x7f\x7f\x7fTIT2\x00\xbf\x00\x00\x00\x00\x03abcd")
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	props, err := ReadID3(bytes.NewReader(tiny))
+	runtime.ReadMemStats(&after)
+	if err == nil || len(props) != 0 {
+		t.Errorf("Expected a truncated frame got %v %v", props, err)
+	}
+	if allocated := after.TotalAlloc - before.TotalAlloc; allocated > 256<<10 {
+		t.Errorf("Allocated %d bytes for a %d byte tag", allocated, len(tiny))
+	}
+
+	// looking ahead within the buffer reads nothing, past it a source that
+	// seeks is seeked and moved back
+	data := append(make([]byte, 300), "TIT2"...)
+	data = append(append(data, make([]byte, 20<<10)...), "TPE1\x00\x00\x00\x01\x00\x00"...)
+	src := bytes.NewReader(data)
+	br := bufio.NewReader(src)
+	body := &io.LimitedReader{R: newStreamReader(br, src), N: 1 << 20}
+	allocs := testing.AllocsPerRun(10, func() {
+		if ok, err := endsFrame(body, 300, DefaultMaxFrameSize); !ok || err != nil {
+			t.Errorf("Expected a frame after got %v %v", ok, err)
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("Expected no allocations peeking in the buffer got %v", allocs)
+	}
+	if ok, err := endsFrame(body, 304+20<<10, DefaultMaxFrameSize); !ok || err != nil {
+		t.Errorf("Expected a frame past the buffer got %v %v", ok, err)
+	}
+	if body.N != 1<<20 || br.Buffered() != 4096 || src.Len() != len(data)-4096 {
+		t.Errorf("Expected the body left alone, %d buffered %d left", br.Buffered(), src.Len())
+	}
+
+	// one that can't seek has it read ahead and read again from there
+	br = bufio.NewReader(onlyReader{bytes.NewReader(data)})
+	body = &io.LimitedReader{R: newStreamReader(br, br), N: int64(len(data))}
+	if ok, err := endsFrame(body, 304+20<<10, DefaultMaxFrameSize); !ok || err != nil {
+		t.Errorf("Expected a frame past the buffer got %v %v", ok, err)
+	}
+	if got, err := io.ReadAll(body); err != nil || !bytes.Equal(got, data) {
+		t.Errorf("Expected the same data read after looking ahead, got %d bytes %v", len(got), err)
+	}
+}
+
+func TestNonSyncsafeFrameSizes(t *testing.T) {
+	// v2.4 with plain sizes, the APIC's syncsafe size lands in the middle
+	// of the picture and the COMM's has the top bit set
+	data, err := os.ReadFile("testdata/nonsyncsafe.mp3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := ReadTag(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	if parsed.Title() != "Plain sizes" || parsed.Artist() != "Old Tagger" || parsed.Album() != "Syncsafe What" {
+		t.Errorf("Wrong tag %q %q %q", parsed.Title(), parsed.Artist(), parsed.Album())
+	}
+	pics := parsed.Pictures()
+	if len(pics) != 1 || len(pics[0].Data) != 0x170-19 || !bytes.HasSuffix(pics[0].Data, []byte{0xff, 0xd9}) {
+		t.Errorf("Wrong pictures %+v", pics)
+	}
+	if c := parsed.Comments(); len(c) != 1 || !strings.HasPrefix(c[0].Text, "written by a tagger") {
+		t.Errorf("Wrong comments %+v", c)
+	}
+
+	// syncsafe sizes that happen to read as a plain size that fits stay
+	// syncsafe when they're the ones landing on a frame
+	long := bytes.Repeat([]byte("x"), 0x100)
+	tag := tagBytes(4, 0,
+		frameBytes(4, "TIT2", append([]byte{3}, long...)),
+		frameBytes(4, "TPE1", []byte("\x03Artist")),
+		make([]byte, 0x200),
+	)
+	vals, err := ReadID3(bytes.NewReader(tag))
+	if err != nil || vals["TIT2"] != string(long) || vals["TPE1"] != "Artist" {
+		t.Errorf("Wrong values %q %v", vals, err)
+	}
+}
+
+func TestNonSyncsafeBigFrame(t *testing.T) {
+	// a 200KB APIC with a plain size that has no top bits set, its
+	// syncsafe size lands 52KB in, well past the buffer
+	data, err := os.ReadFile("testdata/bigapic.mp3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{"TPE1": "Artist", "TIT2": "Title", "TALB": "Album"}
+	for name, read := range map[string]func() (map[string]string, error){
+		"seeker":   func() (map[string]string, error) { return ReadID3(bytes.NewReader(data)) },
+		"stream":   func() (map[string]string, error) { return ReadID3(onlyReader{bytes.NewReader(data)}) },
+		"bytes":    func() (map[string]string, error) { return ReadID3Bytes(data) },
+		"readerat": func() (map[string]string, error) { return ReadID3At(bytes.NewReader(data)) },
+		"walk": func() (map[string]string, error) {
+			vals := map[string]string{}
+			err := WalkFrames(onlyReader{bytes.NewReader(data)}, func(f *Frame) error {
+				if f.FrameID != "APIC" {
+					vals[f.FrameID] = f.Decoded()
+				}
+				return nil
+			})
+			return vals, err
+		},
+	} {
+		vals, err := read()
+		if err != nil {
+			t.Errorf("%s: failed read: %v", name, err)
+			continue
+		}
+		delete(vals, "APIC")
+		if !reflect.DeepEqual(vals, want) {
+			t.Errorf("%s: wrong values %q", name, vals)
+		}
+	}
+	parsed, err := ReadTag(onlyReader{bytes.NewReader(data)})
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	if pics := parsed.Pictures(); len(pics) != 1 || len(pics[0].Data) != 204800-14 {
+		t.Errorf("Wrong pictures %d", len(pics))
+	}
+}
+
+// hand built the way iTunes 4 wrote tags
+var v22ID3 = []byte{
+	'I', 'D', '3', 0x2, 0x0, 0x0, 0x0, 0x0, 0x0, 0x3d,
+	'T', 'T', '2', 0x0, 0x0, 0xd, 0x0, 'L', 'o', 'n', 'g', ' ', 'S', 'e', 'a', 's', 'o', 'n', 0x0,
+	'T', 'P', '1', 0x0, 0x0, 0xa, 0x0, 'F', 'i', 's', 'h', 'm', 'a', 'n', 's', 0x0,
+	'T', 'A', 'L', 0x0, 0x0, 0x9, 0x0, 'S', 'e', 'a', 's', 'o', 'n', 's', 0x0,
+	'T', 'R', 'K', 0x0, 0x0, 0x5, 0x0, '1', '/', '1', 0x0,
+}
+
+func TestV22(t *testing.T) {
+	vals, err := ReadID3(bytes.NewReader(v22ID3))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	expected := map[string]string{
+		"TIT2": "Long Season",
+		"TPE1": "Fishmans",
+		"TALB": "Seasons",
+		"TRCK": "1/1",
+	}
+	if !reflect.DeepEqual(vals, expected) {
+		t.Errorf("Expected %v got %v", expected, vals)
+	}
+
+	vals, err = ReadID3(bytes.NewReader(v22ID3), WithOriginalFrameIDs())
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	expected = map[string]string{
+		"TT2": "Long Season",
+		"TP1": "Fishmans",
+		"TAL": "Seasons",
+		"TRK": "1/1",
+	}
+	if !reflect.DeepEqual(vals, expected) {
+		t.Errorf("Expected %v got %v", expected, vals)
+	}
+}
+
+func TestStopsAtTagSize(t *testing.T) {
+	tag := tagBytes(4, 0, frameBytes(4, "TIT2", []byte{3, 'T', 'i', 't', 'l', 'e', 0}))
+	// audio that happens to contain something frame shaped
+	audio := []byte{0xff, 0xfb, 0x90, 0x64}
+	audio = append(audio, frameBytes(4, "TPE1", []byte{3, 'B', 'a', 'd', 0})...)
+	audio = append(audio, bytes.Repeat([]byte{0xff, 0xfb, 0x90, 0x64, 0x0}, 100)...)
+	vals, err := ReadID3(bytes.NewReader(append(tag, audio...)))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	if len(vals) != 1 || vals["TIT2"] != "Title" {
+		t.Fatalf("Expected only TIT2 got %v", vals)
+	}
+}
+
+func TestPadding(t *testing.T) {
+	for _, padding := range []int{2048, 4, 0} {
+		tag := tagBytes(4, 0,
+			frameBytes(4, "TIT2", []byte{3, 'T', 'i', 't', 'l', 'e', 0}),
+			frameBytes(4, "TPE1", []byte{3, 'A', 'r', 't', 0}),
+			make([]byte, padding),
+		)
+		vals, err := ReadID3(bytes.NewReader(tag))
+		if err != nil {
+			t.Fatalf("padding %d: Failed read: %v", padding, err)
+		}
+		if len(vals) != 2 {
+			t.Fatalf("padding %d: Expected 2 frames got %q", padding, vals)
+		}
+		for k := range vals {
+			if !validFrameID(k) {
+				t.Fatalf("padding %d: bad key %q", padding, k)
+			}
+		}
+	}
+}
+
+func TestJunkAfterFrames(t *testing.T) {
+	tag := tagBytes(3, 0,
+		frameBytes(3, "TIT2", []byte{3, 'T', 'i', 't', 'l', 'e', 0}),
+		[]byte("tit2\x00\x00\x00\x01\x00\x00x"),
+	)
+	vals, err := ReadID3(bytes.NewReader(tag))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	if len(vals) != 1 || vals["TIT2"] != "Title" {
+		t.Fatalf("Expected only TIT2 got %q", vals)
+	}
+}
+
+func TestTruncatedFrame(t *testing.T) {
+	tag := tagBytes(4, 0,
+		frameBytes(4, "TIT2", []byte{3, 'T', 'i', 't', 'l', 'e', 0}),
+		frameBytes(4, "TPE1", []byte{3, 'A', 'r', 't', 'i', 's', 't', 0}),
+	)
+	for _, cut := range []int{3, 8} {
+		vals, err := ReadID3(bytes.NewReader(tag[:len(tag)-cut]))
+		if !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Fatalf("Expected unexpected EOF got %v", err)
+		}
+		if !strings.Contains(err.Error(), "TPE1") {
+			t.Fatalf("Expected frame ID in error got %v", err)
+		}
+		if vals != nil {
+			t.Fatalf("Expected no values got %v", vals)
+		}
+	}
+}
+
+func TestEmptyFrames(t *testing.T) {
+	for _, version := range []byte{2, 3, 4} {
+		id := "TPE2"
+		if version == 2 {
+			id = "TP2"
+		}
+		for _, data := range [][]byte{{}, {0}, {1}, {2}, {3}, {0xff}} {
+			tag := tagBytes(version, 0, frameBytes(version, id, data))
+			vals, err := ReadID3(bytes.NewReader(tag))
+			if err != nil {
+				t.Fatalf("v2.%d %v: Failed read: %v", version, data, err)
+			}
+			if v, ok := vals["TPE2"]; ok != (len(data) > 0) || (len(data) > 0 && data[0] <= 3 && v != "") {
+				t.Fatalf("v2.%d %v: expected empty %s got %q", version, data, id, vals)
+			}
+		}
+	}
+}
+
+func TestEmptyTags(t *testing.T) {
+	for _, version := range []byte{2, 3, 4} {
+		title, artist := "TIT2", "TPE1"
+		if version == 2 {
+			title, artist = "TT2", "TP1"
+		}
+		for name, tag := range map[string][]byte{
+			"empty":   tagBytes(version, 0),
+			"padding": tagBytes(version, 0, make([]byte, 256)),
+		} {
+			vals, err := ReadID3(bytes.NewReader(tag))
+			if err != nil || len(vals) != 0 {
+				t.Errorf("v2.%d %s: Expected no values got %q %v", version, name, vals, err)
+			}
+			parsed, err := ReadTag(bytes.NewReader(tag))
+			if err != nil || len(parsed.frames) != 0 {
+				t.Errorf("v2.%d %s: Expected no frames got %v %v", version, name, parsed, err)
+			}
+		}
+
+		// a zero size frame is skipped, the ones after it are still read
+		tag := tagBytes(version, 0,
+			frameBytes(version, title, nil),
+			frameBytes(version, artist, []byte("\x00Artist")),
+			frameBytes(version, title, nil),
+			make([]byte, 16),
+		)
+		vals, err := ReadID3(bytes.NewReader(tag))
+		if err != nil || !reflect.DeepEqual(vals, map[string]string{"TPE1": "Artist"}) {
+			t.Errorf("v2.%d: Wrong values %q %v", version, vals, err)
+		}
+		_, err = ReadID3(bytes.NewReader(tag), WithStrict())
+		var se *SpecError
+		if !errors.As(err, &se) {
+			t.Errorf("v2.%d: Expected a spec error got %v", version, err)
+		}
+	}
+}
+
+// feed garbage and mangled tags in, none of it may panic
+func TestNoPanics(t *testing.T) {
+	fixtures := [][]byte{ivsID3, v22ID3}
+	for _, fixture := range fixtures {
+		for i := range fixture {
+			ReadID3(bytes.NewReader(fixture[:i]))
+		}
+	}
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 5000; i++ {
+		fixture := fixtures[i%len(fixtures)]
+		mangled := append([]byte{}, fixture...)
+		for j := 0; j < 1+rnd.Intn(8); j++ {
+			mangled[3+rnd.Intn(len(mangled)-3)] = byte(rnd.Intn(256))
+		}
+		ReadID3(bytes.NewReader(mangled))
+
+		junk := make([]byte, rnd.Intn(64))
+		rnd.Read(junk)
+		ReadID3(bytes.NewReader(append([]byte("ID3"), junk...)))
+	}
+}
+
+func TestFrameSizeLimits(t *testing.T) {
+	// claims far more than the tag holds
+	oversized := frameBytes(4, "APIC", []byte{0, 1, 2, 3})
+	copy(oversized[4:8], synsafeBytes(200<<20))
+	tag := tagBytes(4, 0, frameBytes(4, "TIT2", []byte{3, 'T', 0}), oversized)
+	_, err := ReadID3(bytes.NewReader(tag))
+	if err == nil || !strings.Contains(err.Error(), "remain in the tag") {
+		t.Fatalf("Expected size past the tag error got %v", err)
+	}
+
+	var fe *FrameError
+	if !errors.As(err, &fe) || fe.FrameID != "APIC" || fe.Offset != 23 {
+		t.Errorf("Wrong frame error %+v", fe)
+	}
+	// lenient keeps what's there of it
+	parsed, err := ReadTag(bytes.NewReader(tag), WithLenient())
+	if err == nil || parsed == nil || len(parsed.frames) != 2 {
+		t.Fatalf("Expected the truncated frame got %v %v", parsed, err)
+	}
+	if f := parsed.frames[1]; !f.Truncated() || f.Size != 4 || !bytes.Equal(f.Data, []byte{0, 1, 2, 3}) {
+		t.Errorf("Wrong truncated frame %+v", f)
+	}
+
+	tag = tagBytes(4, 0, frameBytes(4, "APIC", make([]byte, 2048)))
+	_, err = ReadID3(bytes.NewReader(tag), WithMaxFrameSize(1024))
+	if err == nil || !strings.Contains(err.Error(), "limit") {
+		t.Fatalf("Expected frame limit error got %v", err)
+	}
+	_, err = ReadID3(bytes.NewReader(tag), WithMaxFrameSize(2048))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+}
+
+func TestReadID3All(t *testing.T) {
+	tag := tagBytes(4, 0,
+		frameBytes(4, "TIT2", []byte{3, 'T', 'i', 't', 'l', 'e', 0}),
+		frameBytes(4, "TPE1", []byte{3, 'O', 'n', 'e', 0}),
+		frameBytes(4, "TPE1", []byte{3, 'T', 'w', 'o', 0}),
+		frameBytes(4, "TPE1", []byte{3, 'T', 'h', 'r', 'e', 'e', 0}),
+	)
+	vals, err := ReadID3All(bytes.NewReader(tag))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	artists := vals["TPE1"]
+	if len(artists) != 3 || artists[0] != "One" || artists[1] != "Two" || artists[2] != "Three" {
+		t.Fatalf("Wrong TPE1 values %q", artists)
+	}
+	if len(vals["TIT2"]) != 1 || vals["TIT2"][0] != "Title" {
+		t.Fatalf("Wrong TIT2 values %q", vals["TIT2"])
+	}
+
+	// the simple map keeps the last one
+	last, err := ReadID3(bytes.NewReader(tag))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	if last["TPE1"] != "Three" {
+		t.Fatalf("Wrong TPE1 value %q", last["TPE1"])
+	}
+}
+
+func TestReadID3Header(t *testing.T) {
+	tag := tagBytes(3, 0xa0, frameBytes(3, "TIT2", []byte("\x00Title")))
+	r := bytes.NewReader(append(tag, fakeAudio...))
+	header, err := ReadID3Header(r)
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	if header.VersionString() != "2.3.0" || header.Size != len(tag)-10 || header.TotalSize() != len(tag) {
+		t.Errorf("Wrong header %+v", header)
+	}
+	if !header.Unsynchronisation() || header.ExtendedHeader() || !header.Experimental() || header.HasFooter() {
+		t.Errorf("Wrong flags %x", header.Flags)
+	}
+	if r.Len() != len(tag)-10+len(fakeAudio) {
+		t.Errorf("Read past the header, %d left", r.Len())
+	}
+
+	if !HasID3(bytes.NewReader(tag)) {
+		t.Error("Expected a tag")
+	}
+	if HasID3(bytes.NewReader(fakeAudio)) || HasID3(bytes.NewReader([]byte("ID3"))) {
+		t.Error("Found a tag that isn't there")
+	}
+	if _, err := ReadID3Header(bytes.NewReader(fakeAudio)); !errors.Is(err, ErrNoTag) {
+		t.Errorf("Expected ErrNoTag got %v", err)
+	}
+	for _, short := range [][]byte{[]byte("ID3"), tag[:9]} {
+		if _, err := ReadID3Header(bytes.NewReader(short)); !errors.Is(err, ErrTruncated) || !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Errorf("%q: expected ErrTruncated got %v", short, err)
+		}
+	}
+	if _, err := ReadID3Header(bytes.NewReader(nil)); !errors.Is(err, ErrNoTag) {
+		t.Errorf("Expected ErrNoTag for nothing got %v", err)
+	}
+}
+
+func TestTagHeader(t *testing.T) {
+	tag := tagBytes(3, 0x20, frameBytes(3, "TIT2", []byte("\x00Title")))
+	parsed, err := ReadTag(bytes.NewReader(append(tag, fakeAudio...)))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	header := parsed.Header()
+	if header.VersionString() != "2.3.0" || header.TotalSize() != len(tag) || !header.Experimental() || header.Unsynchronisation() {
+		t.Errorf("Wrong header %+v", header)
+	}
+
+	appended := appendedTagBytes(frameBytes(4, "TIT2", []byte("\x03Title\x00")))
+	file := append(append([]byte{}, fakeAudio...), appended...)
+	parsed, err = ReadAppendedTagAt(bytes.NewReader(file), int64(len(file)))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	if header := parsed.Header(); header.ID3 != "ID3" || !header.HasFooter() || header.TotalSize() != len(appended) {
+		t.Errorf("Wrong appended header %+v", header)
+	}
+	if header := NewTag().Header(); header.VersionString() != "2.4.0" {
+		t.Errorf("Wrong new tag header %+v", header)
+	}
+}
+
+// typicalTag is the 20 or so frames a ripped and tagged album track has
+func typicalTag() []byte {
+	text := func(id, value string) []byte {
+		return frameBytes(4, id, append([]byte{3}, value...))
+	}
+	return tagBytes(4, 0,
+		text("TIT2", "Aquarium"),
+		text("TPE1", "Fishmans"),
+		text("TALB", "Long Season"),
+		text("TPE2", "Fishmans"),
+		text("TCOM", "Shinji Sato"),
+		text("TRCK", "3/12"),
+		text("TPOS", "1/1"),
+		text("TDRC", "1996-10-25"),
+		text("TCON", "Dream Pop"),
+		text("TLEN", "215000"),
+		text("TBPM", "92"),
+		text("TSRC", "JPPO09600123"),
+		text("TPUB", "Polydor"),
+		text("TSSE", "LAME 3.100"),
+		frameBytes(4, "TXXX", []byte("\x03MusicBrainz Album Id\x008b0b6aa1-5d2f-4f7c-9d1b-6b3c2a0f1e11")),
+		frameBytes(4, "TXXX", []byte("\x03REPLAYGAIN_TRACK_GAIN\x00-6.20 dB")),
+		frameBytes(4, "TXXX", []byte("\x03REPLAYGAIN_TRACK_PEAK\x000.988")),
+		frameBytes(4, "COMM", []byte("\x03eng\x00Ripped from the 2005 reissue")),
+		frameBytes(4, "USLT", []byte("\x03jpn\x00Instrumental")),
+		frameBytes(4, "APIC", append([]byte("\x00image/jpeg\x00\x03\x00"), make([]byte, 2048)...)),
+		make([]byte, 1024),
+	)
+}
+
+// TestReadAllocs keeps the allocations the benchmarks below measure from
+// creeping back up. go test -bench 'ReadID3$|ReadTag$|WalkFrames' -benchmem
+// gives 76, 34 and 32 allocs/op, they were 172, 107 and 97 before the frame
+// loop was made to share its buffers.
+func TestReadAllocs(t *testing.T) {
+	tag := typicalTag()
+	for name, read := range map[string]func() error{
+		"ReadID3": func() error {
+			_, err := ReadID3(bytes.NewReader(tag))
+			return err
+		},
+		"ReadTag": func() error {
+			_, err := ReadTag(bytes.NewReader(tag))
+			return err
+		},
+	} {
+		var err error
+		allocs := testing.AllocsPerRun(100, func() {
+			err = read()
+		})
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if limit := map[string]float64{"ReadID3": 80, "ReadTag": 40}[name]; allocs > limit {
+			t.Errorf("%s: %v allocs, expected at most %v", name, allocs, limit)
+		}
+	}
+}
+
+func BenchmarkReadID3(b *testing.B) {
+	tag := typicalTag()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, err := ReadID3(bytes.NewReader(tag))
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkReadTag(b *testing.B) {
+	tag := typicalTag()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, err := ReadTag(bytes.NewReader(tag))
+		if err != nil {
+			b.Fatal(err)
+		}
 	}
 }
@@ -0,0 +1,93 @@
+package easyid3
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"strings"
+)
+
+// iffScanner walks the chunks of a RIFF or IFF file, WAV has little endian
+// sizes and AIFF big endian
+type iffScanner struct {
+	r     io.ReadSeeker
+	order binary.ByteOrder
+	// end is where the file ends, a chunk that goes past it was cut short
+	end int64
+}
+
+// newIFFScanner checks r starts with the container chunk with one of forms
+// as its type and leaves r at its first chunk
+func newIFFScanner(r io.ReadSeeker, order binary.ByteOrder, container string, forms ...string) (*iffScanner, error) {
+	start, err := r.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil, err
+	}
+	end, err := r.Seek(0, io.SeekEnd)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := r.Seek(start, io.SeekStart); err != nil {
+		return nil, err
+	}
+	head := make([]byte, 12)
+	_, err = io.ReadFull(r, head)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, err
+	}
+	if err != nil || string(head[:4]) != container || !oneOf(string(head[8:]), forms) {
+		return nil, notFoundError(strings.Join(forms, " or ") + " header")
+	}
+	return &iffScanner{r: r, order: order, end: end}, nil
+}
+
+// find walks to the first chunk with one of ids and leaves r at the start
+// of its data, size is how much data it has. Chunks with an odd size are
+// followed by a padding byte.
+func (s *iffScanner) find(ids ...string) (size int64, err error) {
+	head := make([]byte, 8)
+	for {
+		_, err := io.ReadFull(s.r, head)
+		if errors.Is(err, io.EOF) {
+			return 0, notFoundError("ID3 chunk")
+		}
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			return 0, truncated("chunk header")
+		}
+		if err != nil {
+			return 0, err
+		}
+		size := int64(s.order.Uint32(head[4:]))
+		pos, err := s.r.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return 0, err
+		}
+		if pos+size > s.end {
+			return 0, truncated("%q chunk of %d bytes with %d left in the file", head[:4], size, s.end-pos)
+		}
+		if oneOf(string(head[:4]), ids) {
+			return size, nil
+		}
+		if _, err := s.r.Seek(size+size&1, io.SeekCurrent); err != nil {
+			return 0, err
+		}
+	}
+}
+
+// readID3Chunk is ReadID3 for the tag in the first of the ids chunks
+func readID3Chunk(s *iffScanner, opts []Option, ids ...string) (map[string]string, error) {
+	size, err := s.find(ids...)
+	if err != nil {
+		return nil, err
+	}
+	return ReadID3(io.LimitReader(s.r, size), opts...)
+}
+
+func oneOf(s string, list []string) bool {
+	for _, v := range list {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,63 @@
+package easyid3
+
+import "strings"
+
+// Frames iTunes writes that aren't in either spec
+const (
+	FrameCompilation          = "TCMP"
+	FrameAlbumArtistSortOrder = "TSO2"
+	FrameComposerSortOrder    = "TSOC"
+)
+
+// xsoFrameIDs are the sort frames v2.3 taggers like Picard write, TSOA,
+// TSOP and TSOT only arrived in v2.4
+var xsoFrameIDs = map[string]string{
+	"XSOA": FrameAlbumSortOrder,
+	"XSOP": FramePerformerSortOrder,
+	"XSOT": FrameTitleSortOrder,
+}
+
+// renameSortFrames swaps the v2.3 XSO frames for the v2.4 ones
+func renameSortFrames(frames []*Frame) {
+	for _, f := range frames {
+		if id, ok := xsoFrameIDs[f.FrameID]; ok {
+			f.FrameID = id
+		}
+	}
+}
+
+// iTunesFrames is the frames the way iTunes wants them for
+// WithITunesCompatible, the XSO sort frames get the IDs iTunes reads and
+// TCMP is "1" or "0". The tag's own frames aren't changed.
+func iTunesFrames(frames []*Frame) []*Frame {
+	out := make([]*Frame, 0, len(frames))
+	for _, f := range frames {
+		if id, ok := xsoFrameIDs[f.FrameID]; ok {
+			renamed := *f
+			renamed.FrameID = id
+			f = &renamed
+		}
+		if f.FrameID == FrameCompilation {
+			value := "0"
+			if compilation(f) {
+				value = "1"
+			}
+			if f.Decoded() != value {
+				f = newFrame(FrameCompilation, utf8Terminated(value))
+			}
+		}
+		out = append(out, f)
+	}
+	return out
+}
+
+// compilation is whether a TCMP frame says yes, iTunes writes "1" but some
+// taggers write "true"
+func compilation(f *Frame) bool {
+	s := strings.TrimSpace(f.Decoded())
+	if strings.EqualFold(s, "true") {
+		return true
+	}
+	n, ok := leadingNumber(s)
+	return ok && n != 0
+}
@@ -0,0 +1,97 @@
+package easyid3
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestSortFrames(t *testing.T) {
+	text := func(s string) []byte { return []byte("\x00" + s + "\x00") }
+	v22 := tagBytes(2, 0,
+		frameBytes(2, "TCP", text("1")),
+		frameBytes(2, "TST", text("Title, The")),
+		frameBytes(2, "TSP", text("Artist, The")),
+		frameBytes(2, "TSA", text("Album, The")),
+		frameBytes(2, "TS2", text("Various")),
+		frameBytes(2, "TSC", text("Bach, J.S.")),
+	)
+	v23 := tagBytes(3, 0,
+		frameBytes(3, "TCMP", text("true")),
+		frameBytes(3, "XSOT", text("Title, The")),
+		frameBytes(3, "XSOP", text("Artist, The")),
+		frameBytes(3, "XSOA", text("Album, The")),
+		frameBytes(3, "TSO2", text("Various")),
+		frameBytes(3, "TSOC", text("Bach, J.S.")),
+	)
+	for _, tag := range [][]byte{v22, v23} {
+		for _, opts := range [][]Option{nil, {WithOriginalFrameIDs()}} {
+			parsed, err := ReadTag(bytes.NewReader(tag), opts...)
+			if err != nil {
+				t.Fatalf("Failed read: %v", err)
+			}
+			if !parsed.Compilation() {
+				t.Error("Expected a compilation")
+			}
+			got := []string{parsed.TitleSort(), parsed.ArtistSort(), parsed.AlbumSort(), parsed.AlbumArtistSort(), parsed.ComposerSort()}
+			want := []string{"Title, The", "Artist, The", "Album, The", "Various", "Bach, J.S."}
+			for i := range want {
+				if got[i] != want[i] {
+					t.Errorf("Wrong sort frames got %q want %q", got, want)
+					break
+				}
+			}
+		}
+	}
+	parsed, err := ReadTag(bytes.NewReader(v23))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	if len(parsed.find("XSOA")) != 0 || len(parsed.find("TSOA")) != 1 {
+		t.Errorf("XSOA should be renamed got %v", parsed.frames)
+	}
+}
+
+func TestITunesCompatible(t *testing.T) {
+	tag := tagBytes(3, 0,
+		frameBytes(3, "TCMP", []byte("\x00true\x00")),
+		frameBytes(3, "XSOA", []byte("\x00Album, The\x00")),
+		frameBytes(3, "TIT2", []byte("\x00Title\x00")),
+	)
+	parsed, err := ReadTag(bytes.NewReader(tag), WithOriginalFrameIDs())
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	// not what iTunes wants but leave it alone unless asked
+	read := rewrite(t, parsed)
+	if len(read.find("XSOA")) != 1 || read.text("TCMP") != "true" {
+		t.Errorf("Frames changed without WithITunesCompatible %v", read.frames)
+	}
+
+	var buf bytes.Buffer
+	if _, err := WriteTag(&buf, parsed, WithITunesCompatible()); err != nil {
+		t.Fatalf("Failed write: %v", err)
+	}
+	read, err = ReadTag(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	if ids := []string{read.frames[0].FrameID, read.frames[1].FrameID, read.frames[2].FrameID}; ids[0] != "TCMP" || ids[1] != "TSOA" || ids[2] != "TIT2" {
+		t.Errorf("Wrong frames %v", ids)
+	}
+	if read.text("TCMP") != "1" || read.AlbumSort() != "Album, The" || read.Title() != "Title" {
+		t.Errorf("Wrong frames %v", read.frames)
+	}
+	if len(parsed.find("XSOA")) != 1 {
+		t.Error("Writing shouldn't change the tag")
+	}
+
+	edit := NewTag()
+	edit.SetCompilation(true)
+	if !rewrite(t, edit).Compilation() || edit.text("TCMP") != "1" {
+		t.Errorf("Wrong TCMP %q", edit.text("TCMP"))
+	}
+	edit.SetCompilation(false)
+	if edit.Compilation() || len(edit.frames) != 0 {
+		t.Errorf("Expected TCMP removed got %v", edit.frames)
+	}
+}
@@ -0,0 +1,133 @@
+package easyid3
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+)
+
+// tagJSON is the schema MarshalJSON writes and UnmarshalJSON reads, it
+// only changes by adding fields
+type tagJSON struct {
+	Title    string            `json:"title,omitempty"`
+	Artist   string            `json:"artist,omitempty"`
+	Album    string            `json:"album,omitempty"`
+	Track    string            `json:"track,omitempty"`
+	Disc     string            `json:"disc,omitempty"`
+	Date     string            `json:"date,omitempty"`
+	Genre    []string          `json:"genre,omitempty"`
+	User     map[string]string `json:"user,omitempty"`
+	Comments []commentJSON     `json:"comments,omitempty"`
+	Pictures []pictureJSON     `json:"pictures,omitempty"`
+}
+
+type commentJSON struct {
+	Language    Language `json:"lang"`
+	Description string   `json:"description"`
+	Text        string   `json:"text"`
+}
+
+// pictureJSON has the image itself only with WithJSONPictureData, Size is
+// always there
+type pictureJSON struct {
+	Type        byte   `json:"type"`
+	MIMEType    string `json:"mime"`
+	Description string `json:"description,omitempty"`
+	Size        int    `json:"size"`
+	Data        []byte `json:"data,omitempty"`
+}
+
+// MarshalJSON writes the common fields by name, the TXXX values under user
+// keyed by description, the comments and a summary of each picture. Track
+// and disc are the TRCK and TPOS text like "3/12" and genre has the names
+// of every TCON genre.
+func (t *Tag) MarshalJSON() ([]byte, error) {
+	j := tagJSON{
+		Title:  t.Title(),
+		Artist: t.Artist(),
+		Album:  t.Album(),
+		Track:  t.text("TRCK", "TRK"),
+		Disc:   t.text("TPOS", "TPA"),
+		Genre:  t.Genres(),
+	}
+	if ts, ok, _ := t.Date(); ok {
+		j.Date = ts.String()
+	} else {
+		// keep whatever it is rather than nothing
+		j.Date = t.text("TDRC")
+	}
+	for _, f := range t.find("TXXX", "TXX") {
+		desc, _ := parseUserText(f.Data)
+		if _, ok := j.User[desc]; ok {
+			continue
+		}
+		if j.User == nil {
+			j.User = map[string]string{}
+		}
+		j.User[desc] = f.Decoded()
+	}
+	for _, c := range t.Comments() {
+		j.Comments = append(j.Comments, commentJSON{c.Language, c.Description, c.Text})
+	}
+	for _, p := range t.Pictures() {
+		pj := pictureJSON{Type: p.PictureType, MIMEType: p.MIMEType, Description: p.Description, Size: len(p.Data)}
+		if t.options().jsonPictureData {
+			pj.Data = p.Data
+		}
+		j.Pictures = append(j.Pictures, pj)
+	}
+	return json.Marshal(j)
+}
+
+// UnmarshalJSON replaces the tag with a new v2.4 tag that has what the JSON
+// MarshalJSON writes does. Pictures without their data are left out since
+// there's nothing to write.
+func (t *Tag) UnmarshalJSON(b []byte) error {
+	var j tagJSON
+	if err := json.Unmarshal(b, &j); err != nil {
+		return err
+	}
+	nt := NewTag()
+	for _, field := range []struct{ id, value string }{
+		{"TIT2", j.Title},
+		{"TPE1", j.Artist},
+		{"TALB", j.Album},
+		{"TRCK", j.Track},
+		{"TPOS", j.Disc},
+		{"TDRC", j.Date},
+		{"TCON", strings.Join(j.Genre, "\x00")},
+	} {
+		if field.value == "" {
+			continue
+		}
+		if err := nt.SetText(field.id, field.value); err != nil {
+			return err
+		}
+	}
+	descs := make([]string, 0, len(j.User))
+	for desc := range j.User {
+		descs = append(descs, desc)
+	}
+	sort.Strings(descs)
+	for _, desc := range descs {
+		if err := nt.SetText("TXXX:"+desc, j.User[desc]); err != nil {
+			return err
+		}
+	}
+	for _, c := range j.Comments {
+		if err := nt.SetComment(string(c.Language), c.Description, c.Text); err != nil {
+			return err
+		}
+	}
+	for _, p := range j.Pictures {
+		if len(p.Data) == 0 {
+			continue
+		}
+		err := nt.SetPicture(Picture{MIMEType: p.MIMEType, PictureType: p.Type, Description: p.Description, Data: p.Data})
+		if err != nil {
+			return err
+		}
+	}
+	*t = *nt
+	return nil
+}
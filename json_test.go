@@ -0,0 +1,100 @@
+package easyid3
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func jsonTag(t *testing.T) *Tag {
+	t.Helper()
+	tag := NewTag()
+	for id, value := range map[string]string{
+		"TIT2":                      "Title",
+		"TPE1":                      "Artist",
+		"TALB":                      "Album",
+		"TRCK":                      "3/12",
+		"TPOS":                      "1/2",
+		"TDRC":                      "2021-08-31",
+		"TCON":                      "(17)Shoegaze",
+		"TXXX:MusicBrainz Album Id": "8b0b6aa1-5d2f-4f7c-9d1b-6b3c2a0f1e11",
+	} {
+		if err := tag.SetText(id, value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tag.SetComment("eng", "", "Nice one"); err != nil {
+		t.Fatal(err)
+	}
+	if err := tag.SetPicture(Picture{MIMEType: "image/png", PictureType: PictureTypeFrontCover, Description: "Cover", Data: []byte("\x89PNG data")}); err != nil {
+		t.Fatal(err)
+	}
+	return tag
+}
+
+func TestMarshalJSON(t *testing.T) {
+	b, err := json.Marshal(jsonTag(t))
+	if err != nil {
+		t.Fatalf("Failed marshal: %v", err)
+	}
+	want := `{"title":"Title","artist":"Artist","album":"Album","track":"3/12","disc":"1/2","date":"2021-08-31",` +
+		`"genre":["Rock","Shoegaze"],"user":{"MusicBrainz Album Id":"8b0b6aa1-5d2f-4f7c-9d1b-6b3c2a0f1e11"},` +
+		`"comments":[{"lang":"eng","description":"","text":"Nice one"}],` +
+		`"pictures":[{"type":3,"mime":"image/png","description":"Cover","size":9}]}`
+	if string(b) != want {
+		t.Errorf("Wrong JSON\ngot  %s\nwant %s", b, want)
+	}
+
+	b, err = json.Marshal(NewTag())
+	if err != nil || string(b) != "{}" {
+		t.Errorf("Wrong empty JSON %s %v", b, err)
+	}
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	if _, err := WriteTag(&buf, jsonTag(t)); err != nil {
+		t.Fatalf("Failed write: %v", err)
+	}
+	parsed, err := ReadTag(bytes.NewReader(buf.Bytes()), WithJSONPictureData())
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	b, err := json.Marshal(parsed)
+	if err != nil {
+		t.Fatalf("Failed marshal: %v", err)
+	}
+	if !strings.Contains(string(b), `"data":"iVBORyBkYXRh"`) {
+		t.Errorf("Expected the picture data got %s", b)
+	}
+
+	var back Tag
+	if err := json.Unmarshal(b, &back); err != nil {
+		t.Fatalf("Failed unmarshal: %v", err)
+	}
+	// the picture data only comes back out with the option
+	again, err := json.Marshal(&back)
+	if err != nil || string(again) != strings.Replace(string(b), `,"data":"iVBORyBkYXRh"`, "", 1) {
+		t.Errorf("Wrong round trip\ngot  %s\nwant %s", again, b)
+	}
+	if g := back.Genres(); !reflect.DeepEqual(g, []string{"Rock", "Shoegaze"}) {
+		t.Errorf("Wrong genres %q", g)
+	}
+	if p := back.Pictures(); len(p) != 1 || string(p[0].Data) != "\x89PNG data" {
+		t.Errorf("Wrong pictures %+v", p)
+	}
+	buf.Reset()
+	if _, err := WriteTag(&buf, &back); err != nil {
+		t.Errorf("Failed writing the unmarshalled tag: %v", err)
+	}
+
+	// a picture summary has nothing to write
+	if err := json.Unmarshal([]byte(`{"title":"T","pictures":[{"type":3,"mime":"image/png","size":9}]}`), &back); err != nil {
+		t.Fatalf("Failed unmarshal: %v", err)
+	}
+	if back.Title() != "T" || len(back.Pictures()) != 0 || back.Artist() != "" {
+		t.Errorf("Wrong tag %v", back.frames)
+	}
+}
@@ -0,0 +1,74 @@
+package easyid3
+
+// friendlyKeys are the names WithFriendlyKeys uses for the v2.4 frame IDs.
+// The v2.2 and iTunes IDs get the name of the frame they turn into, and
+// the v2.3 years the name of the v2.4 date that replaced them.
+var friendlyKeys = map[string]string{
+	"TIT1": "grouping",
+	"TIT2": "title",
+	"TIT3": "subtitle",
+	"TPE1": "artist",
+	"TPE2": "album_artist",
+	"TPE3": "conductor",
+	"TPE4": "remixer",
+	"TALB": "album",
+	"TCOM": "composer",
+	"TEXT": "lyricist",
+	"TRCK": "track",
+	"TPOS": "disc",
+	"TDRC": "date",
+	"TDRL": "release_date",
+	"TDOR": "original_date",
+	"TYER": "date",
+	"TORY": "original_date",
+	"TCON": "genre",
+	"TBPM": "bpm",
+	"TKEY": "initial_key",
+	"TLEN": "length",
+	"TMOO": "mood",
+	"TLAN": "language",
+	"TCOP": "copyright",
+	"TPUB": "publisher",
+	"TSRC": "isrc",
+	"TENC": "encoded_by",
+	"TSSE": "encoder",
+	"TCMP": "compilation",
+	"TSOT": "title_sort",
+	"TSOP": "artist_sort",
+	"TSOA": "album_sort",
+	"TSO2": "album_artist_sort",
+	"TSOC": "composer_sort",
+	"WOAR": "artist_url",
+	"WOAS": "source_url",
+}
+
+// key is what the frame goes under in the ReadID3 map. With
+// WithFriendlyKeys the comment and lyrics without a description are
+// comment and lyrics, the ones with one keep their COMM and USLT keys.
+func (o *options) key(f *Frame) string {
+	if !o.friendlyKeys {
+		return f.Key()
+	}
+	id := f.FrameID
+	if v24, ok := v22FrameIDs[id]; ok {
+		id = v24
+	}
+	if v24, ok := xsoFrameIDs[id]; ok {
+		id = v24
+	}
+	switch id {
+	case "COMM":
+		if parseComment(f.Data).Description == "" {
+			return "comment"
+		}
+	case "USLT":
+		if parseLyrics(f.Data).Descriptor == "" {
+			return "lyrics"
+		}
+	default:
+		if name, ok := friendlyKeys[id]; ok {
+			return name
+		}
+	}
+	return f.Key()
+}
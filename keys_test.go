@@ -0,0 +1,63 @@
+package easyid3
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestWithFriendlyKeys(t *testing.T) {
+	tag := tagBytes(4, 0,
+		frameBytes(4, "TIT2", []byte("\x03Title")),
+		frameBytes(4, "TPE2", []byte("\x03Album Artist")),
+		frameBytes(4, "TDRC", []byte("\x032021")),
+		frameBytes(4, "COMM", []byte("\x03eng\x00Nice")),
+		frameBytes(4, "COMM", []byte("\x03engiTunNORM\x00 0000")),
+		frameBytes(4, "TXXX", []byte("\x03CATALOGNUMBER\x00CAT-1")),
+		frameBytes(4, "TOWN", []byte("\x03Owner")),
+	)
+	vals, err := ReadID3(bytes.NewReader(tag), WithFriendlyKeys())
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	want := map[string]string{
+		"title":              "Title",
+		"album_artist":       "Album Artist",
+		"date":               "2021",
+		"comment":            "Nice",
+		"COMM:eng:iTunNORM":  " 0000",
+		"TXXX:CATALOGNUMBER": "CAT-1",
+		"TOWN":               "Owner",
+	}
+	if !reflect.DeepEqual(vals, want) {
+		t.Errorf("Wrong values\ngot  %q\nwant %q", vals, want)
+	}
+	all, err := ReadID3All(bytes.NewReader(tag), WithFriendlyKeys())
+	if err != nil || !reflect.DeepEqual(all["title"], []string{"Title"}) {
+		t.Errorf("Wrong values %q %v", all, err)
+	}
+
+	// the v2.2 IDs get the same names whether or not they're turned into
+	// v2.4 ones
+	want = map[string]string{"title": "Long Season", "artist": "Fishmans", "album": "Seasons", "track": "1/1"}
+	for _, opts := range [][]Option{{WithFriendlyKeys()}, {WithFriendlyKeys(), WithOriginalFrameIDs()}} {
+		vals, err := ReadID3(bytes.NewReader(v22ID3), opts...)
+		if err != nil || !reflect.DeepEqual(vals, want) {
+			t.Errorf("Wrong v2.2 values %q %v", vals, err)
+		}
+	}
+	// the v2.3 and v2.2 years are the same key as the v2.4 date
+	for _, tag := range [][]byte{
+		tagBytes(3, 0, frameBytes(3, "TYER", []byte("\x001987")), frameBytes(3, "TORY", []byte("\x001979"))),
+		tagBytes(2, 0, frameBytes(2, "TYE", []byte("\x001987")), frameBytes(2, "TOR", []byte("\x001979"))),
+	} {
+		vals, err := ReadID3(bytes.NewReader(tag), WithFriendlyKeys())
+		want := map[string]string{"date": "1987", "original_date": "1979"}
+		if err != nil || !reflect.DeepEqual(vals, want) {
+			t.Errorf("Wrong year values %q %v", vals, err)
+		}
+	}
+	if vals, _ := ReadID3(bytes.NewReader(v22ID3)); vals["TIT2"] != "Long Season" {
+		t.Errorf("Expected frame IDs without the option got %q", vals)
+	}
+}
@@ -0,0 +1,47 @@
+package easyid3
+
+import "strings"
+
+// Language is the lower case ISO-639-2 code COMM, USLT and SYLT frames
+// have
+type Language string
+
+// LanguageUndetermined is what a frame with no language, or the "XXX" and
+// nulls taggers write instead of one, comes back as
+const LanguageUndetermined Language = "und"
+
+// parseLanguage normalizes the 3 language bytes of a frame
+func parseLanguage(b []byte) Language {
+	lang := strings.ToLower(string(b))
+	if !validLanguage(lang) || lang == "xxx" {
+		return LanguageUndetermined
+	}
+	return Language(lang)
+}
+
+// validLanguage is whether s is 3 ASCII letters
+func validLanguage(s string) bool {
+	if len(s) != 3 {
+		return false
+	}
+	for i := 0; i < 3; i++ {
+		c := s[i] | 0x20
+		if c < 'a' || c > 'z' {
+			return false
+		}
+	}
+	return true
+}
+
+// inLanguages is whether lang is one of langs, no langs matches everything
+func inLanguages(lang Language, langs []Language) bool {
+	if len(langs) == 0 {
+		return true
+	}
+	for _, l := range langs {
+		if Language(strings.ToLower(string(l))) == lang {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,215 @@
+package easyid3
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// LazyFrame is a frame WithLazyFrames left in the file. Offset is where
+// its header is in the io.ReadSeeker the tag was read from and Size is the
+// size the header declares.
+type LazyFrame struct {
+	FrameID string
+	Size    int
+	Offset  int64
+
+	rs io.ReadSeeker
+	// frame is the header as it was read, for undoing the format flags
+	frame     Frame
+	rawID     string
+	tagUnsync bool
+	normalize bool
+	raw       bool
+	// maxSize is WithMaxFrameSize's limit
+	maxSize int
+}
+
+// Load seeks back to the frame and reads it, the data is what Frame.Data
+// would have been if it was read with the rest of the tag. It moves the
+// position of the io.ReadSeeker. A frame that's no longer there because the
+// file changed is an error, so is one bigger than WithMaxFrameSize allows.
+func (lf *LazyFrame) Load() ([]byte, error) {
+	if lf.Size > lf.maxSize {
+		return nil, lf.frame.errorf("declares %d bytes, more than the %d byte limit", lf.Size, lf.maxSize)
+	}
+	end, err := lf.rs.Seek(0, io.SeekEnd)
+	if err != nil {
+		return nil, lf.frame.errorf("seeking to load it: %w", err)
+	}
+	size := int64(frameHeaderSize(lf.frame.Version) + lf.Size)
+	// don't allocate for a file that's been cut short since
+	if lf.Offset+size > end {
+		return nil, lf.frame.wrapError(truncated("loading, expected %d bytes the file has %d", size, end-lf.Offset))
+	}
+	if _, err := lf.rs.Seek(lf.Offset, io.SeekStart); err != nil {
+		return nil, lf.frame.errorf("seeking to load it: %w", err)
+	}
+	raw := make([]byte, size)
+	n, err := io.ReadFull(lf.rs, raw)
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, lf.frame.wrapError(truncated("loading, expected %d bytes read %d", len(raw), n))
+	}
+	if err != nil {
+		return nil, lf.frame.errorf("loading: %w", err)
+	}
+	if string(raw[:len(lf.rawID)]) != lf.rawID {
+		return nil, lf.frame.errorf("isn't at offset %d any more, found %q", lf.Offset, raw[:len(lf.rawID)])
+	}
+	f := lf.frame
+	f.Data = raw[frameHeaderSize(f.Version):]
+	if !lf.raw {
+		if err := f.unformat(lf.tagUnsync, lf.maxSize); err != nil {
+			return nil, err
+		}
+	}
+	if lf.normalize && f.FrameID == "APIC" {
+		f.Data = picToAPIC(f.Data)
+	}
+	return f.Data, nil
+}
+
+// String is the ID, size and offset
+func (lf *LazyFrame) String() string {
+	return fmt.Sprintf("%s: %d bytes at %d", lf.FrameID, lf.Size, lf.Offset)
+}
+
+// LazyFrames are the frames WithLazyFrames left to Load, in the order they
+// are in the tag
+func (t *Tag) LazyFrames() []*LazyFrame {
+	return t.header.lazy
+}
+
+// seekReader reads an io.ReadSeeker through a small buffer keeping track of
+// where it is, so frames can be skipped with a seek instead of being read
+type seekReader struct {
+	rs io.ReadSeeker
+	// pos is where the next byte read is from, buf starts there
+	pos int64
+	buf []byte
+	mem []byte
+}
+
+func newSeekReader(rs io.ReadSeeker) (*seekReader, error) {
+	pos, err := rs.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil, err
+	}
+	return &seekReader{rs: rs, pos: pos, mem: make([]byte, 512)}, nil
+}
+
+func (r *seekReader) Read(p []byte) (int, error) {
+	if len(r.buf) == 0 {
+		if len(p) >= len(r.mem) {
+			n, err := r.rs.Read(p)
+			r.pos += int64(n)
+			return n, err
+		}
+		n, err := r.rs.Read(r.mem)
+		r.buf = r.mem[:n]
+		if n == 0 {
+			return 0, err
+		}
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	r.pos += int64(n)
+	return n, nil
+}
+
+// seek moves to pos, within what's buffered it doesn't need to
+func (r *seekReader) seek(pos int64) error {
+	if pos >= r.pos && pos <= r.pos+int64(len(r.buf)) {
+		r.buf = r.buf[pos-r.pos:]
+		r.pos = pos
+		return nil
+	}
+	r.buf = nil
+	_, err := r.rs.Seek(pos, io.SeekStart)
+	if err != nil {
+		return err
+	}
+	r.pos = pos
+	return nil
+}
+
+// peekAt is n bytes starting off bytes ahead without moving, nil when
+// there aren't that many
+func (r *seekReader) peekAt(off int64, n int) ([]byte, error) {
+	start := r.pos
+	if err := r.seek(start + off); err != nil {
+		return nil, err
+	}
+	b := make([]byte, n)
+	_, err := io.ReadFull(r, b)
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		b, err = nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return b, r.seek(start)
+}
+
+// readLazyTag is readOneTag for WithLazyFrames. It reads rs directly so the
+// big frames can be seeked past.
+func readLazyTag(rs io.ReadSeeker, o *options) (*Header, []*Frame, error) {
+	sr, err := newSeekReader(rs)
+	if err != nil {
+		return nil, nil, err
+	}
+	buf := make([]byte, 10)
+	n, err := io.ReadFull(sr, buf)
+	if n < 3 || string(buf[:3]) != "ID3" {
+		return nil, nil, notFoundError("ID3 header")
+	}
+	if err != nil {
+		return nil, nil, truncated("ID3 header")
+	}
+	header, err := newID3(buf)
+	if err != nil {
+		return nil, nil, err
+	}
+	frames, err := readBody(sr, header, o)
+	if err != nil {
+		return nil, nil, err
+	}
+	if header.HasFooter() {
+		if err := sr.seek(sr.pos + 10); err != nil {
+			return nil, nil, err
+		}
+	}
+	// leave rs right after the tag
+	if _, err := rs.Seek(sr.pos, io.SeekStart); err != nil {
+		return nil, nil, err
+	}
+	return header, frames, nil
+}
+
+// leaveLazy skips over the data of the frame whose header was just read and
+// keeps it on the header to Load later. ok is false when body can't skip.
+func leaveLazy(body *io.LimitedReader, header *Header, frame *Frame, rawID string, o *options) (ok bool, err error) {
+	sr, isSeek := body.R.(*seekReader)
+	if !isSeek {
+		return false, nil
+	}
+	hs := frameHeaderSize(frame.Version)
+	lf := &LazyFrame{
+		FrameID:   frame.FrameID,
+		Size:      frame.Size,
+		Offset:    sr.pos - int64(hs),
+		rs:        sr.rs,
+		frame:     *frame,
+		rawID:     rawID,
+		tagUnsync: frame.Version == 4 && header.Unsynchronisation(),
+		normalize: frame.Version == 2 && !o.raw && !o.originalIDs,
+		raw:       o.raw,
+		maxSize:   o.maxFrameSize,
+	}
+	if err := sr.seek(sr.pos + int64(frame.Size)); err != nil {
+		return false, err
+	}
+	body.N -= int64(frame.Size)
+	header.lazy = append(header.lazy, lf)
+	return true, nil
+}
@@ -0,0 +1,160 @@
+package easyid3
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"reflect"
+	"runtime"
+	"testing"
+)
+
+// countingSeeker counts what's read through it
+type countingSeeker struct {
+	*bytes.Reader
+	read int
+}
+
+func (r *countingSeeker) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	r.read += n
+	return n, err
+}
+
+func lazyTag() []byte {
+	art := bytes.Repeat([]byte{0xaa}, 64<<10)
+	return tagBytes(4, 0,
+		frameBytes(4, "TIT2", []byte("\x03Title")),
+		frameBytes(4, "APIC", apicData(0, "image/jpeg", PictureTypeFrontCover, []byte("\x00"), art)),
+		frameBytes(4, "GEOB", geobData(0, "application/octet-stream", []byte{0}, []byte("Blob\x00"), art)),
+		frameBytes(4, "TPE1", []byte("\x03Artist")),
+		make([]byte, 64),
+	)
+}
+
+func TestWithLazyFrames(t *testing.T) {
+	tag := lazyTag()
+	file := append(append([]byte{}, tag...), 0xff, 0xfb, 0x90, 0x64)
+	eager, err := ReadTag(bytes.NewReader(file))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+
+	rs := &countingSeeker{Reader: bytes.NewReader(file)}
+	parsed, err := ReadTag(rs, WithLazyFrames(1024))
+	if err != nil {
+		t.Fatalf("Failed lazy read: %v", err)
+	}
+	if rs.read > 4096 {
+		t.Errorf("Expected the big frames to be seeked past, read %d bytes", rs.read)
+	}
+	if pos, _ := rs.Seek(0, io.SeekCurrent); pos != int64(len(tag)) {
+		t.Errorf("Expected to be after the tag got %d", pos)
+	}
+	if parsed.Title() != "Title" || parsed.Artist() != "Artist" || len(parsed.frames) != 2 {
+		t.Errorf("Wrong frames %v", parsed.frames)
+	}
+	if !reflect.DeepEqual(parsed.frames, []*Frame{eager.frames[0], eager.frames[3]}) {
+		t.Errorf("Small frames differ from an eager read\ngot  %+v\nwant %+v", parsed.frames, eager.frames)
+	}
+	lazy := parsed.LazyFrames()
+	if len(lazy) != 2 || lazy[0].FrameID != "APIC" || lazy[1].FrameID != "GEOB" {
+		t.Fatalf("Wrong lazy frames %v", lazy)
+	}
+	if lazy[0].Offset != eager.frames[1].offset || lazy[0].Size != eager.frames[1].Size {
+		t.Errorf("Wrong offset and size %v", lazy[0])
+	}
+	for i, lf := range lazy {
+		data, err := lf.Load()
+		if err != nil || !bytes.Equal(data, eager.frames[i+1].Data) {
+			t.Errorf("%s: Wrong data %d bytes %v", lf.FrameID, len(data), err)
+		}
+	}
+
+	// the file changed since, the frame isn't where it was
+	copy(file[lazy[1].Offset:], "TXXX")
+	if _, err := lazy[1].Load(); err == nil {
+		t.Error("Expected an error loading a frame that moved")
+	}
+	short, err := ReadTag(bytes.NewReader(tag), WithLazyFrames(1024))
+	if err != nil {
+		t.Fatalf("Failed lazy read: %v", err)
+	}
+	short.LazyFrames()[0].rs = bytes.NewReader(tag[:1000])
+	if _, err := short.LazyFrames()[0].Load(); !errors.Is(err, ErrTruncated) {
+		t.Errorf("Expected a truncated error got %v", err)
+	}
+
+	// without seeking it's a normal read
+	parsed, err = ReadTag(io.MultiReader(bytes.NewReader(tag)), WithLazyFrames(1024))
+	if err != nil || len(parsed.frames) != 4 || len(parsed.LazyFrames()) != 0 {
+		t.Errorf("Expected every frame got %v %v", parsed, err)
+	}
+}
+
+func TestLazyLoadSize(t *testing.T) {
+	tag := lazyTag()
+	parsed, err := ReadTag(bytes.NewReader(tag), WithLazyFrames(1024), WithMaxFrameSize(2048))
+	if err != nil {
+		t.Fatalf("Failed lazy read: %v", err)
+	}
+	lf := parsed.LazyFrames()[0]
+	if _, err := lf.Load(); err == nil {
+		t.Error("Expected an error loading a frame over the limit")
+	}
+
+	// the file is shorter than the size, nothing gets allocated for it
+	parsed, err = ReadTag(bytes.NewReader(tag), WithLazyFrames(1024), WithMaxFrameSize(1<<30))
+	if err != nil {
+		t.Fatalf("Failed lazy read: %v", err)
+	}
+	lf = parsed.LazyFrames()[0]
+	lf.Size = 1 << 30
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	_, err = lf.Load()
+	runtime.ReadMemStats(&after)
+	if !errors.Is(err, ErrTruncated) {
+		t.Errorf("Expected a truncated error got %v", err)
+	}
+	if allocated := after.TotalAlloc - before.TotalAlloc; allocated > 1<<20 {
+		t.Errorf("Allocated %d bytes for a frame the file doesn't have", allocated)
+	}
+}
+
+func TestLazyNonSyncsafe(t *testing.T) {
+	data, err := os.ReadFile("testdata/nonsyncsafe.mp3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	eager, err := ReadTag(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	parsed, err := ReadTag(bytes.NewReader(data), WithLazyFrames(128))
+	if err != nil {
+		t.Fatalf("Failed lazy read: %v", err)
+	}
+	if parsed.Album() != "Syncsafe What" || len(parsed.LazyFrames()) != 2 {
+		t.Fatalf("Wrong lazy read %v %v", parsed.frames, parsed.LazyFrames())
+	}
+	pic, err := parsed.LazyFrames()[0].Load()
+	if err != nil || !bytes.Equal(pic, eager.frames[1].Data) {
+		t.Errorf("Wrong picture %d bytes %v", len(pic), err)
+	}
+}
+
+func TestLazyV22(t *testing.T) {
+	pic := append([]byte("\x00PNG\x03\x00"), bytes.Repeat([]byte{1}, 256)...)
+	tag := tagBytes(2, 0, frameBytes(2, "TT2", []byte("\x00Title")), frameBytes(2, "PIC", pic))
+	parsed, err := ReadTag(bytes.NewReader(tag), WithLazyFrames(100))
+	if err != nil || parsed.Title() != "Title" || len(parsed.LazyFrames()) != 1 {
+		t.Fatalf("Wrong lazy read %v %v", parsed, err)
+	}
+	lf := parsed.LazyFrames()[0]
+	data, err := lf.Load()
+	if err != nil || lf.FrameID != "APIC" || parsePicture(data, false).MIMEType != "image/png" {
+		t.Errorf("Wrong v2.2 picture %v %v", lf, err)
+	}
+}
@@ -0,0 +1,61 @@
+package easyid3
+
+import (
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding"
+)
+
+// latin1Text is whether the frame says it's ISO-8859-1 and is all text
+// after the encoding byte. Only those are reinterpreted, the nulls between
+// the parts stay where they are so COMM, TXXX and the rest still split the
+// same.
+func latin1Text(f *Frame) bool {
+	if len(f.Data) == 0 || f.Data[0] != encodingISO88591 {
+		return false
+	}
+	switch f.Kind() {
+	case KindText, KindComment, KindPair:
+		return true
+	}
+	return false
+}
+
+// detectUTF8 marks the ISO-8859-1 frames that are valid UTF-8 with high
+// bytes as UTF-8 and returns their keys. Plain ASCII is the same either
+// way and real ISO-8859-1 text is almost never valid UTF-8.
+func detectUTF8(frames []*Frame) []string {
+	var keys []string
+	for _, f := range frames {
+		if !latin1Text(f) || !hasHighBytes(f.Data[1:]) || !utf8.Valid(f.Data[1:]) {
+			continue
+		}
+		f.Data = append([]byte{encodingUTF8}, f.Data[1:]...)
+		keys = append(keys, f.Key())
+	}
+	return keys
+}
+
+func hasHighBytes(b []byte) bool {
+	for _, c := range b {
+		if c >= 0x80 {
+			return true
+		}
+	}
+	return false
+}
+
+// decodeLegacy re-encodes the text of the ISO-8859-1 frames from enc to
+// UTF-8
+func decodeLegacy(frames []*Frame, enc encoding.Encoding) {
+	for _, f := range frames {
+		if !latin1Text(f) {
+			continue
+		}
+		text, err := enc.NewDecoder().Bytes(f.Data[1:])
+		if err != nil {
+			continue
+		}
+		f.Data = append([]byte{encodingUTF8}, text...)
+	}
+}
@@ -0,0 +1,124 @@
+package easyid3
+
+import (
+	"bytes"
+	"testing"
+
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/japanese"
+)
+
+func TestWithLegacyEncoding(t *testing.T) {
+	tag := tagBytes(3, 0,
+		frameBytes(3, "TIT2", []byte("\x00\xcf\xf0\xe8\xe2\xe5\xf2 \xec\xe8\xf0\x00")),
+		frameBytes(3, "TXXX", []byte("\x00\xca\xe8\xed\xee\x00\xcf\xf0\xe8\xe2\xe5\xf2\x00")),
+		frameBytes(3, "COMM", []byte("\x00rus\x00\xca\xe8\xed\xee")),
+		// already right, the bytes would turn into something else as CP1251
+		frameBytes(3, "TPE1", []byte("\x01\xff\xfe\xe9\x00\x00\x00")),
+		frameBytes(3, "TALB", []byte("\x03Caf\xc3\xa9\x00")),
+		frameBytes(3, "APIC", []byte("\x00image/png\x00\x03\xca\x00\xff\xd8")),
+	)
+	parsed, err := ReadTag(bytes.NewReader(tag), WithLegacyEncoding(charmap.Windows1251))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	if parsed.Title() != "Привет мир" {
+		t.Errorf("Wrong title %q", parsed.Title())
+	}
+	if v, _ := parsed.userText("Кино"); v != "Привет" {
+		t.Errorf("Wrong TXXX %q", v)
+	}
+	if c := parsed.Comments("rus"); len(c) != 1 || c[0].Text != "Кино" {
+		t.Errorf("Wrong comments %+v", c)
+	}
+	if parsed.Artist() != "é" || parsed.Album() != "Café" {
+		t.Errorf("Declared encodings changed artist %q album %q", parsed.Artist(), parsed.Album())
+	}
+	if p := parsed.Pictures(); len(p) != 1 || p[0].Description != "Ê" || !bytes.Equal(p[0].Data, []byte{0xff, 0xd8}) {
+		t.Errorf("Picture changed %+v", p)
+	}
+	if read := rewrite(t, parsed); read.Title() != "Привет мир" {
+		t.Errorf("Wrong title after writing %q", read.Title())
+	}
+
+	plain, err := ReadTag(bytes.NewReader(tag))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	if plain.Title() != "Ïðèâåò ìèð" {
+		t.Errorf("Expected ISO-8859-1 without the option got %q", plain.Title())
+	}
+
+	sjis := tagBytes(3, 0, frameBytes(3, "TIT2", []byte("\x00\x82\xa0\x82\xe8\x82\xaa\x82\xc6\x82\xa4\x00")))
+	parsed, err = ReadTag(bytes.NewReader(sjis), WithLegacyEncoding(japanese.ShiftJIS))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	if parsed.Title() != "ありがとう" {
+		t.Errorf("Wrong Shift-JIS title %q", parsed.Title())
+	}
+}
+
+func TestWithDetectUTF8(t *testing.T) {
+	tag := tagBytes(3, 0,
+		// UTF-8 Café said to be ISO-8859-1
+		frameBytes(3, "TIT2", []byte("\x00Caf\xc3\xa9\x00")),
+		// real ISO-8859-1, é then a space isn't UTF-8
+		frameBytes(3, "TPE1", []byte("\x00Beyonc\xe9 Knowles\x00")),
+		frameBytes(3, "TALB", []byte("\x00Plain ASCII\x00")),
+		frameBytes(3, "COMM", []byte("\x00eng\x00\xe2\x80\x9cQuoted\xe2\x80\x9d")),
+		frameBytes(3, "TCOM", []byte("\x00\xcf\xf0\xe8\xe2\xe5\xf2\x00")),
+	)
+	parsed, err := ReadTag(bytes.NewReader(tag), WithDetectUTF8(), WithLegacyEncoding(charmap.Windows1251))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	if parsed.Title() != "Café" || parsed.Comment() != "“Quoted”" {
+		t.Errorf("Wrong title %q comment %q", parsed.Title(), parsed.Comment())
+	}
+	if parsed.Album() != "Plain ASCII" || parsed.Composer() != "Привет" {
+		t.Errorf("Wrong album %q composer %q", parsed.Album(), parsed.Composer())
+	}
+	if r := parsed.Reinterpreted(); len(r) != 2 || r[0] != "TIT2" || r[1] != "COMM:eng:" {
+		t.Errorf("Wrong reinterpreted frames %q", r)
+	}
+
+	parsed, err = ReadTag(bytes.NewReader(tag), WithDetectUTF8())
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	if parsed.Artist() != "Beyoncé Knowles" {
+		t.Errorf("ISO-8859-1 artist was reinterpreted %q", parsed.Artist())
+	}
+	plain, err := ReadTag(bytes.NewReader(tag))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	if plain.Title() != "CafÃ©" || plain.Reinterpreted() != nil {
+		t.Errorf("Expected no detection without the option got %q", plain.Title())
+	}
+
+	// walking decodes the text the same
+	opts := []Option{WithDetectUTF8(), WithLegacyEncoding(charmap.Windows1251)}
+	parsed, err = ReadTag(bytes.NewReader(tag), opts...)
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	var walked []string
+	err = WalkFrames(bytes.NewReader(tag), func(f *Frame) error {
+		walked = append(walked, f.Decoded())
+		return nil
+	}, opts...)
+	if err != nil {
+		t.Fatalf("Failed walk: %v", err)
+	}
+	if len(walked) != len(parsed.frames) {
+		t.Fatalf("Walked %d frames read %d", len(walked), len(parsed.frames))
+	}
+	for i, f := range parsed.frames {
+		if walked[i] != f.Decoded() {
+			t.Errorf("Walked %q read %q", walked, f.Decoded())
+			break
+		}
+	}
+}
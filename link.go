@@ -0,0 +1,104 @@
+package easyid3
+
+import (
+	"bytes"
+	"io"
+)
+
+// LinkResolver opens what a LINK frame's URL points at for
+// WithLinkResolver
+type LinkResolver func(url string) (io.ReadCloser, error)
+
+// Link is a LINK frame saying the frame with FrameID is in another file.
+// AdditionalData is whatever tells it apart from other frames with the
+// same ID there.
+type Link struct {
+	FrameID        string
+	URL            string
+	AdditionalData []string
+}
+
+// parseLink reads the frame ID, which is 3 characters in v2.2 and 4 after,
+// the terminated URL and then terminated strings of additional data
+func parseLink(data []byte, version byte) Link {
+	size := 4
+	if version == 2 {
+		size = 3
+	}
+	if len(data) < size {
+		return Link{}
+	}
+	l := Link{FrameID: string(data[:size])}
+	url, rest := splitTerminated(encodingISO88591, data[size:])
+	l.URL = decodeLatin1(url)
+	for len(rest) > 0 {
+		var s []byte
+		s, rest = splitTerminated(encodingISO88591, rest)
+		l.AdditionalData = append(l.AdditionalData, decodeLatin1(s))
+	}
+	return l
+}
+
+// Links returns all the LINK frames
+func (t *Tag) Links() []Link {
+	var links []Link
+	for _, f := range t.find("LINK", "LNK") {
+		links = append(links, parseLink(f.Data, f.Version))
+	}
+	return links
+}
+
+// resolveLinks replaces the LINK frames with the frames they point at,
+// the linked file is read as a tag and its frames with the linked ID are
+// put where the LINK was. Links in the linked tag aren't followed. With
+// WithLenient a link that can't be read stays a LINK.
+func resolveLinks(frames []*Frame, header *Header, o *options) ([]*Frame, error) {
+	linked := *o
+	linked.resolveLink = nil
+	var out []*Frame
+	for _, f := range frames {
+		if f.FrameID != "LINK" && f.FrameID != "LNK" {
+			out = append(out, f)
+			continue
+		}
+		l := parseLink(f.Data, f.Version)
+		found, err := readLink(l, &linked, o.resolveLink)
+		if err != nil {
+			if err = o.tolerate(header, f.errorf("resolving %s: %w", l.URL, err)); err != nil {
+				return nil, err
+			}
+			out = append(out, f)
+			continue
+		}
+		out = append(out, found...)
+	}
+	return out, nil
+}
+
+// readLink reads the tag at the link's URL and returns its frames with the
+// link's frame ID
+func readLink(l Link, o *options, resolve LinkResolver) ([]*Frame, error) {
+	rc, err := resolve(l.URL)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, err
+	}
+	// the linked tag's header is thrown away, there'd be no closing a spill
+	lo := *o
+	lo.tagResult = false
+	_, frames, err := readTag(bytes.NewReader(data), &lo)
+	if err != nil {
+		return nil, err
+	}
+	var found []*Frame
+	for _, f := range frames {
+		if f.FrameID == l.FrameID {
+			found = append(found, f)
+		}
+	}
+	return found, nil
+}
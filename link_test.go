@@ -0,0 +1,77 @@
+package easyid3
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+)
+
+func TestLinks(t *testing.T) {
+	art := tagBytes(4, 0,
+		frameBytes(4, "TIT2", []byte("\x03Not linked\x00")),
+		frameBytes(4, "APIC", []byte("\x00image/png\x00\x03Cover\x00\x89PNG")),
+	)
+	tag := tagBytes(4, 0,
+		frameBytes(4, "TIT2", []byte("\x03Title\x00")),
+		frameBytes(4, "LINK", []byte("APICfile:///art/cover.id3\x00front\x00large\x00")),
+		frameBytes(4, "TPE1", []byte("\x03Artist\x00")),
+	)
+	parsed, err := ReadTag(bytes.NewReader(tag))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	want := []Link{{FrameID: "APIC", URL: "file:///art/cover.id3", AdditionalData: []string{"front", "large"}}}
+	if got := parsed.Links(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Wrong links got %+v want %+v", got, want)
+	}
+	if len(parsed.Pictures()) != 0 {
+		t.Error("Expected no pictures without a resolver")
+	}
+	vals, err := ReadID3(bytes.NewReader(tag))
+	if err != nil || vals["LINK"] != "file:///art/cover.id3" {
+		t.Errorf("Wrong values %q %v", vals, err)
+	}
+
+	var urls []string
+	resolve := func(url string) (io.ReadCloser, error) {
+		urls = append(urls, url)
+		return io.NopCloser(bytes.NewReader(art)), nil
+	}
+	parsed, err = ReadTag(bytes.NewReader(tag), WithLinkResolver(resolve))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	if len(urls) != 1 || urls[0] != "file:///art/cover.id3" {
+		t.Errorf("Resolved %q", urls)
+	}
+	if ids := []string{parsed.frames[0].FrameID, parsed.frames[1].FrameID, parsed.frames[2].FrameID}; len(parsed.frames) != 3 || ids[1] != "APIC" {
+		t.Errorf("Wrong frames %v", parsed.frames)
+	}
+	if p := parsed.Pictures(); len(p) != 1 || p[0].Description != "Cover" || string(p[0].Data) != "\x89PNG" {
+		t.Errorf("Wrong pictures %+v", p)
+	}
+	if parsed.Title() != "Title" {
+		t.Errorf("Linked tag replaced the title %q", parsed.Title())
+	}
+
+	missing := errors.New("no such file")
+	broken := func(url string) (io.ReadCloser, error) { return nil, missing }
+	if _, err := ReadTag(bytes.NewReader(tag), WithLinkResolver(broken)); !errors.Is(err, missing) {
+		t.Errorf("Expected the resolver error got %v", err)
+	}
+	parsed, err = ReadTag(bytes.NewReader(tag), WithLinkResolver(broken), WithLenient())
+	if !errors.Is(err, missing) || len(parsed.Links()) != 1 || parsed.Artist() != "Artist" {
+		t.Errorf("Expected the LINK kept got %v %v", parsed, err)
+	}
+
+	v22 := tagBytes(2, 0, frameBytes(2, "LNK", []byte("PIChttp://example.com/a\x00")))
+	parsed, err = ReadTag(bytes.NewReader(v22))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	if l := parsed.Links(); len(l) != 1 || l[0].FrameID != "PIC" || l[0].URL != "http://example.com/a" || l[0].AdditionalData != nil {
+		t.Errorf("Wrong v2.2 link %+v", l)
+	}
+}
@@ -0,0 +1,100 @@
+package easyid3
+
+import "time"
+
+// Lyrics is an USLT frame
+type Lyrics struct {
+	Language   Language
+	Descriptor string
+	Lyrics     string
+}
+
+// parseLyrics reads an USLT frame which has the same layout as COMM
+func parseLyrics(data []byte) Lyrics {
+	c := parseComment(data)
+	return Lyrics{
+		Language:   c.Language,
+		Descriptor: c.Description,
+		Lyrics:     c.Text,
+	}
+}
+
+// Timestamp formats used by SYLT, ETCO and POSS
+const (
+	TimestampMPEGFrames   byte = 1
+	TimestampMilliseconds byte = 2
+)
+
+// SYLT content types
+const (
+	ContentTypeOther byte = iota
+	ContentTypeLyrics
+	ContentTypeTranscription
+	ContentTypeMovement
+	ContentTypeEvents
+	ContentTypeChord
+	ContentTypeTrivia
+	ContentTypeWebpageURLs
+	ContentTypeImageURLs
+)
+
+// DefaultMPEGFrameDuration is used to turn MPEG frame timestamps into time
+// unless changed with WithMPEGFrameDuration, it assumes the usual MPEG-1
+// layer III 1152 samples at 44.1kHz as the tag doesn't know anything about
+// the audio.
+const DefaultMPEGFrameDuration = 1152 * time.Second / 44100
+
+// SyncedLyrics is a SYLT frame
+type SyncedLyrics struct {
+	Language        Language
+	TimestampFormat byte
+	ContentType     byte
+	Descriptor      string
+	Entries         []SyncedText
+}
+
+// SyncedText is one piece of text and when it shows up. Timestamp is the
+// raw value in whatever unit the frame's TimestampFormat says.
+type SyncedText struct {
+	Text      string
+	Time      time.Duration
+	Timestamp uint32
+}
+
+// timestampDuration converts a timestamp in the given format to time
+func timestampDuration(format byte, ts uint64, frameDuration time.Duration) time.Duration {
+	if format == TimestampMPEGFrames {
+		return time.Duration(ts) * frameDuration
+	}
+	return time.Duration(ts) * time.Millisecond
+}
+
+// parseSyncedLyrics reads the encoding, language, timestamp format, content
+// type and descriptor and then terminated text and 4 byte timestamp pairs
+// until the data runs out. A broken entry at the end is dropped.
+func parseSyncedLyrics(data []byte, frameDuration time.Duration) SyncedLyrics {
+	var sl SyncedLyrics
+	if len(data) < 6 {
+		return sl
+	}
+	enc := data[0]
+	sl.Language = parseLanguage(data[1:4])
+	sl.TimestampFormat = data[4]
+	sl.ContentType = data[5]
+	desc, data := splitTerminated(enc, data[6:])
+	sl.Descriptor = decodeText(enc, desc)
+	for len(data) > 0 {
+		text, rest := splitTerminated(enc, data)
+		if len(rest) < 4 {
+			break
+		}
+		ts := uint32(beInt(rest[:4]))
+		sl.Entries = append(sl.Entries, SyncedText{
+			Text:      decodeText(enc, text),
+			Time:      timestampDuration(sl.TimestampFormat, uint64(ts), frameDuration),
+			Timestamp: ts,
+		})
+		data = rest[4:]
+	}
+	return sl
+}
@@ -0,0 +1,106 @@
+package easyid3
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLyrics(t *testing.T) {
+	verse := "Ich bin müde\nIch will schlafen\n"
+	long := strings.Repeat("la la la\n", 4000) + "  "
+	german := append([]byte("\x01deu"), utf16LE("")...)
+	german = append(german, 0, 0)
+	german = append(german, utf16LE(verse)...)
+	german = append(german, 0, 0)
+	tag := tagBytes(4, 0,
+		frameBytes(4, "USLT", german),
+		frameBytes(4, "USLT", []byte("\x03engChorus\x00"+long)),
+	)
+	parsed, err := ReadTag(bytes.NewReader(tag))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	lyrics := parsed.Lyrics()
+	if len(lyrics) != 2 {
+		t.Fatalf("Expected 2 lyrics got %d", len(lyrics))
+	}
+	if lyrics[0] != (Lyrics{Language: "deu", Lyrics: verse}) {
+		t.Errorf("Wrong lyrics %+v", lyrics[0])
+	}
+	if lyrics[1].Language != "eng" || lyrics[1].Descriptor != "Chorus" || lyrics[1].Lyrics != long {
+		t.Errorf("Wrong lyrics %s %s %d bytes", lyrics[1].Language, lyrics[1].Descriptor, len(lyrics[1].Lyrics))
+	}
+
+	vals, err := ReadID3(bytes.NewReader(tag))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	if vals["USLT:deu:"] != verse || vals["USLT:eng:Chorus"] != long {
+		t.Errorf("Wrong lyrics in the map %q", vals["USLT:deu:"])
+	}
+}
+
+func syltEntry(text []byte, ts uint32) []byte {
+	return append(text, byte(ts>>24), byte(ts>>16), byte(ts>>8), byte(ts))
+}
+
+func TestSyncedLyrics(t *testing.T) {
+	data := []byte{3, 'e', 'n', 'g', TimestampMilliseconds, ContentTypeLyrics}
+	data = append(data, "Karaoke\x00"...)
+	data = append(data, syltEntry([]byte("Strangers\x00"), 0)...)
+	data = append(data, syltEntry([]byte("in the night\x00"), 1500)...)
+	data = append(data, syltEntry([]byte("exchanging glances\x00"), 72250)...)
+	// malformed entry missing most of its timestamp
+	data = append(data, "broken\x00\x00\x01"...)
+
+	utf16Data := []byte{1, 'e', 'n', 'g', TimestampMPEGFrames, ContentTypeLyrics, 0, 0}
+	utf16Data = append(utf16Data, syltEntry(append(utf16LE("Früh"), 0, 0), 0)...)
+	utf16Data = append(utf16Data, syltEntry(append(utf16LE("Spät"), 0, 0), 100)...)
+
+	tag := tagBytes(4, 0, frameBytes(4, "SYLT", data), frameBytes(4, "SYLT", utf16Data))
+	parsed, err := ReadTag(bytes.NewReader(tag))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	synced := parsed.SyncedLyrics()
+	if len(synced) != 2 {
+		t.Fatalf("Expected 2 synced lyrics got %d", len(synced))
+	}
+	sl := synced[0]
+	if sl.Language != "eng" || sl.Descriptor != "Karaoke" || sl.ContentType != ContentTypeLyrics {
+		t.Errorf("Wrong synced lyrics %+v", sl)
+	}
+	expected := []SyncedText{
+		{Text: "Strangers", Time: 0, Timestamp: 0},
+		{Text: "in the night", Time: 1500 * time.Millisecond, Timestamp: 1500},
+		{Text: "exchanging glances", Time: 72250 * time.Millisecond, Timestamp: 72250},
+	}
+	if len(sl.Entries) != len(expected) {
+		t.Fatalf("Expected %d entries got %+v", len(expected), sl.Entries)
+	}
+	for i, e := range expected {
+		if sl.Entries[i] != e {
+			t.Errorf("Expected %+v got %+v", e, sl.Entries[i])
+		}
+	}
+
+	sl = synced[1]
+	if len(sl.Entries) != 2 || sl.Entries[0].Text != "Früh" || sl.Entries[1].Text != "Spät" {
+		t.Fatalf("Wrong UTF-16 entries %+v", sl.Entries)
+	}
+	// 100 frames of 1152 samples at 44.1kHz
+	if d := sl.Entries[1].Time; d.Round(time.Millisecond) != 2612*time.Millisecond {
+		t.Errorf("Wrong MPEG frame time %v", d)
+	}
+}
+
+func TestShortSyncedLyrics(t *testing.T) {
+	for _, data := range [][]byte{{}, {3, 'e', 'n', 'g'}, {3, 'e', 'n', 'g', 2, 1}, {3, 'e', 'n', 'g', 2, 1, 'x'}, {1, 'e', 'n', 'g', 2, 1, 0, 0, 'x'}} {
+		sl := parseSyncedLyrics(data, DefaultMPEGFrameDuration)
+		if len(sl.Entries) != 0 {
+			t.Errorf("%v: expected no entries got %+v", data, sl)
+		}
+	}
+}
@@ -0,0 +1,425 @@
+package easyid3
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"time"
+)
+
+// ErrNoAudio is what errors match when no MPEG audio frame is found after
+// the tag
+var ErrNoAudio = errors.New("no MPEG audio frame")
+
+// maxSyncSearch is how far past the tag the first frame is looked for,
+// encoders and taggers sometimes leave junk in between
+const maxSyncSearch = 64 << 10
+
+// MPEGVersion is the MPEG version of the audio frames
+type MPEGVersion byte
+
+// The MPEG versions, 2.5 is the unofficial extension to lower sample rates
+const (
+	MPEG1 MPEGVersion = iota + 1
+	MPEG2
+	MPEG25
+)
+
+func (v MPEGVersion) String() string {
+	switch v {
+	case MPEG1:
+		return "MPEG-1"
+	case MPEG2:
+		return "MPEG-2"
+	case MPEG25:
+		return "MPEG-2.5"
+	}
+	return fmt.Sprintf("MPEGVersion(%d)", byte(v))
+}
+
+// ChannelMode is how the channels of the audio frames are coded
+type ChannelMode byte
+
+// The channel modes in the order of the header bits
+const (
+	ChannelStereo ChannelMode = iota
+	ChannelJointStereo
+	ChannelDual
+	ChannelMono
+)
+
+func (m ChannelMode) String() string {
+	switch m {
+	case ChannelStereo:
+		return "stereo"
+	case ChannelJointStereo:
+		return "joint stereo"
+	case ChannelDual:
+		return "dual channel"
+	case ChannelMono:
+		return "mono"
+	}
+	return fmt.Sprintf("ChannelMode(%d)", byte(m))
+}
+
+// DurationSource is where an AudioInfo's Duration came from
+type DurationSource byte
+
+// The duration sources from the most to the least to be trusted
+const (
+	// DurationFromVBR is the frame count in the VBR header
+	DurationFromVBR DurationSource = iota + 1
+	// DurationFromTLEN is the TLEN frame of the tag
+	DurationFromTLEN
+	// DurationFromBitrate is the size of the audio at the first frame's
+	// bitrate, only right for files that keep to one bitrate
+	DurationFromBitrate
+)
+
+func (s DurationSource) String() string {
+	switch s {
+	case DurationFromVBR:
+		return "VBR header"
+	case DurationFromTLEN:
+		return "TLEN"
+	case DurationFromBitrate:
+		return "bitrate"
+	}
+	return fmt.Sprintf("DurationSource(%d)", byte(s))
+}
+
+// AudioInfo is what the first MPEG audio frame after the tag says about
+// the stream. Bitrate is in bits per second and SampleRate in Hz. Offset is
+// where the frame starts and AudioSize is how many bytes there are from
+// there to the end of the audio.
+type AudioInfo struct {
+	Version         MPEGVersion
+	Layer           int
+	Bitrate         int
+	SampleRate      int
+	ChannelMode     ChannelMode
+	Padding         bool
+	FrameSize       int
+	SamplesPerFrame int
+	Offset          int64
+	AudioSize       int64
+	// VBR is the Xing, Info or VBRI header in the first frame, nil when
+	// there isn't one
+	VBR *VBRHeader
+	// Duration is from the VBR header, then TLEN and then the bitrate,
+	// DurationSource says which
+	Duration       time.Duration
+	DurationSource DurationSource
+	// AverageBitrate is the size of the audio over the duration
+	AverageBitrate int
+}
+
+// VBRHeader is the Xing, Info or VBRI header encoders put in the first
+// frame in place of audio. Frames and Bytes are the totals for the stream
+// and Quality is from 0 to 100, they're 0 when the header leaves them out.
+type VBRHeader struct {
+	// ID is Xing, Info for LAME's CBR files or VBRI
+	ID      string
+	Frames  int
+	Bytes   int
+	Quality int
+	// TOC is the seek table. Xing's has 100 entries, the offset at each
+	// percent of the duration as a fraction of Bytes out of 256. VBRI's are
+	// the bytes each FramesPerEntry frames take up.
+	TOC            []int
+	FramesPerEntry int
+}
+
+// ReadAudioInfo skips the ID3 tag at the start of rs and reads the first
+// MPEG audio frame header along with the VBR header in it. The tag's TLEN
+// is only used without a VBR header. An ID3v1 or appended tag at the end
+// isn't counted as audio. The first frame is looked for up to 64KB after
+// the tag.
+func ReadAudioInfo(rs io.ReadSeeker) (*AudioInfo, error) {
+	start, err := rs.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil, err
+	}
+	end, err := rs.Seek(0, io.SeekEnd)
+	if err != nil {
+		return nil, err
+	}
+	end, err = id3v1Start(rs, end)
+	if err != nil {
+		return nil, err
+	}
+	footer, err := footerBefore(rs, end)
+	if err != nil {
+		return nil, err
+	}
+	if footer != nil {
+		end -= int64(footer.TotalSize())
+	}
+	if _, err := rs.Seek(start, io.SeekStart); err != nil {
+		return nil, err
+	}
+	return ReadAudioInfoSize(rs, end-start)
+}
+
+// ReadAudioInfoSize is ReadAudioInfo for a file that can't seek, r starts
+// at the start of the file and size is how long it is. All of what's after
+// the first frame is taken to be audio.
+func ReadAudioInfoSize(r io.Reader, size int64) (*AudioInfo, error) {
+	br := bufio.NewReaderSize(r, maxSyncSearch+2*maxMPEGFrameSize)
+	offset, tag, err := skipTags(br)
+	if err != nil {
+		return nil, err
+	}
+	window, err := br.Peek(maxSyncSearch + 2*maxMPEGFrameSize)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	info, at := findFrame(window)
+	if info == nil {
+		return nil, fmt.Errorf("in the %d bytes after the tag: %w", len(window), ErrNoAudio)
+	}
+	info.Offset = offset + int64(at)
+	info.AudioSize = size - info.Offset
+	if info.AudioSize < 0 {
+		info.AudioSize = 0
+	}
+	info.VBR = parseVBRHeader(window[at:], info)
+	audioSize := info.AudioSize
+	length, hasLength := time.Duration(0), false
+	if tag != nil {
+		length, hasLength = tag.Length()
+	}
+	switch {
+	case info.VBR != nil && info.VBR.Frames > 0:
+		info.Duration = time.Duration(float64(info.VBR.Frames) * float64(info.SamplesPerFrame) / float64(info.SampleRate) * float64(time.Second))
+		info.DurationSource = DurationFromVBR
+		if info.VBR.Bytes > 0 {
+			audioSize = int64(info.VBR.Bytes)
+		}
+	case hasLength && length > 0:
+		info.Duration, info.DurationSource = length, DurationFromTLEN
+	default:
+		info.Duration = time.Duration(float64(info.AudioSize) * 8 / float64(info.Bitrate) * float64(time.Second))
+		info.DurationSource = DurationFromBitrate
+	}
+	if info.Duration > 0 {
+		info.AverageBitrate = int(math.Round(float64(audioSize) * 8 / info.Duration.Seconds()))
+	}
+	return info, nil
+}
+
+// skipTags reads past the ID3 tags at the start of br, offset is how much
+// was skipped. tag is the first one with just its TLEN read.
+func skipTags(br *bufio.Reader) (offset int64, tag *Tag, err error) {
+	o := newOptions([]Option{WithFrames("TLEN"), WithLenient()})
+	for {
+		header, err := readHeader(br)
+		if errors.Is(err, ErrNoTag) {
+			return offset, tag, nil
+		}
+		if err != nil {
+			return 0, nil, err
+		}
+		rest := &io.LimitedReader{R: br, N: int64(header.TotalSize() - 10)}
+		// a broken tag still has the audio after it
+		frames, _ := readBody(rest, header, o)
+		if tag == nil {
+			tag = &Tag{header: header, frames: frames, opts: o}
+		}
+		if _, err := io.Copy(io.Discard, rest); err != nil {
+			return 0, nil, err
+		}
+		if rest.N > 0 {
+			return 0, nil, truncated("ID3 tag before the audio")
+		}
+		offset += int64(header.TotalSize())
+	}
+}
+
+// maxMPEGFrameSize is the biggest a frame can be, MPEG-1 layer 2 at 384
+// kbps and 32 kHz with padding. MPEG-2.5 would go past it with layer 2 but
+// it's layer 3 only.
+const maxMPEGFrameSize = 1729
+
+// findFrame is the first frame header in b. The next frame's header has to
+// follow it so a stray sync word isn't taken for the start of the audio,
+// unless b ends right where the frame does.
+func findFrame(b []byte) (*AudioInfo, int) {
+	for i := 0; i+4 <= len(b) && i <= maxSyncSearch; i++ {
+		if b[i] != 0xff || b[i+1]&0xe0 != 0xe0 {
+			continue
+		}
+		info, ok := parseMPEGHeader(b[i : i+4])
+		if !ok {
+			continue
+		}
+		next := i + info.FrameSize
+		if next == len(b) {
+			return info, i
+		}
+		if next+4 > len(b) {
+			continue
+		}
+		following, ok := parseMPEGHeader(b[next : next+4])
+		if ok && following.Version == info.Version && following.Layer == info.Layer && following.SampleRate == info.SampleRate {
+			return info, i
+		}
+	}
+	return nil, -1
+}
+
+var mpegBitrates = [5][15]int{
+	// MPEG-1 layers 1, 2 and 3
+	{0, 32, 64, 96, 128, 160, 192, 224, 256, 288, 320, 352, 384, 416, 448},
+	{0, 32, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320, 384},
+	{0, 32, 40, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320},
+	// MPEG-2 and 2.5 layer 1, then layers 2 and 3
+	{0, 32, 48, 56, 64, 80, 96, 112, 128, 144, 160, 176, 192, 224, 256},
+	{0, 8, 16, 24, 32, 40, 48, 56, 64, 80, 96, 112, 128, 144, 160},
+}
+
+var mpegSampleRates = map[MPEGVersion][3]int{
+	MPEG1:  {44100, 48000, 32000},
+	MPEG2:  {22050, 24000, 16000},
+	MPEG25: {11025, 12000, 8000},
+}
+
+// parseMPEGHeader reads the 4 byte frame header, ok is false for the
+// reserved values, the free format bitrate and MPEG-2.5 layers 1 and 2
+// which it doesn't have
+func parseMPEGHeader(h []byte) (*AudioInfo, bool) {
+	if h[0] != 0xff || h[1]&0xe0 != 0xe0 {
+		return nil, false
+	}
+	var version MPEGVersion
+	switch (h[1] >> 3) & 3 {
+	case 0:
+		version = MPEG25
+	case 2:
+		version = MPEG2
+	case 3:
+		version = MPEG1
+	default:
+		return nil, false
+	}
+	layer := 4 - int((h[1]>>1)&3)
+	bitrateIndex := int(h[2] >> 4)
+	rateIndex := int((h[2] >> 2) & 3)
+	if layer == 4 || bitrateIndex == 0 || bitrateIndex == 15 || rateIndex == 3 {
+		return nil, false
+	}
+	if version == MPEG25 && layer != 3 {
+		return nil, false
+	}
+	table := layer - 1
+	if version != MPEG1 {
+		table = 3
+		if layer > 1 {
+			table = 4
+		}
+	}
+	info := &AudioInfo{
+		Version:     version,
+		Layer:       layer,
+		Bitrate:     mpegBitrates[table][bitrateIndex] * 1000,
+		SampleRate:  mpegSampleRates[version][rateIndex],
+		ChannelMode: ChannelMode(h[3] >> 6),
+		Padding:     h[2]&0x02 != 0,
+	}
+	pad := 0
+	if info.Padding {
+		pad = 1
+	}
+	switch {
+	case layer == 1:
+		info.SamplesPerFrame = 384
+		info.FrameSize = (12*info.Bitrate/info.SampleRate + pad) * 4
+	case layer == 3 && version != MPEG1:
+		info.SamplesPerFrame = 576
+		info.FrameSize = 72*info.Bitrate/info.SampleRate + pad
+	default:
+		info.SamplesPerFrame = 1152
+		info.FrameSize = 144*info.Bitrate/info.SampleRate + pad
+	}
+	return info, true
+}
+
+// parseVBRHeader reads the Xing or Info header after the side information
+// of frame, or the VBRI header 32 bytes after the frame header
+func parseVBRHeader(frame []byte, info *AudioInfo) *VBRHeader {
+	if len(frame) > info.FrameSize {
+		frame = frame[:info.FrameSize]
+	}
+	// the side information is smaller for mono and for MPEG-2
+	off := 36
+	switch {
+	case info.Version == MPEG1 && info.ChannelMode == ChannelMono:
+		off = 21
+	case info.Version != MPEG1 && info.ChannelMode != ChannelMono:
+		off = 21
+	case info.Version != MPEG1:
+		off = 13
+	}
+	if len(frame) >= off+8 && (string(frame[off:off+4]) == "Xing" || string(frame[off:off+4]) == "Info") {
+		return parseXing(frame[off:])
+	}
+	if len(frame) >= 36+26 && string(frame[36:40]) == "VBRI" {
+		return parseVBRI(frame[36:])
+	}
+	return nil
+}
+
+// parseXing reads the fields the flags say are there, as many as fit
+func parseXing(b []byte) *VBRHeader {
+	vbr := &VBRHeader{ID: string(b[:4])}
+	flags := beInt(b[4:8])
+	b = b[8:]
+	field := func(flag int, size int) []byte {
+		if flags&flag == 0 || len(b) < size {
+			return nil
+		}
+		v := b[:size]
+		b = b[size:]
+		return v
+	}
+	if v := field(0x1, 4); v != nil {
+		vbr.Frames = beInt(v)
+	}
+	if v := field(0x2, 4); v != nil {
+		vbr.Bytes = beInt(v)
+	}
+	if v := field(0x4, 100); v != nil {
+		vbr.TOC = make([]int, len(v))
+		for i, e := range v {
+			vbr.TOC[i] = int(e)
+		}
+	}
+	if v := field(0x8, 4); v != nil {
+		vbr.Quality = beInt(v)
+	}
+	return vbr
+}
+
+// parseVBRI reads the Fraunhofer header, the TOC entries are scaled up to
+// bytes
+func parseVBRI(b []byte) *VBRHeader {
+	vbr := &VBRHeader{
+		ID:             "VBRI",
+		Quality:        beInt(b[8:10]),
+		Bytes:          beInt(b[10:14]),
+		Frames:         beInt(b[14:18]),
+		FramesPerEntry: beInt(b[24:26]),
+	}
+	entries, scale, size := beInt(b[18:20]), beInt(b[20:22]), beInt(b[22:24])
+	b = b[26:]
+	if size < 1 || size > 4 {
+		return vbr
+	}
+	for i := 0; i < entries && len(b) >= size; i++ {
+		vbr.TOC = append(vbr.TOC, beInt(b[:size])*scale)
+		b = b[size:]
+	}
+	return vbr
+}
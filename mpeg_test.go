@@ -0,0 +1,175 @@
+package easyid3
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// mpegFrames is n frames with header h and silence
+func mpegFrames(h []byte, n int) []byte {
+	info, ok := parseMPEGHeader(h)
+	if !ok {
+		panic("bad MPEG header")
+	}
+	frame := append(append([]byte{}, h...), make([]byte, info.FrameSize-4)...)
+	return bytes.Repeat(frame, n)
+}
+
+func TestReadAudioInfo(t *testing.T) {
+	tag := tagBytes(4, 0, frameBytes(4, "TIT2", []byte("\x03Title")), make([]byte, 32))
+	junk := []byte("\x00\x00junk\xff\xfb\x90\x64stray sync word")
+	audio := mpegFrames([]byte{0xff, 0xfb, 0x90, 0x64}, 100)
+	file := append(append(append([]byte{}, tag...), junk...), audio...)
+	v1 := id3v1Bytes("ID3v1", "", "", "", "", 0, 255)
+
+	info, err := ReadAudioInfo(bytes.NewReader(append(file, v1...)))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	want := AudioInfo{
+		Version: MPEG1, Layer: 3, Bitrate: 128000, SampleRate: 44100,
+		ChannelMode: ChannelJointStereo, FrameSize: 417, SamplesPerFrame: 1152,
+		Offset: int64(len(tag) + len(junk)), AudioSize: int64(len(audio)),
+		Duration: 2606250 * time.Microsecond, DurationSource: DurationFromBitrate, AverageBitrate: 128000,
+	}
+	if *info != want {
+		t.Errorf("Wrong info\ngot  %+v\nwant %+v", *info, want)
+	}
+	if info.Version.String() != "MPEG-1" || info.ChannelMode.String() != "joint stereo" || info.DurationSource.String() != "bitrate" {
+		t.Errorf("Wrong names %v %v %v", info.Version, info.ChannelMode, info.DurationSource)
+	}
+
+	// TLEN is what the tag says
+	info, err = ReadAudioInfo(bytes.NewReader(append(append([]byte{}, typicalTag()...), audio...)))
+	if err != nil || info.Duration != 215*time.Second || info.DurationSource != DurationFromTLEN || info.AverageBitrate != 1552 {
+		t.Errorf("Wrong TLEN info %+v %v", info, err)
+	}
+
+	// without seeking the ID3v1 tag counts as audio
+	info, err = ReadAudioInfoSize(bytes.NewReader(append(file, v1...)), int64(len(file)+len(v1)))
+	if err != nil || info.AudioSize != int64(len(audio)+len(v1)) {
+		t.Errorf("Wrong info %+v %v", info, err)
+	}
+
+	appended := appendedTagBytes(frameBytes(4, "TIT2", []byte("\x03Appended")))
+	info, err = ReadAudioInfo(bytes.NewReader(append(append([]byte{}, audio...), appended...)))
+	if err != nil || info.Offset != 0 || info.AudioSize != int64(len(audio)) {
+		t.Errorf("Wrong info without a tag in front %+v %v", info, err)
+	}
+}
+
+func TestReadAudioInfoFormats(t *testing.T) {
+	for _, test := range []struct {
+		header []byte
+		want   AudioInfo
+	}{
+		{[]byte{0xff, 0xf3, 0x80, 0xc0}, AudioInfo{Version: MPEG2, Layer: 3, Bitrate: 64000, SampleRate: 22050, ChannelMode: ChannelMono, FrameSize: 208, SamplesPerFrame: 576}},
+		{[]byte{0xff, 0xe3, 0x42, 0x80}, AudioInfo{Version: MPEG25, Layer: 3, Bitrate: 32000, SampleRate: 11025, ChannelMode: ChannelDual, Padding: true, FrameSize: 209, SamplesPerFrame: 576}},
+		{[]byte{0xff, 0xfd, 0xd4, 0x00}, AudioInfo{Version: MPEG1, Layer: 2, Bitrate: 320000, SampleRate: 48000, FrameSize: 960, SamplesPerFrame: 1152}},
+		{[]byte{0xff, 0xff, 0xa8, 0x40}, AudioInfo{Version: MPEG1, Layer: 1, Bitrate: 320000, SampleRate: 32000, ChannelMode: ChannelJointStereo, FrameSize: 480, SamplesPerFrame: 384}},
+	} {
+		audio := mpegFrames(test.header, 10)
+		info, err := ReadAudioInfo(bytes.NewReader(audio))
+		if err != nil {
+			t.Errorf("%x: failed read: %v", test.header, err)
+			continue
+		}
+		test.want.AudioSize = int64(len(audio))
+		test.want.Duration = time.Duration(float64(len(audio)) * 8 / float64(test.want.Bitrate) * float64(time.Second))
+		test.want.DurationSource = DurationFromBitrate
+		test.want.AverageBitrate = test.want.Bitrate
+		if *info != test.want {
+			t.Errorf("%x: wrong info\ngot  %+v\nwant %+v", test.header, *info, test.want)
+		}
+	}
+}
+
+func TestReadAudioInfoErrors(t *testing.T) {
+	tag := typicalTag()
+	for name, file := range map[string][]byte{
+		"no audio":   tag,
+		"fake audio": append(append([]byte{}, tag...), fakeAudio...),
+		"too far":    append(append(append([]byte{}, tag...), make([]byte, maxSyncSearch+1)...), mpegFrames([]byte{0xff, 0xfb, 0x90, 0x64}, 3)...),
+		"reserved":   bytes.Repeat([]byte{0xff, 0xeb, 0x90, 0x64}, 1000),
+		"free":       bytes.Repeat([]byte{0xff, 0xfb, 0x00, 0x64}, 1000),
+		// MPEG-2.5 layer 2 at 160 kbps and 8 kHz is 2880 byte frames
+		"mpeg 2.5 layer 2": append([]byte{0xff, 0xe5, 0xe8, 0x00}, make([]byte, 2876)...),
+		"mpeg 2.5 layer 1": bytes.Repeat(append([]byte{0xff, 0xe7, 0x90, 0x00}, make([]byte, 620)...), 3),
+	} {
+		if _, err := ReadAudioInfo(bytes.NewReader(file)); !errors.Is(err, ErrNoAudio) {
+			t.Errorf("%s: expected ErrNoAudio got %v", name, err)
+		}
+	}
+	// one frame on its own is enough
+	if info, err := ReadAudioInfo(bytes.NewReader(mpegFrames([]byte{0xff, 0xfb, 0x90, 0x64}, 1))); err != nil || info.FrameSize != 417 {
+		t.Errorf("Wrong info %+v %v", info, err)
+	}
+	if _, err := ReadAudioInfo(bytes.NewReader(tag[:50])); !errors.Is(err, ErrTruncated) {
+		t.Errorf("Expected ErrTruncated got %v", err)
+	}
+}
+
+// vbrFrame is a frame with header h and the VBR header at off
+func vbrFrame(h []byte, off int, vbr []byte) []byte {
+	frame := mpegFrames(h, 1)
+	copy(frame[off:], vbr)
+	return frame
+}
+
+func TestReadAudioInfoVBR(t *testing.T) {
+	xing := []byte("Xing\x00\x00\x00\x0f\x00\x00\x03\xe8\x00\x01\x86\xa0")
+	for i := 0; i < 100; i++ {
+		xing = append(xing, byte(i*255/99))
+	}
+	xing = append(xing, 0, 0, 0, 57)
+	// the frames don't all have the same bitrate
+	audio := append(mpegFrames([]byte{0xff, 0xfb, 0x90, 0x64}, 3), mpegFrames([]byte{0xff, 0xfb, 0xb0, 0x64}, 3)...)
+	// a TLEN that's wrong loses out to the frame count
+	file := append(append(append([]byte{}, typicalTag()...), vbrFrame([]byte{0xff, 0xfb, 0x90, 0x64}, 36, xing)...), audio...)
+	info, err := ReadAudioInfo(bytes.NewReader(file))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	vbr := info.VBR
+	if vbr == nil || vbr.ID != "Xing" || vbr.Frames != 1000 || vbr.Bytes != 100000 || vbr.Quality != 57 || len(vbr.TOC) != 100 || vbr.TOC[99] != 255 {
+		t.Fatalf("Wrong Xing header %+v", vbr)
+	}
+	frames := 1000.0
+	want := time.Duration(frames * 1152 / 44100 * float64(time.Second))
+	if info.Duration != want || info.DurationSource != DurationFromVBR || info.AverageBitrate != 30625 {
+		t.Errorf("Wrong duration %v %v %d", info.Duration, info.DurationSource, info.AverageBitrate)
+	}
+
+	// MPEG-2 mono has it at 13 and Info has only the frame count
+	info, err = ReadAudioInfo(bytes.NewReader(append(vbrFrame([]byte{0xff, 0xf3, 0x80, 0xc0}, 13, []byte("Info\x00\x00\x00\x01\x00\x00\x00\x64")), mpegFrames([]byte{0xff, 0xf3, 0x80, 0xc0}, 2)...)))
+	if err != nil || info.VBR == nil || info.VBR.ID != "Info" || info.VBR.Frames != 100 || info.VBR.TOC != nil {
+		t.Fatalf("Wrong Info header %+v %v", info, err)
+	}
+	frames = 100
+	if want := time.Duration(frames * 576 / 22050 * float64(time.Second)); info.Duration != want {
+		t.Errorf("Wrong duration %v want %v", info.Duration, want)
+	}
+
+	vbri := []byte("VBRI\x00\x01\x04\x40\x00\x4b\x00\x00\x27\x10\x00\x00\x00\x64\x00\x03\x00\x02\x00\x02\x00\x21\x0b\xb8\x0f\xa0\x03\xe8")
+	info, err = ReadAudioInfo(bytes.NewReader(append(vbrFrame([]byte{0xff, 0xfb, 0x90, 0x64}, 36, vbri), audio...)))
+	if err != nil || info.VBR == nil {
+		t.Fatalf("Wrong VBRI read %+v %v", info, err)
+	}
+	vbr = info.VBR
+	if vbr.ID != "VBRI" || vbr.Frames != 100 || vbr.Bytes != 10000 || vbr.Quality != 75 || vbr.FramesPerEntry != 33 || !reflect.DeepEqual(vbr.TOC, []int{6000, 8000, 2000}) {
+		t.Errorf("Wrong VBRI header %+v", vbr)
+	}
+	if info.DurationSource != DurationFromVBR || info.AverageBitrate != int(math.Round(10000*8/info.Duration.Seconds())) {
+		t.Errorf("Wrong duration %v %v %d", info.Duration, info.DurationSource, info.AverageBitrate)
+	}
+
+	// a stereo frame doesn't have it at the mono offset
+	info, err = ReadAudioInfo(bytes.NewReader(append(vbrFrame([]byte{0xff, 0xfb, 0x90, 0x64}, 21, xing), audio...)))
+	if err != nil || info.VBR != nil || info.DurationSource != DurationFromBitrate {
+		t.Errorf("Expected no VBR header got %+v %v", info, err)
+	}
+}
@@ -0,0 +1,53 @@
+package easyid3
+
+// MusicBrainz is the IDs Picard tags a file with. The recording ID is in
+// the MusicBrainz UFID, the rest are TXXX frames. Fields with more than one
+// ID, like ArtistID on a track with a featured artist, are joined with
+// DefaultTextSeparator.
+type MusicBrainz struct {
+	RecordingID      string
+	TrackID          string
+	AlbumID          string
+	ArtistID         string
+	AlbumArtistID    string
+	ReleaseGroupID   string
+	WorkID           string
+	DiscID           string
+	OriginalAlbumID  string
+	OriginalArtistID string
+	TRMID            string
+	AcoustID         string
+	AcoustIDPrint    string
+	MusicIPPUID      string
+	AlbumType        string
+	AlbumStatus      string
+	ReleaseCountry   string
+}
+
+// MusicBrainz collects the MusicBrainz IDs, the fields the tag doesn't have
+// are empty
+func (t *Tag) MusicBrainz() MusicBrainz {
+	text := func(desc string) string {
+		s, _ := t.userText(desc)
+		return s
+	}
+	return MusicBrainz{
+		RecordingID:      t.MusicBrainzRecordingID(),
+		TrackID:          text("MusicBrainz Release Track Id"),
+		AlbumID:          text("MusicBrainz Album Id"),
+		ArtistID:         text("MusicBrainz Artist Id"),
+		AlbumArtistID:    text("MusicBrainz Album Artist Id"),
+		ReleaseGroupID:   text("MusicBrainz Release Group Id"),
+		WorkID:           text("MusicBrainz Work Id"),
+		DiscID:           text("MusicBrainz Disc Id"),
+		OriginalAlbumID:  text("MusicBrainz Original Album Id"),
+		OriginalArtistID: text("MusicBrainz Original Artist Id"),
+		TRMID:            text("MusicBrainz TRM Id"),
+		AcoustID:         text("Acoustid Id"),
+		AcoustIDPrint:    text("Acoustid Fingerprint"),
+		MusicIPPUID:      text("MusicIP PUID"),
+		AlbumType:        text("MusicBrainz Album Type"),
+		AlbumStatus:      text("MusicBrainz Album Status"),
+		ReleaseCountry:   text("MusicBrainz Album Release Country"),
+	}
+}
@@ -0,0 +1,45 @@
+package easyid3
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+func TestMusicBrainz(t *testing.T) {
+	// laid out the way Picard writes v2.4 tags
+	data, err := os.ReadFile("testdata/picard.mp3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := ReadTag(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	want := MusicBrainz{
+		RecordingID:    "32bdf4b4-0fe9-4e4e-9a3c-3f9c7e0d1f4a",
+		TrackID:        "a4c9a8f3-7c1e-3b9e-8f2d-6b7e9d0c1a2b",
+		AlbumID:        "1b022e01-4da6-387b-8658-8678046e4cef",
+		ArtistID:       "0383dadf-2a4e-4d10-a46a-e9e041da8eb3; 5441c29d-3602-4898-b1a1-b77fa23b8e50",
+		AlbumArtistID:  "0383dadf-2a4e-4d10-a46a-e9e041da8eb3",
+		ReleaseGroupID: "8c3d5e5d-8e37-33b5-9b8d-f3e3e8d4a2b1",
+		WorkID:         "e3f2d1c0-b9a8-4765-8432-10fedcba9876",
+		AcoustID:       "6a2b1c4d-0e9f-4a8b-b7c6-d5e4f3a2b1c0",
+		AlbumType:      "album",
+		AlbumStatus:    "Official",
+		ReleaseCountry: "GB",
+	}
+	if got := parsed.MusicBrainz(); got != want {
+		t.Errorf("Wrong IDs\ngot  %+v\nwant %+v", got, want)
+	}
+
+	// other taggers don't keep Picard's casing
+	tag := tagBytes(3, 0, frameBytes(3, "TXXX", []byte("\x00MUSICBRAINZ ALBUM ID\x00abc\x00")))
+	parsed, err = ReadTag(bytes.NewReader(tag))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	if got := parsed.MusicBrainz(); got != (MusicBrainz{AlbumID: "abc"}) {
+		t.Errorf("Wrong IDs %+v", got)
+	}
+}
@@ -0,0 +1,305 @@
+package easyid3
+
+import (
+	"time"
+
+	"golang.org/x/text/encoding"
+)
+
+// DefaultMaxFrameSize is the largest frame payload that will be read into
+// memory unless changed with WithMaxFrameSize
+const DefaultMaxFrameSize = 16 << 20
+
+// DefaultMaxFrames is how many frames a tag can have before the read
+// stops unless changed with WithMaxFrames
+const DefaultMaxFrames = 10000
+
+// DefaultTextSeparator joins the values of a text frame with more than one
+// in Decoded and the ReadID3 map unless changed with WithTextSeparator
+const DefaultTextSeparator = "; "
+
+// Option changes how a tag is read or written
+type Option func(*options)
+
+type options struct {
+	maxFrameSize int
+	maxFrames    int
+	checkCRC     bool
+	seekDepth    int
+	padding      int
+	id3v1        bool
+	frames       map[string]bool
+	// maxInlineSize is the biggest binary frame that's read, -1 for all
+	maxInlineSize int
+	// lazySize is the biggest frame WithLazyFrames reads, -1 for all
+	lazySize int
+	// spill is where WithSpill puts the binary frames over spillSize,
+	// only the reads that return a Tag set tagResult to keep them
+	spill             SpillFunc
+	spillSize         int
+	spillTemp         bool
+	tagResult         bool
+	mpegFrameDuration time.Duration
+	lenient           bool
+	strict            bool
+	// raw leaves the format flags for ReadID3Raw
+	raw           bool
+	originalIDs   bool
+	textSeparator string
+	friendlyKeys  bool
+	itunes        bool
+	resolveLink   LinkResolver
+	// legacyEncoding is what ISO-8859-1 text really is
+	legacyEncoding  encoding.Encoding
+	detectUTF8      bool
+	jsonPictureData bool
+	traceFn         func(TraceEvent)
+}
+
+// tolerate keeps the problem on the header and carries on with WithLenient,
+// nil is nothing wrong
+func (o *options) tolerate(header *Header, err error) error {
+	if err == nil || !o.lenient {
+		return err
+	}
+	header.errors = append(header.errors, err)
+	return nil
+}
+
+// writeFrames is the frames as they get written
+func (o *options) writeFrames(frames []*Frame) []*Frame {
+	if o.itunes {
+		return iTunesFrames(frames)
+	}
+	return frames
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{
+		maxFrameSize:      DefaultMaxFrameSize,
+		maxFrames:         DefaultMaxFrames,
+		lazySize:          -1,
+		maxInlineSize:     -1,
+		mpegFrameDuration: DefaultMPEGFrameDuration,
+		textSeparator:     DefaultTextSeparator,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// WithMaxFrameSize limits how big a single frame can be. Frames declaring
+// more than n bytes fail the read instead of being allocated.
+func WithMaxFrameSize(n int) Option {
+	return func(o *options) {
+		o.maxFrameSize = n
+	}
+}
+
+// WithMaxFrames limits how many frames a tag can have, a tag full of tiny
+// frames stops with ErrTooManyFrames once it has more than n. With
+// WithLenient the first n come back. 0 or less is no limit.
+func WithMaxFrames(n int) Option {
+	return func(o *options) {
+		o.maxFrames = n
+	}
+}
+
+// WithCRCCheck checks the frames, and in v2.4 the padding after them,
+// against the CRC when the extended header has one. A mismatch fails the
+// read with ErrCRCMismatch.
+func WithCRCCheck() Option {
+	return func(o *options) {
+		o.checkCRC = true
+	}
+}
+
+// WithFollowSeek follows SEEK frames to the update tags they point at when
+// reading from an io.ReadSeeker, up to depth tags deep. Frames from the
+// update tags replace the ones they update.
+func WithFollowSeek(depth int) Option {
+	return func(o *options) {
+		o.seekDepth = depth
+	}
+}
+
+// WithFrames only reads the frames with the IDs, the rest are skipped over
+// without being read into memory. Encrypted frames need ENCR in the list to
+// be decrypted.
+func WithFrames(ids ...string) Option {
+	return func(o *options) {
+		if o.frames == nil {
+			o.frames = map[string]bool{}
+		}
+		for _, id := range ids {
+			o.frames[id] = true
+		}
+	}
+}
+
+// WithMaxInlineFrameSize skips the APIC, GEOB and PRIV frames bigger than
+// n bytes without reading them, text frames are read as normal. The tag's
+// Skipped frames say where they were so they can be read later.
+func WithMaxInlineFrameSize(n int) Option {
+	return func(o *options) {
+		o.maxInlineSize = n
+	}
+}
+
+// WithLazyFrames leaves frames bigger than n bytes in the file when the tag
+// is read from an io.ReadSeeker, they're seeked past and Tag.LazyFrames
+// has them to Load when they're wanted. Before v2.4 an unsynchronised tag
+// can't be seeked around in and is read as normal.
+func WithLazyFrames(n int) Option {
+	return func(o *options) {
+		o.lazySize = n
+	}
+}
+
+// WithSpill writes the data of APIC, GEOB and PRIV frames bigger than n
+// bytes to spill as it's read instead of keeping it, Tag.Spilled has the
+// references spill returned. The text frames and what ReadID3 returns are
+// the same as without it. Frames with format flags to undo and everything
+// read without giving back a Tag are read as normal. Cleaning up what spill
+// wrote is up to the caller.
+func WithSpill(n int, spill SpillFunc) Option {
+	return func(o *options) {
+		o.spill, o.spillSize, o.spillTemp = spill, n, false
+	}
+}
+
+// WithSpillToTemp is WithSpill to temporary files in dir, the default
+// temporary directory when it's empty. Tag.Close removes them.
+func WithSpillToTemp(n int, dir string) Option {
+	return func(o *options) {
+		o.spill, o.spillSize, o.spillTemp = spillToTemp(dir), n, true
+	}
+}
+
+// WithLenient reads past broken frames instead of failing. The frames that
+// could be read come back along with an ErrorList of what was wrong. A
+// frame that overruns the tag or is cut short is kept with the data there
+// was and is Truncated.
+func WithLenient() Option {
+	return func(o *options) {
+		o.lenient = true
+	}
+}
+
+// WithStrict fails the read with a SpecError on things that break the spec
+// but can still be read, like deprecated frames in a v2.4 tag or text that
+// isn't terminated. With WithLenient as well they all come back in the
+// ErrorList instead.
+func WithStrict() Option {
+	return func(o *options) {
+		o.strict = true
+	}
+}
+
+// WithOriginalFrameIDs keeps the frames the way the tag has them instead of
+// the v2.4 way. v2.2 keeps its three character IDs and PIC frames their
+// image format, v2.3 keeps TYER, TDAT and TIME instead of them being merged
+// into TDRC.
+func WithOriginalFrameIDs() Option {
+	return func(o *options) {
+		o.originalIDs = true
+	}
+}
+
+// WithTextSeparator joins the values of text frames with more than one with
+// sep in the map ReadID3 returns, Tag.Texts has them separately.
+func WithTextSeparator(sep string) Option {
+	return func(o *options) {
+		o.textSeparator = sep
+	}
+}
+
+// WithFriendlyKeys keys the ReadID3 and ReadID3All maps with names like
+// title, artist and album_artist instead of frame IDs, v2.2 IDs read with
+// WithOriginalFrameIDs get the same names. Frames without one keep their
+// usual key.
+func WithFriendlyKeys() Option {
+	return func(o *options) {
+		o.friendlyKeys = true
+	}
+}
+
+// WithLinkResolver reads the files LINK frames point at with resolve and
+// puts the frames they link to in the tag in place of the LINK.
+func WithLinkResolver(resolve LinkResolver) Option {
+	return func(o *options) {
+		o.resolveLink = resolve
+	}
+}
+
+// WithLegacyEncoding decodes the text frames that say they're ISO-8859-1
+// with enc instead, for tags written by software that put CP1251 or
+// Shift-JIS in them. Frames that say they're UTF-8 or UTF-16 are left
+// alone. The frames are UTF-8 after so WriteTag writes them correctly.
+func WithLegacyEncoding(enc encoding.Encoding) Option {
+	return func(o *options) {
+		o.legacyEncoding = enc
+	}
+}
+
+// WithDetectUTF8 reads text frames that say they're ISO-8859-1 as UTF-8
+// when their bytes are valid UTF-8, which is what a lot of taggers really
+// wrote. It goes before WithLegacyEncoding so the frames that aren't UTF-8
+// can still be something else. Tag.Reinterpreted says which frames it
+// changed.
+func WithDetectUTF8() Option {
+	return func(o *options) {
+		o.detectUTF8 = true
+	}
+}
+
+// WithMPEGFrameDuration sets how long an MPEG frame is for SYLT timestamps
+// counted in frames, the tag has no way to know so it's up to the audio.
+func WithMPEGFrameDuration(d time.Duration) Option {
+	return func(o *options) {
+		o.mpegFrameDuration = d
+	}
+}
+
+// WithPadding adds n bytes of padding after the frames when writing so the
+// tag can grow later without moving the audio.
+func WithPadding(n int) Option {
+	return func(o *options) {
+		o.padding = n
+	}
+}
+
+// WithITunesCompatible writes the sort and compilation frames the way
+// iTunes reads them, XSOA, XSOP and XSOT become TSOA, TSOP and TSOT and
+// TCMP is always "1" or "0".
+func WithITunesCompatible() Option {
+	return func(o *options) {
+		o.itunes = true
+	}
+}
+
+// WithID3v1 has SaveTag write an ID3v1.1 tag from the same fields for
+// players that can't read anything newer, replacing the one already there.
+func WithID3v1() Option {
+	return func(o *options) {
+		o.id3v1 = true
+	}
+}
+
+// WithJSONPictureData has MarshalJSON include the picture data, base64'd
+// like any []byte. Without it pictures only have their size.
+func WithJSONPictureData() Option {
+	return func(o *options) {
+		o.jsonPictureData = true
+	}
+}
+
+// WithTrace calls fn with each decision made reading the tag, the header,
+// every frame header, frames skipped, the padding and why the frames
+// stopped. TraceWriter writes them out for reading.
+func WithTrace(fn func(TraceEvent)) Option {
+	return func(o *options) {
+		o.traceFn = fn
+	}
+}
@@ -0,0 +1,210 @@
+package easyid3
+
+import (
+	"bytes"
+	"errors"
+	"hash/crc32"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// artworkTag is a tag with a big picture in the middle of the text frames
+func artworkTag() []byte {
+	art := bytes.Repeat([]byte{0xaa}, 512<<10)
+	return tagBytes(4, 0,
+		frameBytes(4, "TIT2", []byte("\x03Title\x00")),
+		frameBytes(4, "APIC", apicData(0, "image/jpeg", PictureTypeFrontCover, []byte("\x00"), art)),
+		frameBytes(4, "TPE1", []byte("\x03Artist\x00")),
+		frameBytes(4, "TALB", []byte("\x03Album\x00")),
+		frameBytes(4, "TLEN", []byte("\x03180000\x00")),
+	)
+}
+
+func TestWithFrames(t *testing.T) {
+	props, err := ReadID3(bytes.NewReader(artworkTag()), WithFrames("TIT2", "TALB"), WithFrames("TLEN"))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	want := map[string]string{"TIT2": "Title", "TALB": "Album", "TLEN": "180000"}
+	if !reflect.DeepEqual(props, want) {
+		t.Errorf("Got %v", props)
+	}
+
+	// skipped frames still count towards the CRC
+	frames := append(frameBytes(4, "TIT2", []byte("\x03Title\x00")), frameBytes(4, "TPE1", []byte("\x03Artist\x00"))...)
+	crc := crc32.ChecksumIEEE(frames)
+	tag := tagBytes(4, 0x40, v24ExtendedHeader(false, &crc, nil), frames)
+	props, err = ReadID3(bytes.NewReader(tag), WithFrames("TIT2"), WithCRCCheck())
+	if err != nil || !reflect.DeepEqual(props, map[string]string{"TIT2": "Title"}) {
+		t.Errorf("Got %v: %v", props, err)
+	}
+
+	// a frame over the size limit is fine when it isn't read
+	_, err = ReadID3(bytes.NewReader(artworkTag()), WithFrames("TIT2"), WithMaxFrameSize(1024))
+	if err != nil {
+		t.Errorf("Failed read skipping a big frame: %v", err)
+	}
+}
+
+func BenchmarkReadID3Artwork(b *testing.B) {
+	tag := artworkTag()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, err := ReadID3(bytes.NewReader(tag))
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkReadID3ArtworkWithFrames(b *testing.B) {
+	tag := artworkTag()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, err := ReadID3(bytes.NewReader(tag), WithFrames("TIT2", "TPE1", "TALB", "TLEN"))
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestWithMaxInlineFrameSize(t *testing.T) {
+	data := artworkTag()
+	tag, err := ReadTag(bytes.NewReader(data), WithMaxInlineFrameSize(1024))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	if tag.Title() != "Title" || tag.Artist() != "Artist" || len(tag.Pictures()) != 0 {
+		t.Errorf("Wrong frames %v", tag.frames)
+	}
+	skipped := tag.Skipped()
+	if len(skipped) != 1 || skipped[0].FrameID != "APIC" || skipped[0].Data != nil || skipped[0].Err != nil {
+		t.Fatalf("Wrong skipped frames %+v", skipped)
+	}
+	// the offset and size are enough to read it again
+	s := skipped[0]
+	if string(data[s.Offset:s.Offset+4]) != "APIC" {
+		t.Errorf("Offset %d isn't the frame", s.Offset)
+	}
+	payload := data[s.Offset+10 : s.Offset+10+int64(s.Size)]
+	if p := parsePicture(payload, false); p.MIMEType != "image/jpeg" || len(p.Data) != 512<<10 {
+		t.Errorf("Wrong picture at the offset %q %d", p.MIMEType, len(p.Data))
+	}
+
+	// small ones are still read
+	tag, err = ReadTag(bytes.NewReader(data), WithMaxInlineFrameSize(1<<20))
+	if err != nil || len(tag.Pictures()) != 1 || len(tag.Skipped()) != 0 {
+		t.Errorf("Expected the picture to be read: %v", err)
+	}
+}
+
+func TestWithMPEGFrameDuration(t *testing.T) {
+	data := []byte{3, 'e', 'n', 'g', TimestampMPEGFrames, ContentTypeLyrics, 0}
+	data = append(data, syltEntry([]byte("Hello\x00"), 40)...)
+	tag := tagBytes(4, 0, frameBytes(4, "SYLT", data))
+
+	parsed, err := ReadTag(bytes.NewReader(tag))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	if d := parsed.SyncedLyrics()[0].Entries[0].Time; d != 40*DefaultMPEGFrameDuration {
+		t.Errorf("Wrong default time %v", d)
+	}
+	// 1152 samples at 48kHz
+	parsed, err = ReadTag(bytes.NewReader(tag), WithMPEGFrameDuration(24*time.Millisecond))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	if d := parsed.SyncedLyrics()[0].Entries[0].Time; d != 960*time.Millisecond {
+		t.Errorf("Wrong time %v", d)
+	}
+	if d := NewTag().options().mpegFrameDuration; d != DefaultMPEGFrameDuration {
+		t.Errorf("Wrong default for a new tag %v", d)
+	}
+}
+
+func TestWithFramesChapters(t *testing.T) {
+	tag := tagBytes(4, 0,
+		frameBytes(4, "TIT2", []byte("\x03Episode\x00")),
+		frameBytes(4, "CHAP", chapData("chp0", 0, 60000,
+			frameBytes(4, "TIT2", []byte("\x03Intro\x00")),
+		)),
+	)
+	parsed, err := ReadTag(bytes.NewReader(tag), WithFrames("CHAP"))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	if parsed.Title() != "" {
+		t.Errorf("Read a frame that wasn't asked for %q", parsed.Title())
+	}
+	if c := parsed.Chapters(); len(c) != 1 || c[0].Title != "Intro" {
+		t.Errorf("Wrong chapters %+v", c)
+	}
+}
+
+func TestWithLenient(t *testing.T) {
+	title := frameBytes(4, "TIT2", []byte("\x03Title\x00"))
+	// compressed with nothing after the data length indicator
+	broken := flaggedFrameBytes(4, "TPE1", 0, 0x09, []byte{0, 0, 0, 10, 0xde, 0xad})
+	big := frameBytes(4, "PRIV", bytes.Repeat([]byte{1}, 64))
+	album := frameBytes(4, "TALB", []byte("\x03Album\x00"))
+	tag := tagBytes(4, 0, title, broken, big, album)
+
+	if _, err := ReadID3(bytes.NewReader(tag), WithMaxFrameSize(32)); err == nil {
+		t.Fatal("Expected the broken frame to fail the read")
+	}
+	props, err := ReadID3(bytes.NewReader(tag), WithMaxFrameSize(32), WithLenient())
+	want := map[string]string{"TIT2": "Title", "TALB": "Album"}
+	if !reflect.DeepEqual(props, want) {
+		t.Errorf("Got %v", props)
+	}
+	var list ErrorList
+	if !errors.As(err, &list) || len(list) != 2 {
+		t.Fatalf("Expected 2 errors got %v", err)
+	}
+	var fe *FrameError
+	if !errors.As(err, &fe) || fe.FrameID != "TPE1" || fe.Offset != int64(10+len(title)) {
+		t.Errorf("Wrong frame error %+v", fe)
+	}
+	if !errors.As(list[1], &fe) || fe.FrameID != "PRIV" {
+		t.Errorf("Wrong frame error %+v", fe)
+	}
+
+	// cut short in the last frame, it's kept with what's there
+	parsed, err := ReadTag(bytes.NewReader(tag[:len(tag)-3]), WithMaxFrameSize(32), WithLenient())
+	if !errors.Is(err, ErrTruncated) || parsed == nil || parsed.Title() != "Title" || parsed.Album() != "Alb" {
+		t.Errorf("Wrong truncated read %v %v", parsed, err)
+	}
+	if f := parsed.frames[len(parsed.frames)-1]; !f.Truncated() || f.Size != 4 || parsed.frames[0].Truncated() {
+		t.Errorf("Wrong truncated frame %+v", f)
+	}
+	if props, err := ReadID3(bytes.NewReader(tagBytes(4, 0, title)), WithLenient()); err != nil || props["TIT2"] != "Title" {
+		t.Errorf("Expected a clean read got %v %v", props, err)
+	}
+}
+
+func TestWithMaxFrames(t *testing.T) {
+	// a quarter of a million one byte frames
+	tiny := frameBytes(4, "TIT2", []byte{3})
+	tag := tagBytes(4, 0, bytes.Repeat(tiny, 250000))
+
+	_, err := ReadID3(bytes.NewReader(tag))
+	if !errors.Is(err, ErrTooManyFrames) {
+		t.Fatalf("Expected too many frames got %v", err)
+	}
+	parsed, err := ReadTag(bytes.NewReader(tag), WithMaxFrames(100), WithLenient())
+	if !errors.Is(err, ErrTooManyFrames) || parsed == nil || len(parsed.frames) != 100 {
+		t.Fatalf("Expected the first 100 frames got %v", err)
+	}
+	// stopping at the limit means the work doesn't grow with the tag
+	allocs := testing.AllocsPerRun(5, func() {
+		ReadTag(bytes.NewReader(tag), WithMaxFrames(100), WithLenient())
+	})
+	if allocs > 2000 {
+		t.Errorf("Expected bounded allocations got %v", allocs)
+	}
+	if parsed, err := ReadTag(bytes.NewReader(tag), WithMaxFrames(0)); err != nil || len(parsed.frames) != 250000 {
+		t.Errorf("Expected no limit got %v", err)
+	}
+}
@@ -0,0 +1,93 @@
+package easyid3
+
+// Picture types from the APIC spec
+const (
+	PictureTypeOther byte = iota
+	PictureTypeFileIcon
+	PictureTypeOtherFileIcon
+	PictureTypeFrontCover
+	PictureTypeBackCover
+	PictureTypeLeaflet
+	PictureTypeMedia
+	PictureTypeLeadArtist
+	PictureTypeArtist
+	PictureTypeConductor
+	PictureTypeBand
+	PictureTypeComposer
+	PictureTypeLyricist
+	PictureTypeRecordingLocation
+	PictureTypeDuringRecording
+	PictureTypeDuringPerformance
+	PictureTypeScreenCapture
+	PictureTypeBrightColouredFish
+	PictureTypeIllustration
+	PictureTypeBandLogo
+	PictureTypePublisherLogo
+)
+
+// Picture is an APIC frame, Data is the image file as is
+type Picture struct {
+	MIMEType    string
+	PictureType byte
+	Description string
+	Data        []byte
+}
+
+// Pictures is every picture in a tag in the order they appear
+type Pictures []Picture
+
+// ByType returns the pictures of the given type
+func (ps Pictures) ByType(pictureType byte) Pictures {
+	var found Pictures
+	for _, p := range ps {
+		if p.PictureType == pictureType {
+			found = append(found, p)
+		}
+	}
+	return found
+}
+
+// FrontCover is the first front cover, falling back to the first picture
+// of type other which is what a lot of taggers use for the cover. It's
+// nil when there's neither.
+func (ps Pictures) FrontCover() *Picture {
+	for _, pictureType := range []byte{PictureTypeFrontCover, PictureTypeOther} {
+		for i := range ps {
+			if ps[i].PictureType == pictureType {
+				return &ps[i]
+			}
+		}
+	}
+	return nil
+}
+
+// parsePicture reads the encoding byte, the terminated MIME type (always
+// ISO-8859-1), picture type, terminated description and then the image.
+// v2.2 PIC frames have a fixed 3 character image format instead of the
+// MIME type.
+func parsePicture(data []byte, v22 bool) Picture {
+	var p Picture
+	if len(data) == 0 {
+		return p
+	}
+	enc := data[0]
+	data = data[1:]
+	var mime []byte
+	if v22 {
+		if len(data) < 3 {
+			return p
+		}
+		mime, data = data[:3], data[3:]
+	} else {
+		mime, data = splitTerminated(encodingISO88591, data)
+	}
+	p.MIMEType = decodeLatin1(mime)
+	if len(data) == 0 {
+		return p
+	}
+	p.PictureType = data[0]
+	desc, img := splitTerminated(enc, data[1:])
+	p.Description = decodeText(enc, desc)
+	p.Data = img
+	return p
+}
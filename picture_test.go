@@ -0,0 +1,127 @@
+package easyid3
+
+import (
+	"bytes"
+	"testing"
+)
+
+// a JPEG is full of nulls which can't be mistaken for terminators
+var tinyJPEG = []byte{0xff, 0xd8, 0xff, 0xe0, 0x0, 0x10, 'J', 'F', 'I', 'F', 0x0, 0x1, 0x1, 0x0, 0x0, 0x1, 0x0, 0x1, 0x0, 0x0, 0xff, 0xd9}
+
+func apicData(enc byte, mime string, picType byte, desc []byte, img []byte) []byte {
+	out := []byte{enc}
+	out = append(out, mime...)
+	out = append(out, 0, picType)
+	out = append(out, desc...)
+	return append(out, img...)
+}
+
+func TestPictures(t *testing.T) {
+	tag := tagBytes(4, 0,
+		frameBytes(4, "TIT2", []byte("\x03Episode 1\x00")),
+		frameBytes(4, "APIC", apicData(3, "image/jpeg", 3, []byte("Cover\x00"), tinyJPEG)),
+		frameBytes(4, "APIC", apicData(1, "image/png", 4, []byte{0xff, 0xfe, 'B', 0, 0, 0}, []byte{0x89, 'P', 'N', 'G', 0, 0})),
+	)
+	parsed, err := ReadTag(bytes.NewReader(tag))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	pictures := parsed.Pictures()
+	if len(pictures) != 2 {
+		t.Fatalf("Expected 2 pictures got %d", len(pictures))
+	}
+	p := pictures[0]
+	if p.MIMEType != "image/jpeg" || p.PictureType != 3 || p.Description != "Cover" {
+		t.Errorf("Wrong picture %s %d %q", p.MIMEType, p.PictureType, p.Description)
+	}
+	if !bytes.Equal(p.Data, tinyJPEG) {
+		t.Errorf("Wrong image data %x", p.Data)
+	}
+	p = pictures[1]
+	if p.MIMEType != "image/png" || p.PictureType != 4 || p.Description != "B" {
+		t.Errorf("Wrong picture %s %d %q", p.MIMEType, p.PictureType, p.Description)
+	}
+	if !bytes.Equal(p.Data, []byte{0x89, 'P', 'N', 'G', 0, 0}) {
+		t.Errorf("Wrong image data %x", p.Data)
+	}
+
+	vals, err := ReadID3(bytes.NewReader(tag))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	if vals["APIC"] != "B" {
+		t.Errorf("Expected the description in the map got %q", vals["APIC"])
+	}
+}
+
+func TestV22Picture(t *testing.T) {
+	data := append([]byte{0, 'J', 'P', 'G', 3, 0}, tinyJPEG...)
+	tag := tagBytes(2, 0, frameBytes(2, "PIC", data))
+	parsed, err := ReadTag(bytes.NewReader(tag))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	pictures := parsed.Pictures()
+	if len(pictures) != 1 {
+		t.Fatalf("Expected 1 picture got %d", len(pictures))
+	}
+	if pictures[0].MIMEType != "image/jpeg" || pictures[0].PictureType != 3 || !bytes.Equal(pictures[0].Data, tinyJPEG) {
+		t.Errorf("Wrong picture %+v", pictures[0])
+	}
+
+	parsed, err = ReadTag(bytes.NewReader(tag), WithOriginalFrameIDs())
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	if p := parsed.Pictures(); len(p) != 1 || p[0].MIMEType != "JPG" || !bytes.Equal(p[0].Data, tinyJPEG) {
+		t.Errorf("Wrong picture %+v", p)
+	}
+}
+
+func TestShortPictures(t *testing.T) {
+	for _, data := range [][]byte{{}, {0}, {0, 'i', 'm'}, {0, 'i', 'm', 0}} {
+		p := parsePicture(data, false)
+		if p.Data != nil || p.PictureType != 0 {
+			t.Errorf("%v: expected empty picture got %+v", data, p)
+		}
+		parsePicture(data, true)
+	}
+}
+
+func TestPictureTypes(t *testing.T) {
+	tag := tagBytes(3, 0,
+		frameBytes(3, "APIC", apicData(0, "image/jpeg", PictureTypeArtist, []byte("Artist\x00"), tinyJPEG)),
+		frameBytes(3, "APIC", apicData(0, "image/jpeg", PictureTypeBackCover, []byte("Back\x00"), tinyJPEG)),
+		frameBytes(3, "APIC", apicData(0, "image/jpeg", PictureTypeFrontCover, []byte("Front\x00"), tinyJPEG)),
+	)
+	parsed, err := ReadTag(bytes.NewReader(tag))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	pictures := parsed.Pictures()
+	if len(pictures) != 3 {
+		t.Fatalf("Expected 3 pictures got %d", len(pictures))
+	}
+	if back := pictures.ByType(PictureTypeBackCover); len(back) != 1 || back[0].Description != "Back" {
+		t.Errorf("Wrong back cover %+v", back)
+	}
+	if front := pictures.FrontCover(); front == nil || front.Description != "Front" {
+		t.Errorf("Wrong front cover %+v", front)
+	}
+	if none := pictures.ByType(PictureTypeBandLogo); len(none) != 0 {
+		t.Errorf("Expected no band logo got %+v", none)
+	}
+}
+
+func TestFrontCoverFallback(t *testing.T) {
+	pictures := Pictures{
+		{PictureType: PictureTypeArtist, Description: "Artist"},
+		{PictureType: PictureTypeOther, Description: "Other"},
+	}
+	if front := pictures.FrontCover(); front == nil || front.Description != "Other" {
+		t.Errorf("Expected fallback to other got %+v", front)
+	}
+	if front := pictures[:1].FrontCover(); front != nil {
+		t.Errorf("Expected no front cover got %+v", front)
+	}
+}
@@ -0,0 +1,65 @@
+package easyid3
+
+import "strings"
+
+// Frames iTunes writes for podcast episodes, none of them are in the specs
+const (
+	FramePodcast            = "PCST"
+	FramePodcastDescription = "TDES"
+	FramePodcastID          = "TGID"
+	FramePodcastFeed        = "WFED"
+	FramePodcastKeywords    = "TKWD"
+	FramePodcastCategory    = "TCAT"
+)
+
+// pcstData is the PCST iTunes writes, it's the frame being there that
+// counts and not the number in it
+var pcstData = []byte{0, 0, 0, 0}
+
+// textURL is whether a URL frame has an encoding byte like a text frame,
+// iTunes writes WFED that way
+func textURL(f *Frame) bool {
+	return f.FrameID == FramePodcastFeed && len(f.Data) > 0 && f.Data[0] <= encodingUTF8
+}
+
+// Podcast is whether iTunes marked the file as a podcast episode with PCST
+func (t *Tag) Podcast() bool {
+	return len(t.find(FramePodcast)) > 0
+}
+
+// PodcastDescription is TDES, the episode description which can be a lot
+// longer than a comment
+func (t *Tag) PodcastDescription() string {
+	return t.text(FramePodcastDescription)
+}
+
+// PodcastID is TGID, the episode GUID from the feed
+func (t *Tag) PodcastID() string {
+	return t.text(FramePodcastID)
+}
+
+// PodcastFeed is WFED, the URL of the feed
+func (t *Tag) PodcastFeed() string {
+	return t.text(FramePodcastFeed)
+}
+
+// PodcastKeywords is TKWD split on the commas iTunes puts between them
+func (t *Tag) PodcastKeywords() []string {
+	var keywords []string
+	for _, k := range strings.Split(t.text(FramePodcastKeywords), ",") {
+		if k = strings.TrimSpace(k); k != "" {
+			keywords = append(keywords, k)
+		}
+	}
+	return keywords
+}
+
+// SetPodcast marks the file as a podcast episode with PCST, false removes
+// it. WFED, TDES, TGID and TKWD are set with SetText.
+func (t *Tag) SetPodcast(podcast bool) {
+	if !podcast {
+		t.DeleteFrame(FramePodcast)
+		return
+	}
+	t.replace(newFrame(FramePodcast, append([]byte(nil), pcstData...)))
+}
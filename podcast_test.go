@@ -0,0 +1,86 @@
+package easyid3
+
+import (
+	"bytes"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestPodcast(t *testing.T) {
+	// laid out the way iTunes tags an episode, v2.3 with UTF-16 text
+	props, err := ReadID3File("testdata/podcast.mp3")
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	if props["PCST"] != "1" || props["WFED"] != "https://feeds.example.com/show.xml" || props["TGID"] != "https://example.com/episodes/42" {
+		t.Errorf("Wrong props %q", props)
+	}
+
+	parsed := readPodcast(t)
+	if !parsed.Podcast() {
+		t.Error("Expected a podcast")
+	}
+	if d := parsed.PodcastDescription(); d != "We talk about release week, what broke and what we’d do differently." {
+		t.Errorf("Wrong description %q", d)
+	}
+	if parsed.PodcastID() != "https://example.com/episodes/42" || parsed.PodcastFeed() != "https://feeds.example.com/show.xml" {
+		t.Errorf("Wrong GUID %q feed %q", parsed.PodcastID(), parsed.PodcastFeed())
+	}
+	if k := parsed.PodcastKeywords(); !reflect.DeepEqual(k, []string{"tech", "releases", "go"}) {
+		t.Errorf("Wrong keywords %q", k)
+	}
+
+	read := rewrite(t, parsed)
+	if !read.Podcast() || read.PodcastFeed() != parsed.PodcastFeed() || read.Title() != "Episode 42: Shipping It" {
+		t.Errorf("Podcast frames lost in the rewrite %v", read.frames)
+	}
+	if f := read.find("WFED"); len(f) != 1 || !textURL(f[0]) {
+		t.Errorf("WFED should be written as text %v", f)
+	}
+	read.SetPodcast(false)
+	if read.Podcast() {
+		t.Error("Expected PCST removed")
+	}
+}
+
+func TestWritePodcast(t *testing.T) {
+	var buf bytes.Buffer
+	_, err := WriteID3(&buf, map[string]string{
+		"PCST": "1",
+		"WFED": "https://feeds.example.com/show.xml",
+		"TDES": "Description",
+	})
+	if err != nil {
+		t.Fatalf("Failed write: %v", err)
+	}
+	parsed, err := ReadTag(&buf)
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	if f := parsed.find("PCST"); len(f) != 1 || !bytes.Equal(f[0].Data, pcstData) {
+		t.Errorf("Wrong PCST %v", f)
+	}
+	if !parsed.Podcast() || parsed.PodcastFeed() != "https://feeds.example.com/show.xml" || parsed.PodcastDescription() != "Description" {
+		t.Errorf("Wrong frames %v", parsed.frames)
+	}
+
+	tag := NewTag()
+	tag.SetPodcast(true)
+	if !rewrite(t, tag).Podcast() {
+		t.Error("Expected a podcast")
+	}
+}
+
+func readPodcast(t *testing.T) *Tag {
+	t.Helper()
+	data, err := os.ReadFile("testdata/podcast.mp3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := ReadTag(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	return parsed
+}
@@ -0,0 +1,45 @@
+package easyid3
+
+import (
+	"fmt"
+	"time"
+)
+
+// PositionSync is a POSS frame, where in the audio the tag showed up when
+// it's in a stream. Position is in whatever unit TimestampFormat says and
+// Time is it converted.
+type PositionSync struct {
+	TimestampFormat byte
+	Position        uint64
+	Time            time.Duration
+}
+
+// parsePositionSync reads the timestamp format and then the position,
+// which is as many bytes as it needs like PCNT
+func parsePositionSync(data []byte, frameDuration time.Duration) (PositionSync, error) {
+	if len(data) < 2 {
+		return PositionSync{}, fmt.Errorf("POSS of %d bytes has no position", len(data))
+	}
+	pos, err := parseCounter(data[1:])
+	if err != nil {
+		return PositionSync{}, fmt.Errorf("POSS position: %w", err)
+	}
+	return PositionSync{
+		TimestampFormat: data[0],
+		Position:        pos,
+		Time:            timestampDuration(data[0], pos, frameDuration),
+	}, nil
+}
+
+// PositionSync is the POSS frame, ok is false when there isn't one
+func (t *Tag) PositionSync() (ps PositionSync, ok bool, err error) {
+	frames := t.find("POSS")
+	if len(frames) == 0 {
+		return ps, false, nil
+	}
+	ps, err = parsePositionSync(frames[0].Data, t.options().mpegFrameDuration)
+	if err != nil {
+		return ps, false, frames[0].wrapError(err)
+	}
+	return ps, true, nil
+}
@@ -0,0 +1,42 @@
+package easyid3
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestPositionSync(t *testing.T) {
+	for _, tc := range []struct {
+		data []byte
+		want PositionSync
+	}{
+		{[]byte{TimestampMilliseconds, 0, 0, 0x75, 0x30}, PositionSync{TimestampMilliseconds, 30000, 30 * time.Second}},
+		// past 32 bits for a stream that's been going for a couple of months
+		{[]byte{TimestampMilliseconds, 0x01, 0x00, 0x00, 0x00, 0x00}, PositionSync{TimestampMilliseconds, 1 << 32, (1 << 32) * time.Millisecond}},
+		{[]byte{TimestampMPEGFrames, 0x10}, PositionSync{TimestampMPEGFrames, 16, 16 * 26 * time.Millisecond}},
+	} {
+		tag := tagBytes(4, 0, frameBytes(4, "POSS", tc.data))
+		parsed, err := ReadTag(bytes.NewReader(tag), WithMPEGFrameDuration(26*time.Millisecond))
+		if err != nil {
+			t.Fatalf("Failed read: %v", err)
+		}
+		if got, ok, err := parsed.PositionSync(); !ok || err != nil || got != tc.want {
+			t.Errorf("%x got %+v %v want %+v", tc.data, got, err, tc.want)
+		}
+	}
+
+	tag := tagBytes(4, 0, frameBytes(4, "POSS", []byte{TimestampMilliseconds, 1, 0, 0, 0, 0, 0, 0, 0, 0}))
+	parsed, err := ReadTag(bytes.NewReader(tag))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	var fe *FrameError
+	if _, ok, err := parsed.PositionSync(); ok || !errors.As(err, &fe) || fe.FrameID != "POSS" {
+		t.Errorf("Expected a FrameError for 72 bits got %v", err)
+	}
+	if _, ok, err := NewTag().PositionSync(); ok || err != nil {
+		t.Errorf("Expected no POSS got %v", err)
+	}
+}
@@ -0,0 +1,85 @@
+package easyid3
+
+import (
+	"io"
+)
+
+// RawFrame is a frame as it is in the tag, nothing the format flags did to
+// the data has been undone. A v2.3 tag that's unsynchronised as a whole has
+// had that undone as it isn't part of the frame.
+type RawFrame struct {
+	ID    string
+	Flags []byte
+	Data  []byte
+	// Version is the major version of the tag, it says which flag layout
+	// Flags is in
+	Version byte
+	// Offset is where the frame header is counting from the start of the tag
+	Offset int64
+	// tagUnsync is the v2.4 header saying every frame is unsynchronised
+	tagUnsync bool
+	// maxSize is WithMaxFrameSize's limit, 0 for a frame not read by
+	// ReadID3Raw
+	maxSize int
+}
+
+// ReadID3Raw reads the frames without decoding anything so the frames the
+// package doesn't know about can be read or written back as they were.
+// Encrypted frames aren't decrypted and SEEK frames aren't followed.
+// WithMaxFrameSize carries over to what Frame decompresses.
+func ReadID3Raw(r io.Reader, opts ...Option) ([]RawFrame, error) {
+	o := newOptions(opts)
+	o.raw = true
+	br := bufferedReader(r)
+	defer releaseReader(br, r)
+	header, err := readHeader(br)
+	if err != nil {
+		return nil, err
+	}
+	body, err := bodyReader(newStreamReader(br, r), header, o)
+	if err != nil {
+		return nil, err
+	}
+	tagUnsync := header.Version[0] == 4 && header.Unsynchronisation()
+	var frames []RawFrame
+	err = walkFrames(body, header, o, func(f *Frame) error {
+		frames = append(frames, RawFrame{
+			ID:        f.FrameID,
+			Flags:     f.Flags,
+			Data:      f.Data,
+			Version:   f.Version,
+			Offset:    f.offset,
+			tagUnsync: tagUnsync,
+			maxSize:   o.maxFrameSize,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return frames, header.frameErrors()
+}
+
+// Frame undoes the format flags the way ReadTag does so the data can be
+// decoded. Encrypted frames need decrypting after, their Data is still
+// encrypted.
+func (rf RawFrame) Frame() (*Frame, error) {
+	f := &Frame{
+		FrameID:    rf.ID,
+		Size:       len(rf.Data),
+		Flags:      rf.Flags,
+		Data:       append([]byte(nil), rf.Data...),
+		Version:    rf.Version,
+		DataLength: -1,
+		offset:     rf.Offset,
+	}
+	maxSize := rf.maxSize
+	if maxSize == 0 {
+		maxSize = DefaultMaxFrameSize
+	}
+	err := f.unformat(rf.tagUnsync, maxSize)
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
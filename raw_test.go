@@ -0,0 +1,64 @@
+package easyid3
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestReadID3Raw(t *testing.T) {
+	title := []byte("\x03Compressed title\x00")
+	compressed := append(synsafeBytes(len(title)), deflate(title)...)
+	priv := []byte("owner\x00\x00\x01\x02\xff")
+	tag := tagBytes(4, 0,
+		flaggedFrameBytes(4, "TIT2", 0x40, 0x09, compressed),
+		frameBytes(4, "PRIV", priv),
+		flaggedFrameBytes(4, "TPE1", 0, 0x04, []byte("\x80secret")),
+	)
+	frames, err := ReadID3Raw(bytes.NewReader(tag))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	if len(frames) != 3 {
+		t.Fatalf("Expected 3 frames got %+v", frames)
+	}
+	tit2 := frames[0]
+	if tit2.ID != "TIT2" || !bytes.Equal(tit2.Flags, []byte{0x40, 0x09}) || !bytes.Equal(tit2.Data, compressed) || tit2.Offset != 10 {
+		t.Errorf("Wrong raw frame %+v", tit2)
+	}
+	if !bytes.Equal(frames[1].Data, priv) || frames[1].Offset != int64(20+len(compressed)) {
+		t.Errorf("Wrong raw frame %+v", frames[1])
+	}
+	if string(frames[2].Data) != "\x80secret" {
+		t.Errorf("Wrong raw frame %+v", frames[2])
+	}
+
+	f, err := tit2.Frame()
+	if err != nil {
+		t.Fatalf("Failed unformat: %v", err)
+	}
+	if f.Decoded() != "Compressed title" || f.TagAlterPreserve() {
+		t.Errorf("Wrong frame %v", f)
+	}
+	if !bytes.Equal(tit2.Data, compressed) {
+		t.Error("Frame changed the raw data")
+	}
+
+	// the size limit holds for what the frame inflates to
+	big := append([]byte{3}, bytes.Repeat([]byte("x"), 64<<10)...)
+	bomb := append(synsafeBytes(len(big)), deflate(big)...)
+	tag = tagBytes(4, 0, flaggedFrameBytes(4, "TIT2", 0, 0x09, bomb))
+	frames, err = ReadID3Raw(bytes.NewReader(tag), WithMaxFrameSize(1024))
+	if err != nil || len(frames) != 1 {
+		t.Fatalf("Failed read: %v %+v", err, frames)
+	}
+	if _, err := frames[0].Frame(); err == nil {
+		t.Error("Expected an error inflating past the size limit")
+	}
+	frames, err = ReadID3Raw(bytes.NewReader(tag))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	if f, err := frames[0].Frame(); err != nil || len(f.Data) != len(big) {
+		t.Errorf("Failed unformat: %v", err)
+	}
+}
@@ -0,0 +1,112 @@
+package easyid3
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ReadID3At is ReadID3 for an io.ReaderAt like a ranged HTTP or S3 reader.
+// It reads the 10 byte header and then the rest of the tag in one more
+// read, SEEK frames aren't followed.
+func ReadID3At(r io.ReaderAt, opts ...Option) (map[string]string, error) {
+	header, err := readHeaderAt(r, 0)
+	if err != nil {
+		return nil, err
+	}
+	if header.IsFooter() {
+		return nil, notFoundError("ID3 header")
+	}
+	o := newOptions(opts)
+	frames, err := readBodyAt(r, 10, header, o)
+	if err != nil {
+		return nil, err
+	}
+	return frameMap(frames, o), header.frameErrors()
+}
+
+// ReadAppendedTagAt is ReadAppendedTag for an io.ReaderAt of size bytes. It
+// reads the footer in the last 10 bytes and then the tag in front of it,
+// when there's no footer there it checks for one before an ID3v1 tag.
+func ReadAppendedTagAt(r io.ReaderAt, size int64, opts ...Option) (*Tag, error) {
+	end := size
+	footer, err := readHeaderAt(r, end-10)
+	if errors.Is(err, ErrNoTag) && size >= 128 {
+		magic := make([]byte, 3)
+		_, err = r.ReadAt(magic, size-128)
+		if err != nil {
+			return nil, err
+		}
+		if string(magic) != "TAG" {
+			return nil, notFoundError("ID3 footer")
+		}
+		end = size - 128
+		footer, err = readHeaderAt(r, end-10)
+	}
+	if errors.Is(err, ErrNoTag) {
+		return nil, notFoundError("ID3 footer")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !footer.IsFooter() {
+		return nil, notFoundError("ID3 footer")
+	}
+	start := end - 10 - int64(footer.Size) - 10
+	if start < 0 {
+		return nil, errors.New("ID3 footer size is bigger than the file")
+	}
+	// the footer is a copy of the header so there's no need to read it
+	header := *footer
+	header.ID3 = "ID3"
+	o := newOptions(opts)
+	o.tagResult = true
+	frames, err := readBodyAt(r, start+10, &header, o)
+	if err != nil {
+		return nil, err
+	}
+	return &Tag{header: &header, frames: frames, opts: o}, header.frameErrors()
+}
+
+// readHeaderAt reads a header or footer at off
+func readHeaderAt(r io.ReaderAt, off int64) (*Header, error) {
+	if off < 0 {
+		return nil, notFoundError("ID3 header")
+	}
+	buf := make([]byte, 10)
+	n, err := r.ReadAt(buf, off)
+	if n < 10 {
+		if errors.Is(err, io.EOF) {
+			return nil, notFoundError("ID3 header")
+		}
+		return nil, err
+	}
+	if string(buf[:3]) != "ID3" && string(buf[:3]) != "3DI" {
+		return nil, notFoundError("ID3 header")
+	}
+	return newID3(buf)
+}
+
+// readBodyAt reads the whole body at off in one go and parses it from
+// memory the same way the streaming readers do. A body bigger than the
+// frame size limit is streamed instead so a bad size can't allocate it.
+func readBodyAt(r io.ReaderAt, off int64, header *Header, o *options) ([]*Frame, error) {
+	if v := header.Version[0]; v < 2 || v > 4 {
+		return nil, fmt.Errorf("ID3v2.%d: %w", v, ErrUnsupportedVersion)
+	}
+	if header.Size > o.maxFrameSize {
+		section := io.NewSectionReader(r, off, int64(header.Size))
+		br := bufferedReader(section)
+		defer releaseReader(br, section)
+		return readBody(newStreamReader(br, section), header, o)
+	}
+	body := make([]byte, header.Size)
+	n, err := r.ReadAt(body, off)
+	if n < len(body) {
+		if err == nil || errors.Is(err, io.EOF) {
+			return nil, truncated("tag is %d bytes but only %d could be read", header.Size, n)
+		}
+		return nil, err
+	}
+	return readBody(&sliceReader{b: body}, header, o)
+}
@@ -0,0 +1,98 @@
+package easyid3
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"runtime"
+	"testing"
+)
+
+// countingReaderAt counts the reads, each one is a request when it's HTTP
+type countingReaderAt struct {
+	r     io.ReaderAt
+	reads int
+}
+
+func (c *countingReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	c.reads++
+	return c.r.ReadAt(p, off)
+}
+
+func TestReadID3At(t *testing.T) {
+	for _, tag := range [][]byte{ivsID3, v22ID3, iTunesComments} {
+		want, err := ReadID3(bytes.NewReader(tag))
+		if err != nil {
+			t.Fatal(err)
+		}
+		r := &countingReaderAt{r: bytes.NewReader(append(append([]byte{}, tag...), fakeAudio...))}
+		got, err := ReadID3At(r)
+		if err != nil {
+			t.Fatalf("Failed read: %v", err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Got %v want %v", got, want)
+		}
+		if r.reads != 2 {
+			t.Errorf("Took %d reads", r.reads)
+		}
+	}
+
+	if _, err := ReadID3At(bytes.NewReader(fakeAudio)); !errors.Is(err, ErrNoTag) {
+		t.Errorf("Expected ErrNoTag got %v", err)
+	}
+	tag := tagBytes(4, 0, frameBytes(4, "TIT2", []byte("\x03Title\x00")))
+	if _, err := ReadID3At(bytes.NewReader(tag[:15])); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("Expected ErrUnexpectedEOF got %v", err)
+	}
+}
+
+func TestReadAppendedTagAt(t *testing.T) {
+	appended := appendedTagBytes(frameBytes(4, "TIT2", []byte("\x03Appended\x00")))
+	file := append(append([]byte{}, fakeAudio...), appended...)
+	r := &countingReaderAt{r: bytes.NewReader(file)}
+	tag, err := ReadAppendedTagAt(r, int64(len(file)))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	if tag.Title() != "Appended" || r.reads != 2 {
+		t.Errorf("Got %q in %d reads", tag.Title(), r.reads)
+	}
+
+	withV1 := append(append([]byte{}, file...), id3v1Bytes("v1", "", "", "", "", 0, 255)...)
+	tag, err = ReadAppendedTagAt(bytes.NewReader(withV1), int64(len(withV1)))
+	if err != nil || tag.Title() != "Appended" {
+		t.Errorf("Got %v %v", tag, err)
+	}
+
+	for _, f := range [][]byte{fakeAudio, {}, append(append([]byte{}, fakeAudio...), id3v1Bytes("v1", "", "", "", "", 0, 255)...)} {
+		if _, err := ReadAppendedTagAt(bytes.NewReader(f), int64(len(f))); !errors.Is(err, ErrNoTag) {
+			t.Errorf("Expected ErrNoTag got %v", err)
+		}
+	}
+}
+
+func TestReadID3AtBadSize(t *testing.T) {
+	for name, test := range map[string]struct {
+		input   string
+		wantErr bool
+	}{
+		// the size bytes have their top bit set, it was read as 536MB
+		"not syncsafe": {"ID3\x00\x00\xff\xfb\x90d\x00\x00\x00\x00\x00", true},
+		"old version":  {"ID3\x01\x00\x00\x7f\x7f\x7f\x7f\x00\x00\x00\x00", true},
+		// streamed, the zeros are taken for padding the way ReadID3 does
+		"biggest size": {"ID3\x04\x00\x00\x7f\x7f\x7f\x7f\x00\x00\x00\x00", false},
+	} {
+		var before, after runtime.MemStats
+		runtime.ReadMemStats(&before)
+		props, err := ReadID3At(bytes.NewReader([]byte(test.input)))
+		runtime.ReadMemStats(&after)
+		if (err != nil) != test.wantErr || len(props) != 0 {
+			t.Errorf("%s: wrong result %v %v", name, props, err)
+		}
+		if allocated := after.TotalAlloc - before.TotalAlloc; allocated > 1<<20 {
+			t.Errorf("%s: allocated %d bytes", name, allocated)
+		}
+	}
+}
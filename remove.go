@@ -0,0 +1,83 @@
+package easyid3
+
+import (
+	"bufio"
+	"errors"
+	"io"
+)
+
+// RemoveID3 copies r to w without the ID3v2 tags at the start. When r is an
+// io.ReadSeeker the ID3v1 tag and appended ID3v2 tag at the end are left
+// out too. leading and trailing are how many bytes were taken off each end,
+// anything without a tag is copied as it is.
+func RemoveID3(w io.Writer, r io.Reader) (leading, trailing int64, err error) {
+	audioEnd := int64(-1)
+	if rs, ok := r.(io.ReadSeeker); ok {
+		audioEnd, trailing, err = trailingTags(rs)
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+	br := bufio.NewReader(r)
+	// some files have more than one tag stacked up at the start
+	for {
+		n, err := SkipID3(br)
+		leading += n
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			// nothing but a cut off tag, there's no audio to copy
+			return leading, 0, nil
+		}
+		if err != nil {
+			return 0, 0, err
+		}
+		if n == 0 {
+			break
+		}
+	}
+	var audio io.Reader = br
+	if audioEnd >= 0 {
+		if leading > audioEnd {
+			// a file that's only a tag with a footer finds it at both ends
+			trailing -= leading - audioEnd
+			audioEnd = leading
+		}
+		audio = io.LimitReader(br, audioEnd-leading)
+	}
+	_, err = io.Copy(w, audio)
+	if err != nil {
+		return 0, 0, err
+	}
+	return leading, trailing, nil
+}
+
+// trailingTags finds where the tags at the end start, leaving rs back where
+// it was. audioEnd is relative to where rs was.
+func trailingTags(rs io.ReadSeeker) (audioEnd, trailing int64, err error) {
+	start, err := rs.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return 0, 0, err
+	}
+	end, err := rs.Seek(0, io.SeekEnd)
+	if err != nil {
+		return 0, 0, err
+	}
+	audioEnd, err = id3v1Start(rs, end)
+	if err != nil {
+		return 0, 0, err
+	}
+	footer, err := footerBefore(rs, audioEnd)
+	if err != nil {
+		return 0, 0, err
+	}
+	if footer != nil && audioEnd-20-int64(footer.Size) >= start {
+		audioEnd -= 20 + int64(footer.Size)
+	}
+	_, err = rs.Seek(start, io.SeekStart)
+	if err != nil {
+		return 0, 0, err
+	}
+	if audioEnd < start {
+		audioEnd = start
+	}
+	return audioEnd - start, end - audioEnd, nil
+}
@@ -0,0 +1,65 @@
+package easyid3
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+func TestRemoveID3(t *testing.T) {
+	v23 := tagBytes(3, 0, frameBytes(3, "TIT2", []byte("\x00Title")))
+	v24 := tagBytes(4, 0x10, frameBytes(4, "TIT2", []byte("\x03Title\x00")))
+	v24 = append(v24, append([]byte("3DI"), v24[3:10]...)...)
+	appended := appendedTagBytes(frameBytes(4, "TPE1", []byte("\x03Artist\x00")))
+
+	tests := []struct {
+		name              string
+		file              []byte
+		seek              bool
+		leading, trailing int
+	}{
+		{"no tag", fakeAudio, true, 0, 0},
+		{"v2.3", append(v23, fakeAudio...), true, len(v23), 0},
+		{"footer", append(v24, fakeAudio...), true, len(v24), 0},
+		{"stacked", append(append(v23, v24...), fakeAudio...), true, len(v23) + len(v24), 0},
+		{"v1", append(append(v23, fakeAudio...), id3v1Bytes("", "", "", "", "", 0, 255)...), true, len(v23), 128},
+		{"appended and v1", append(append(fakeAudio, appended...), id3v1Bytes("", "", "", "", "", 0, 255)...), true, 0, len(appended) + 128},
+		{"stream", append(append(v23, fakeAudio...), id3v1Bytes("", "", "", "", "", 0, 255)...), false, len(v23), 0},
+	}
+	for _, tt := range tests {
+		var r io.Reader = bytes.NewReader(tt.file)
+		if !tt.seek {
+			r = struct{ io.Reader }{r}
+		}
+		var out bytes.Buffer
+		leading, trailing, err := RemoveID3(&out, r)
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if leading != int64(tt.leading) || trailing != int64(tt.trailing) {
+			t.Errorf("%s: removed %d and %d want %d and %d", tt.name, leading, trailing, tt.leading, tt.trailing)
+		}
+		want := tt.file[tt.leading : len(tt.file)-tt.trailing]
+		if !bytes.Equal(out.Bytes(), want) {
+			t.Errorf("%s: wrong audio, %d bytes want %d", tt.name, out.Len(), len(want))
+		}
+	}
+}
+
+func TestRemoveID3OnlyTag(t *testing.T) {
+	v24 := tagBytes(4, 0x10, frameBytes(4, "TIT2", []byte("\x03Title\x00")))
+	v24 = append(v24, append([]byte("3DI"), v24[3:10]...)...)
+	var out bytes.Buffer
+	leading, trailing, err := RemoveID3(&out, bytes.NewReader(v24))
+	if err != nil || leading != int64(len(v24)) || trailing != 0 || out.Len() != 0 {
+		t.Errorf("Removed %d and %d leaving %d bytes: %v", leading, trailing, out.Len(), err)
+	}
+
+	// cut off in the middle of the tag
+	out.Reset()
+	leading, _, err = RemoveID3(&out, bytes.NewReader(v24[:15]))
+	if err != nil || leading != 15 || out.Len() != 0 {
+		t.Errorf("Removed %d leaving %d bytes: %v", leading, out.Len(), err)
+	}
+}
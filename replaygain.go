@@ -0,0 +1,63 @@
+package easyid3
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ReplayGain is the track and album gain in dB and peak as a fraction of
+// full scale, each Has field says whether the tag had that value.
+type ReplayGain struct {
+	TrackGain, TrackPeak, AlbumGain, AlbumPeak             float64
+	HasTrackGain, HasTrackPeak, HasAlbumGain, HasAlbumPeak bool
+}
+
+// ReplayGain reads the replaygain_ TXXX frames, with any casing since
+// taggers don't agree on one. Values they don't have come from the "track"
+// and "album" RVA2 frames when there are some.
+func (t *Tag) ReplayGain() ReplayGain {
+	var rg ReplayGain
+	rg.TrackGain, rg.HasTrackGain = t.userFloat("replaygain_track_gain")
+	rg.TrackPeak, rg.HasTrackPeak = t.userFloat("replaygain_track_peak")
+	rg.AlbumGain, rg.HasAlbumGain = t.userFloat("replaygain_album_gain")
+	rg.AlbumPeak, rg.HasAlbumPeak = t.userFloat("replaygain_album_peak")
+	for _, v := range t.VolumeAdjustments() {
+		master := v.Channel(ChannelMaster)
+		if master == nil {
+			continue
+		}
+		gain, peak, hasPeak := master.Gain, master.Peak, master.PeakBits > 0
+		switch strings.ToLower(v.Identification) {
+		case "track":
+			if !rg.HasTrackGain {
+				rg.TrackGain, rg.HasTrackGain = gain, true
+			}
+			if !rg.HasTrackPeak && hasPeak {
+				rg.TrackPeak, rg.HasTrackPeak = peak, true
+			}
+		case "album":
+			if !rg.HasAlbumGain {
+				rg.AlbumGain, rg.HasAlbumGain = gain, true
+			}
+			if !rg.HasAlbumPeak && hasPeak {
+				rg.AlbumPeak, rg.HasAlbumPeak = peak, true
+			}
+		}
+	}
+	return rg
+}
+
+// userFloat is the number at the start of the TXXX value with the
+// description, so "-6.20 dB" is -6.2
+func (t *Tag) userFloat(desc string) (float64, bool) {
+	value, _ := t.userText(desc)
+	fields := strings.Fields(value)
+	if len(fields) == 0 {
+		return 0, false
+	}
+	n, err := strconv.ParseFloat(strings.TrimSuffix(strings.ToLower(fields[0]), "db"), 64)
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
@@ -0,0 +1,49 @@
+package easyid3
+
+import (
+	"bytes"
+	"math"
+	"testing"
+)
+
+func TestReplayGain(t *testing.T) {
+	tag := tagBytes(4, 0,
+		frameBytes(4, "TXXX", []byte("\x03replaygain_track_gain\x00-6.20 dB\x00")),
+		frameBytes(4, "TXXX", []byte("\x03REPLAYGAIN_TRACK_PEAK\x000.988525\x00")),
+		frameBytes(4, "TXXX", []byte("\x03replaygain_album_gain\x00+1.5dB\x00")),
+		// album peak only from RVA2, the track one is ignored for the TXXX
+		frameBytes(4, "RVA2", []byte("track\x00\x01\xf0\x00\x10\x40\x00")),
+		frameBytes(4, "RVA2", []byte("album\x00\x02\x00\x00\x00\x01\xfc\x00\x10\x80\x00")),
+	)
+	parsed, err := ReadTag(bytes.NewReader(tag))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	rg := parsed.ReplayGain()
+	if !rg.HasTrackGain || rg.TrackGain != -6.2 || !rg.HasTrackPeak || rg.TrackPeak != 0.988525 {
+		t.Errorf("Wrong track gain %+v", rg)
+	}
+	if !rg.HasAlbumGain || rg.AlbumGain != 1.5 || !rg.HasAlbumPeak || math.Abs(rg.AlbumPeak-1) > 1e-6 {
+		t.Errorf("Wrong album gain %+v", rg)
+	}
+
+	tag = tagBytes(4, 0,
+		frameBytes(4, "RVA2", []byte("Track\x00\x01\xf0\x00\x10\x40\x00")),
+		frameBytes(4, "TXXX", []byte("\x03replaygain_album_gain\x00loud\x00")),
+	)
+	parsed, err = ReadTag(bytes.NewReader(tag))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	rg = parsed.ReplayGain()
+	if !rg.HasTrackGain || rg.TrackGain != -8 || !rg.HasTrackPeak || math.Abs(rg.TrackPeak-0.5) > 1e-6 {
+		t.Errorf("Wrong RVA2 track gain %+v", rg)
+	}
+	if rg.HasAlbumGain || rg.HasAlbumPeak {
+		t.Errorf("Expected no album gain %+v", rg)
+	}
+
+	if rg := NewTag().ReplayGain(); rg != (ReplayGain{}) {
+		t.Errorf("Expected nothing got %+v", rg)
+	}
+}
@@ -0,0 +1,70 @@
+package easyid3
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+)
+
+// ScanForID3 is ReadID3 for files with junk in front of the tag. It looks
+// through the first maxSkip bytes for a header and reads the tag from
+// there, skipped is how many bytes came before it.
+func ScanForID3(r io.Reader, maxSkip int, opts ...Option) (props map[string]string, skipped int, err error) {
+	if maxSkip < 0 {
+		maxSkip = 0
+	}
+	br := bufio.NewReaderSize(r, maxSkip+10)
+	start, err := br.Peek(maxSkip + 10)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, bufio.ErrBufferFull) {
+		return nil, 0, err
+	}
+	skipped = findHeader(start, maxSkip)
+	if skipped < 0 {
+		return nil, 0, notFoundError("ID3 header")
+	}
+	_, err = br.Discard(skipped)
+	if err != nil {
+		return nil, 0, err
+	}
+	o := newOptions(opts)
+	header, frames, err := readOneTag(br, o)
+	if err != nil {
+		return nil, skipped, err
+	}
+	return frameMap(frames, o), skipped, header.frameErrors()
+}
+
+// findHeader is the offset of the first header in b that starts no later
+// than maxSkip, -1 when there isn't one
+func findHeader(b []byte, maxSkip int) int {
+	for off := 0; off <= maxSkip; {
+		i := bytes.Index(b[off:], []byte("ID3"))
+		if i < 0 || off+i > maxSkip {
+			return -1
+		}
+		if plausibleHeader(b[off+i:]) {
+			return off + i
+		}
+		off += i + 1
+	}
+	return -1
+}
+
+// plausibleHeader checks the bytes after ID3 could be a header, the string
+// turns up in all sorts of places. The version bytes are never 0xFF and
+// the size is syncsafe.
+func plausibleHeader(b []byte) bool {
+	if len(b) < 10 || string(b[:3]) != "ID3" {
+		return false
+	}
+	if b[3] == 0xff || b[4] == 0xff {
+		return false
+	}
+	for _, c := range b[6:10] {
+		if c >= 0x80 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,38 @@
+package easyid3
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestScanForID3(t *testing.T) {
+	tag := tagBytes(4, 0, frameBytes(4, "TIT2", []byte("\x03Title\x00")))
+	junk := append(make([]byte, 300), []byte("xxID3\xff\x00\x00\x00\x00\x00ID3\x04\x00\x00\x80\x00\x00\x00")...)
+	file := append(append(append([]byte{}, junk...), tag...), fakeAudio...)
+
+	props, skipped, err := ScanForID3(bytes.NewReader(file), 1024)
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	if skipped != len(junk) || props["TIT2"] != "Title" {
+		t.Errorf("Skipped %d want %d got %v", skipped, len(junk), props)
+	}
+
+	// right at the limit
+	_, skipped, err = ScanForID3(bytes.NewReader(file), len(junk))
+	if err != nil || skipped != len(junk) {
+		t.Errorf("Skipped %d: %v", skipped, err)
+	}
+	if _, _, err := ScanForID3(bytes.NewReader(file), len(junk)-1); !errors.Is(err, ErrNoTag) {
+		t.Errorf("Expected ErrNoTag got %v", err)
+	}
+
+	props, skipped, err = ScanForID3(bytes.NewReader(tag), 0)
+	if err != nil || skipped != 0 || props["TIT2"] != "Title" {
+		t.Errorf("Got %v skipping %d: %v", props, skipped, err)
+	}
+	if _, _, err := ScanForID3(bytes.NewReader(fakeAudio), 1024); !errors.Is(err, ErrNoTag) {
+		t.Errorf("Expected ErrNoTag got %v", err)
+	}
+}
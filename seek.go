@@ -0,0 +1,62 @@
+package easyid3
+
+import (
+	"fmt"
+	"io"
+)
+
+// seekOffset is the offset from the end of the tag to the next one as given
+// by the SEEK frame
+func seekOffset(frames []*Frame) (int64, bool) {
+	for _, f := range frames {
+		if f.FrameID == "SEEK" && len(f.Data) >= 4 {
+			return int64(synsafeInt(f.Data[:4])), true
+		}
+	}
+	return 0, false
+}
+
+// followSeek reads the tags SEEK frames point at, the depth limit stops
+// files that point back at themselves from going forever.
+func followSeek(rs io.ReadSeeker, start int64, header *Header, frames []*Frame, o *options) (*Header, []*Frame, error) {
+	latest, latestHeader := frames, header
+	for depth := 0; depth < o.seekDepth; depth++ {
+		offset, ok := seekOffset(latest)
+		if !ok {
+			break
+		}
+		start += int64(latestHeader.TotalSize()) + offset
+		_, err := rs.Seek(start, io.SeekStart)
+		if err != nil {
+			return nil, nil, err
+		}
+		latestHeader, latest, err = readOneTag(rs, o)
+		if err != nil {
+			header.removeSpilled()
+			return nil, nil, fmt.Errorf("reading the tag SEEK points to at %d: %w", start, err)
+		}
+		frames = mergeFrames(frames, latest)
+		header.skipped = append(header.skipped, latestHeader.skipped...)
+		header.errors = append(header.errors, latestHeader.errors...)
+		header.reinterpreted = append(header.reinterpreted, latestHeader.reinterpreted...)
+		header.lazy = append(header.lazy, latestHeader.lazy...)
+		header.spilled = append(header.spilled, latestHeader.spilled...)
+	}
+	return header, frames, nil
+}
+
+// mergeFrames replaces frames with the updates for the same key and adds
+// the ones that are new
+func mergeFrames(frames, updates []*Frame) []*Frame {
+	updated := map[string]bool{}
+	for _, f := range updates {
+		updated[f.Key()] = true
+	}
+	var merged []*Frame
+	for _, f := range frames {
+		if !updated[f.Key()] {
+			merged = append(merged, f)
+		}
+	}
+	return append(merged, updates...)
+}
@@ -0,0 +1,83 @@
+package easyid3
+
+import (
+	"bytes"
+	"testing"
+)
+
+func seekFrame(offset int) []byte {
+	return frameBytes(4, "SEEK", synsafeBytes(offset))
+}
+
+func TestFollowSeek(t *testing.T) {
+	original := tagBytes(4, 0,
+		frameBytes(4, "TIT2", []byte("\x03Old title\x00")),
+		frameBytes(4, "TPE1", []byte("\x03Artist\x00")),
+		frameBytes(4, "TXXX", []byte("\x03kept\x00yes\x00")),
+		frameBytes(4, "TXXX", []byte("\x03changed\x00no\x00")),
+		seekFrame(len(fakeAudio)),
+	)
+	update := appendedTagBytes(
+		frameBytes(4, "TIT2", []byte("\x03New title\x00")),
+		frameBytes(4, "TXXX", []byte("\x03changed\x00yes\x00")),
+		frameBytes(4, "TALB", []byte("\x03Album\x00")),
+	)
+	file := append(append(append([]byte{}, original...), fakeAudio...), update...)
+	// something in front to make sure offsets are from where the tag starts
+	junk := []byte("junk")
+	r := bytes.NewReader(append(append([]byte{}, junk...), file...))
+	r.Seek(int64(len(junk)), 0)
+	vals, err := ReadID3(r, WithFollowSeek(4))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	expected := map[string]string{
+		"TIT2":         "New title",
+		"TPE1":         "Artist",
+		"TALB":         "Album",
+		"TXXX:kept":    "yes",
+		"TXXX:changed": "yes",
+	}
+	for k, v := range expected {
+		if vals[k] != v {
+			t.Errorf("Wrong value for %s expected %q got %q", k, v, vals[k])
+		}
+	}
+
+	// not followed unless asked
+	vals, err = ReadID3(bytes.NewReader(file))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	if vals["TIT2"] != "Old title" {
+		t.Errorf("Expected the original title got %q", vals["TIT2"])
+	}
+
+	parsed, err := ReadTag(bytes.NewReader(file))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	if offset, ok := parsed.SeekOffset(); !ok || offset != int64(len(fakeAudio)) {
+		t.Errorf("Wrong seek offset %d %v", offset, ok)
+	}
+}
+
+func TestSeekDepth(t *testing.T) {
+	// a chain of tags each pointing at the next, only depth of them are read
+	var file []byte
+	for i := 0; i < 10; i++ {
+		file = append(file, tagBytes(4, 0, frameBytes(4, "TRCK", []byte{3, '0' + byte(i), 0}), seekFrame(0))...)
+	}
+	vals, err := ReadID3(bytes.NewReader(file), WithFollowSeek(3))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	if vals["TRCK"] != "3" {
+		t.Fatalf("Expected to stop at the 4th tag got %q", vals["TRCK"])
+	}
+
+	// pointing past the end of the file is an error when following
+	if _, err := ReadID3(bytes.NewReader(file[:len(file)/2]), WithFollowSeek(10)); err == nil {
+		t.Fatal("Expected an error for a SEEK past the end")
+	}
+}
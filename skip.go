@@ -0,0 +1,50 @@
+package easyid3
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// SkipID3 skips the ID3v2 tag at the start of r including its footer,
+// leaving r at the first byte of audio. skipped is how many bytes that was,
+// 0 when r doesn't start with a tag and nothing is taken out of it. Looking
+// for the tag has to give the bytes back so r has to be a *bufio.Reader or
+// an io.ReadSeeker, wrap anything else in a bufio.Reader and keep reading
+// from that.
+func SkipID3(r io.Reader) (skipped int64, err error) {
+	var raw []byte
+	switch r := r.(type) {
+	case *bufio.Reader:
+		raw, err = r.Peek(10)
+		if err != nil && !errors.Is(err, io.EOF) {
+			return 0, err
+		}
+	case io.ReadSeeker:
+		raw = make([]byte, 10)
+		n, err := io.ReadFull(r, raw)
+		if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+			return 0, err
+		}
+		raw = raw[:n]
+		_, err = r.Seek(int64(-n), io.SeekCurrent)
+		if err != nil {
+			return 0, err
+		}
+	default:
+		return 0, fmt.Errorf("SkipID3 can't give back what it reads from a %T, use a bufio.Reader", r)
+	}
+	if len(raw) < 10 || string(raw[:3]) != "ID3" {
+		return 0, nil
+	}
+	header, err := newID3(raw)
+	if err != nil {
+		return 0, nil
+	}
+	skipped, err = io.CopyN(io.Discard, r, int64(header.TotalSize()))
+	if errors.Is(err, io.EOF) {
+		return skipped, truncated("tag is %d bytes but the stream ended after %d", header.TotalSize(), skipped)
+	}
+	return skipped, err
+}
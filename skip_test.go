@@ -0,0 +1,52 @@
+package easyid3
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestSkipID3(t *testing.T) {
+	v23 := tagBytes(3, 0, frameBytes(3, "TIT2", []byte("\x00Title")))
+	v24 := tagBytes(4, 0x10, frameBytes(4, "TIT2", []byte("\x03Title\x00")))
+	v24 = append(v24, append([]byte("3DI"), v24[3:10]...)...)
+	for _, tt := range []struct {
+		name string
+		file []byte
+		tag  int
+	}{
+		{"v2.3", append(append([]byte{}, v23...), fakeAudio...), len(v23)},
+		{"footer", append(append([]byte{}, v24...), fakeAudio...), len(v24)},
+		{"no tag", fakeAudio, 0},
+		{"short", []byte("ID3"), 0},
+	} {
+		readers := map[string]io.Reader{
+			"bufio":  bufio.NewReader(bytes.NewReader(tt.file)),
+			"seeker": bytes.NewReader(tt.file),
+		}
+		for kind, r := range readers {
+			skipped, err := SkipID3(r)
+			if err != nil || skipped != int64(tt.tag) {
+				t.Errorf("%s %s: skipped %d want %d: %v", tt.name, kind, skipped, tt.tag, err)
+				continue
+			}
+			rest, err := io.ReadAll(r)
+			if err != nil || !bytes.Equal(rest, tt.file[tt.tag:]) {
+				t.Errorf("%s %s: lost bytes, %d left want %d", tt.name, kind, len(rest), len(tt.file)-tt.tag)
+			}
+		}
+	}
+}
+
+func TestSkipID3Errors(t *testing.T) {
+	v23 := tagBytes(3, 0, frameBytes(3, "TIT2", []byte("\x00Title")))
+	if _, err := SkipID3(struct{ io.Reader }{bytes.NewReader(v23)}); err == nil {
+		t.Error("Expected an error for a reader that can't give bytes back")
+	}
+	skipped, err := SkipID3(bufio.NewReader(bytes.NewReader(v23[:15])))
+	if !errors.Is(err, io.ErrUnexpectedEOF) || skipped != 15 {
+		t.Errorf("Expected a truncated tag error got %d %v", skipped, err)
+	}
+}
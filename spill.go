@@ -0,0 +1,118 @@
+package easyid3
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+)
+
+// SpillFunc is where WithSpill puts the data of a big frame. It reads r to
+// the end and returns what the SpilledFrame keeps to find the data again,
+// a path or a key. Only the header of f is set.
+type SpillFunc func(f *Frame, r io.Reader) (ref string, err error)
+
+// SpilledFrame is a frame WithSpill wrote out instead of keeping. Size is
+// how much data was written and Offset is where the frame is in the tag.
+type SpilledFrame struct {
+	FrameID string
+	Size    int
+	Offset  int64
+	Ref     string
+
+	// temp is a file WithSpillToTemp made, Close removes it
+	temp bool
+}
+
+// Spilled are the frames WithSpill wrote out, in the order they are in the
+// tag
+func (t *Tag) Spilled() []SpilledFrame {
+	return t.header.spilled
+}
+
+// Close removes the temporary files WithSpillToTemp wrote the tag's frames
+// to. What a WithSpill SpillFunc wrote is left for the caller to clean up.
+// A tag that didn't spill anything has nothing to close.
+func (t *Tag) Close() error {
+	return t.header.removeSpilled()
+}
+
+// removeSpilled removes the temporary files, the first error is returned
+func (h *Header) removeSpilled() error {
+	var first error
+	for _, sf := range h.spilled {
+		if !sf.temp {
+			continue
+		}
+		err := os.Remove(sf.Ref)
+		if err != nil && !errors.Is(err, os.ErrNotExist) && first == nil {
+			first = err
+		}
+	}
+	return first
+}
+
+// spillToTemp writes each frame to its own file in dir, the ref is its
+// path
+func spillToTemp(dir string) SpillFunc {
+	return func(f *Frame, r io.Reader) (string, error) {
+		tmp, err := os.CreateTemp(dir, "easyid3-"+f.FrameID+"-*")
+		if err != nil {
+			return "", err
+		}
+		_, err = io.Copy(tmp, r)
+		if cerr := tmp.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			os.Remove(tmp.Name())
+			return "", err
+		}
+		return tmp.Name(), nil
+	}
+}
+
+// spillFrame streams the data of the frame whose header was just read to
+// the spill and keeps it on the header. ok is false when the frame has
+// format flags to undo first, those are read as normal.
+func spillFrame(body *io.LimitedReader, header *Header, frame *Frame, o *options) (ok bool, err error) {
+	formatted := len(frame.Flags) > 1 && frame.Flags[1] != 0
+	if formatted || (frame.Version == 4 && header.Unsynchronisation()) {
+		return false, nil
+	}
+	data := &io.LimitedReader{R: body, N: int64(frame.Size)}
+	var r io.Reader = data
+	if frame.Version == 2 && !o.originalIDs && frame.FrameID == "APIC" {
+		// PIC only needs its image format turning into a MIME type
+		head := make([]byte, 4)
+		n, _ := io.ReadFull(data, head)
+		r = io.MultiReader(bytes.NewReader(picToAPIC(head[:n])), data)
+	}
+	counted := &countingReader{r: r}
+	ref, err := o.spill(frame, counted)
+	if err != nil {
+		return false, frame.errorf("spilling: %w", err)
+	}
+	sf := SpilledFrame{FrameID: frame.FrameID, Size: counted.n, Offset: frame.offset, Ref: ref, temp: o.spillTemp}
+	header.spilled = append(header.spilled, sf)
+	// the spill might not have wanted all of it
+	if _, err := io.Copy(io.Discard, data); err != nil {
+		return false, err
+	}
+	if data.N > 0 {
+		return true, o.tolerate(header, frame.wrapError(truncated("spilling, expected %d bytes read %d", frame.Size, int64(frame.Size)-data.N)))
+	}
+	return true, nil
+}
+
+// countingReader counts the bytes read through it
+type countingReader struct {
+	r io.Reader
+	n int
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += n
+	return n, err
+}
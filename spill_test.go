@@ -0,0 +1,123 @@
+package easyid3
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestWithSpillToTemp(t *testing.T) {
+	tag := lazyTag()
+	eager, err := ReadTag(bytes.NewReader(tag))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	dir := t.TempDir()
+	// a stream there's no seeking back in
+	parsed, err := ReadTag(io.MultiReader(bytes.NewReader(tag)), WithSpillToTemp(1024, dir))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	if parsed.Title() != "Title" || parsed.Artist() != "Artist" || len(parsed.frames) != 2 {
+		t.Errorf("Wrong frames %v", parsed.frames)
+	}
+	spilled := parsed.Spilled()
+	if len(spilled) != 2 || spilled[0].FrameID != "APIC" || spilled[1].FrameID != "GEOB" {
+		t.Fatalf("Wrong spilled frames %+v", spilled)
+	}
+	for i, sf := range spilled {
+		want := eager.frames[i+1]
+		data, err := os.ReadFile(sf.Ref)
+		if err != nil || !bytes.Equal(data, want.Data) {
+			t.Errorf("%s: Wrong data %d bytes %v", sf.FrameID, len(data), err)
+		}
+		if sf.Size != len(want.Data) || sf.Offset != want.offset || !strings.HasPrefix(sf.Ref, dir) {
+			t.Errorf("Wrong spilled frame %+v", sf)
+		}
+	}
+
+	if err := parsed.Close(); err != nil {
+		t.Errorf("Failed close: %v", err)
+	}
+	if left, _ := os.ReadDir(dir); len(left) != 0 {
+		t.Errorf("Expected the files removed got %v", left)
+	}
+	if err := parsed.Close(); err != nil {
+		t.Errorf("Expected closing again to do nothing got %v", err)
+	}
+	if err := NewTag().Close(); err != nil {
+		t.Errorf("Expected nothing to close got %v", err)
+	}
+
+	// what's read without a Tag has nowhere to keep them
+	props, err := ReadID3(bytes.NewReader(tag), WithSpillToTemp(1024, dir))
+	if err != nil || props["TIT2"] != "Title" {
+		t.Errorf("Wrong map %v %v", props, err)
+	}
+	if left, _ := os.ReadDir(dir); len(left) != 0 {
+		t.Errorf("Expected nothing spilled got %v", left)
+	}
+}
+
+func TestWithSpill(t *testing.T) {
+	spilled := map[string][]byte{}
+	spill := func(f *Frame, r io.Reader) (string, error) {
+		data, err := io.ReadAll(r)
+		key := f.FrameID + "-1"
+		spilled[key] = data
+		return key, err
+	}
+	pic := append([]byte("\x00PNG\x03\x00"), bytes.Repeat([]byte{1}, 256)...)
+	tag := tagBytes(2, 0, frameBytes(2, "TT2", []byte("\x00Title")), frameBytes(2, "PIC", pic))
+	parsed, err := ReadTag(bytes.NewReader(tag), WithSpill(100, spill))
+	if err != nil || parsed.Title() != "Title" || len(parsed.Spilled()) != 1 {
+		t.Fatalf("Wrong spilled read %v %v", parsed, err)
+	}
+	sf := parsed.Spilled()[0]
+	if sf.FrameID != "APIC" || sf.Ref != "APIC-1" || sf.Size != len(spilled["APIC-1"]) {
+		t.Errorf("Wrong spilled frame %+v", sf)
+	}
+	if p := parsePicture(spilled["APIC-1"], false); p.MIMEType != "image/png" || len(p.Data) != 256 {
+		t.Errorf("Wrong v2.2 picture %+v", p)
+	}
+	if err := parsed.Close(); err != nil || len(spilled) != 1 {
+		t.Errorf("Expected Close to leave the spill alone %v", err)
+	}
+
+	// a data length indicator has to be undone first
+	art := bytes.Repeat([]byte{2}, 256)
+	apic := apicData(0, "image/png", PictureTypeFrontCover, []byte("\x00"), art)
+	flagged := append(synsafeBytes(len(apic)), apic...)
+	parsed, err = ReadTag(bytes.NewReader(tagBytes(4, 0, flaggedFrameBytes(4, "APIC", 0, 0x01, flagged))), WithSpill(100, spill))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	if len(parsed.Spilled()) != 0 || len(parsed.Pictures()) != 1 {
+		t.Errorf("Expected the flagged frame read as normal %v", parsed.Spilled())
+	}
+
+	failed := errors.New("disk full")
+	_, err = ReadTag(bytes.NewReader(lazyTag()), WithSpill(1024, func(*Frame, io.Reader) (string, error) {
+		return "", failed
+	}))
+	if !errors.Is(err, failed) {
+		t.Errorf("Expected the spill error got %v", err)
+	}
+}
+
+func TestSpillCleanUp(t *testing.T) {
+	dir := t.TempDir()
+	art := bytes.Repeat([]byte{0xaa}, 4096)
+	// the last frame says it's bigger than what's left
+	broken := append([]byte("TPE1\x00\x00\x00\x64\x00\x00"), "\x03abc"...)
+	tag := tagBytes(4, 0, frameBytes(4, "APIC", apicData(0, "image/png", PictureTypeFrontCover, []byte("\x00"), art)), broken)
+	if _, err := ReadTag(bytes.NewReader(tag), WithSpillToTemp(1024, dir)); err == nil {
+		t.Fatal("Expected an error")
+	}
+	if left, _ := os.ReadDir(dir); len(left) != 0 {
+		t.Errorf("Expected the files removed after the error got %v", left)
+	}
+}
@@ -0,0 +1,85 @@
+package easyid3
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SpecError is something WithStrict found that breaks the spec. Offset is
+// counted from the start of the tag, FrameID is empty when it's not about
+// a frame.
+type SpecError struct {
+	Offset  int64
+	FrameID string
+	Reason  string
+}
+
+func (e *SpecError) Error() string {
+	if e.FrameID == "" {
+		return fmt.Sprintf("at offset %d: %s", e.Offset, e.Reason)
+	}
+	return fmt.Sprintf("frame %s at offset %d: %s", e.FrameID, e.Offset, e.Reason)
+}
+
+// violation is a SpecError for f
+func (f *Frame) violation(format string, args ...interface{}) error {
+	return &SpecError{Offset: f.offset, FrameID: f.FrameID, Reason: fmt.Sprintf(format, args...)}
+}
+
+// checkFrameHeader is what WithStrict checks before reading the frame.
+// raw is the frame header as it was in the tag.
+func checkFrameHeader(f *Frame, raw []byte) error {
+	if f.Version == 4 {
+		for _, b := range raw[4:8] {
+			if b&0x80 != 0 {
+				return f.violation("size isn't syncsafe")
+			}
+		}
+		if info, ok := LookupFrame(f.FrameID); ok && !info.V24 {
+			return f.violation("deprecated in v2.4")
+		}
+	}
+	return nil
+}
+
+// checkFrame is what WithStrict checks once the frame is read, text frames
+// need a known encoding and the terminator the spec asks for and ETCO
+// events have to be in time order
+func checkFrame(f *Frame) error {
+	if f.FrameID == "ETCO" && parseEventTimingCodes(f.Data, DefaultMPEGFrameDuration).OutOfOrder {
+		return f.violation("events aren't in time order")
+	}
+	if !strings.HasPrefix(f.FrameID, "T") || f.FrameID == "TXXX" || f.FrameID == "TXX" {
+		return nil
+	}
+	if len(f.Data) == 0 {
+		return f.violation("text frame has no encoding")
+	}
+	enc, text := f.Data[0], f.Data[1:]
+	switch enc {
+	case encodingISO88591, encodingUTF16:
+	case encodingUTF16BE, encodingUTF8:
+		if f.Version < 4 {
+			return f.violation("encoding %d is v2.4 only", enc)
+		}
+	default:
+		return f.violation("unknown encoding %d", enc)
+	}
+	terminator := "\x00"
+	if enc == encodingUTF16 || enc == encodingUTF16BE {
+		terminator = "\x00\x00"
+	}
+	if !strings.HasSuffix(string(text), terminator) {
+		return f.violation("text isn't terminated")
+	}
+	return nil
+}
+
+// checkExtendedHeader is whether the extended header size matches what
+// its flags say is in it
+func checkExtendedHeader(ext *ExtendedHeader, used int) error {
+	if ext.Size != used {
+		return &SpecError{Offset: 10, Reason: fmt.Sprintf("extended header size is %d but its flags take %d", ext.Size, used)}
+	}
+	return nil
+}
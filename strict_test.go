@@ -0,0 +1,57 @@
+package easyid3
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestStrict(t *testing.T) {
+	title := frameBytes(4, "TIT2", []byte("\x03Title\x00"))
+	// a v2.3 size of 128 that isn't syncsafe
+	unsafeSize := append([]byte("TPE1\x00\x00\x00\x80\x00\x00\x03"), bytes.Repeat([]byte{'a'}, 126)...)
+	unsafeSize = append(unsafeSize, 0)
+	for name, tc := range map[string]struct {
+		tag     []byte
+		offset  int64
+		frameID string
+	}{
+		"frameID":      {tagBytes(4, 0, title, frameBytes(4, "TPe1", []byte("\x03Artist\x00"))), int64(10 + len(title)), "TPe1"},
+		"deprecated":   {tagBytes(4, 0, title, frameBytes(4, "TYER", []byte("\x031999\x00"))), int64(10 + len(title)), "TYER"},
+		"terminator":   {tagBytes(4, 0, frameBytes(4, "TIT2", []byte("\x03Title"))), 10, "TIT2"},
+		"utf16":        {tagBytes(4, 0, frameBytes(4, "TIT2", []byte("\x01\xff\xfeT\x00"))), 10, "TIT2"},
+		"encoding":     {tagBytes(3, 0, frameBytes(3, "TIT2", []byte("\x03Title\x00"))), 10, "TIT2"},
+		"unknown":      {tagBytes(4, 0, frameBytes(4, "TIT2", []byte("\x07Title\x00"))), 10, "TIT2"},
+		"syncsafe":     {tagBytes(4, 0, title, unsafeSize), int64(10 + len(title)), "TPE1"},
+		"extendedSize": {tagBytes(4, 0x40, append(synsafeBytes(10), 1, 0, 0, 0, 0, 0), title), 10, ""},
+	} {
+		if _, err := ReadID3(bytes.NewReader(tc.tag)); err != nil {
+			t.Errorf("%s: expected the default to read it got %v", name, err)
+		}
+		_, err := ReadID3(bytes.NewReader(tc.tag), WithStrict())
+		var se *SpecError
+		if !errors.As(err, &se) {
+			t.Errorf("%s: expected a SpecError got %v", name, err)
+			continue
+		}
+		if se.Offset != tc.offset || se.FrameID != tc.frameID {
+			t.Errorf("%s: wrong error %+v", name, se)
+		}
+	}
+
+	valid := tagBytes(4, 0x40, v24ExtendedHeader(true, nil, nil), title, frameBytes(4, "TXXX", []byte("\x03desc\x00value")), make([]byte, 8))
+	if _, err := ReadID3(bytes.NewReader(valid), WithStrict()); err != nil {
+		t.Errorf("Expected a valid tag to read got %v", err)
+	}
+
+	// strict and lenient reports everything
+	tag := tagBytes(4, 0, frameBytes(4, "TYER", []byte("\x031999")), title)
+	props, err := ReadID3(bytes.NewReader(tag), WithStrict(), WithLenient())
+	var list ErrorList
+	if !errors.As(err, &list) || len(list) != 2 {
+		t.Fatalf("Expected 2 errors got %v", err)
+	}
+	if props["TYER"] != "1999" || props["TIT2"] != "Title" {
+		t.Errorf("Wrong props %v", props)
+	}
+}
@@ -0,0 +1,285 @@
+package easyid3
+
+import "io"
+
+// Tag is a parsed ID3v2 tag that keeps every frame in the order they were
+// read so the structured frames can be pulled back out of it.
+type Tag struct {
+	header *Header
+	frames []*Frame
+	opts   *options
+}
+
+// ReadTag reads the tag the same way as ReadID3 but returns all the frames
+// instead of flattening them into a map.
+func ReadTag(rdr io.Reader, opts ...Option) (*Tag, error) {
+	o := newOptions(opts)
+	o.tagResult = true
+	header, frames, err := readTag(rdr, o)
+	if err != nil {
+		return nil, err
+	}
+	return &Tag{header: header, frames: frames, opts: o}, header.frameErrors()
+}
+
+// options are the options the tag was read with, the defaults for a tag
+// that wasn't read
+func (t *Tag) options() *options {
+	if t.opts == nil {
+		return newOptions(nil)
+	}
+	return t.opts
+}
+
+// Header is the header the tag was read with, for a new tag it's the v2.4
+// header it will be written with
+func (t *Tag) Header() *Header {
+	return t.header
+}
+
+// ExtendedHeader is the tag's extended header or nil when it doesn't have one
+func (t *Tag) ExtendedHeader() *ExtendedHeader {
+	return t.header.extended
+}
+
+// Restrictions are the tag restrictions from the extended header or nil
+// when there aren't any
+func (t *Tag) Restrictions() *Restrictions {
+	eh := t.header.extended
+	if eh == nil || !eh.HasRestrictions {
+		return nil
+	}
+	r := ParseRestrictions(eh.Restrictions)
+	return &r
+}
+
+// SeekOffset is where the SEEK frame says the next tag is, counted from
+// the end of this one. ok is false without a SEEK frame.
+func (t *Tag) SeekOffset() (offset int64, ok bool) {
+	return seekOffset(t.frames)
+}
+
+// Comments returns the COMM frames in any of the languages, all of them
+// when there aren't any
+func (t *Tag) Comments(langs ...Language) []Comment {
+	var comments []Comment
+	for _, f := range t.find("COMM", "COM") {
+		if c := parseComment(f.Data); inLanguages(c.Language, langs) {
+			comments = append(comments, c)
+		}
+	}
+	return comments
+}
+
+// Lyrics returns the USLT frames in any of the languages like Comments
+func (t *Tag) Lyrics(langs ...Language) []Lyrics {
+	var lyrics []Lyrics
+	for _, f := range t.find("USLT", "ULT") {
+		if l := parseLyrics(f.Data); inLanguages(l.Language, langs) {
+			lyrics = append(lyrics, l)
+		}
+	}
+	return lyrics
+}
+
+// SyncedLyrics returns the SYLT frames in any of the languages like
+// Comments
+func (t *Tag) SyncedLyrics(langs ...Language) []SyncedLyrics {
+	var lyrics []SyncedLyrics
+	for _, f := range t.find("SYLT", "SLT") {
+		if l := parseSyncedLyrics(f.Data, t.options().mpegFrameDuration); inLanguages(l.Language, langs) {
+			lyrics = append(lyrics, l)
+		}
+	}
+	return lyrics
+}
+
+// Pictures returns all the embedded images
+func (t *Tag) Pictures() Pictures {
+	var pictures Pictures
+	for _, f := range t.find("APIC", "PIC") {
+		pictures = append(pictures, parsePicture(f.Data, f.FrameID == "PIC"))
+	}
+	return pictures
+}
+
+// Chapters returns the CHAP frames ordered by the table of contents
+func (t *Tag) Chapters() []Chapter {
+	var chapters []Chapter
+	o := t.options()
+	for _, f := range t.find("CHAP") {
+		chapters = append(chapters, parseChapter(f.Data, f.Version, o))
+	}
+	return orderChapters(chapters, t.TablesOfContents())
+}
+
+// TablesOfContents returns all the CTOC frames
+func (t *Tag) TablesOfContents() []TableOfContents {
+	var tocs []TableOfContents
+	o := t.options()
+	for _, f := range t.find("CTOC") {
+		tocs = append(tocs, parseTableOfContents(f.Data, f.Version, o))
+	}
+	return tocs
+}
+
+// Popularimeters returns all the POPM ratings
+func (t *Tag) Popularimeters() []Popularimeter {
+	var ratings []Popularimeter
+	for _, f := range t.find("POPM", "POP") {
+		ratings = append(ratings, parsePopularimeter(f.Data))
+	}
+	return ratings
+}
+
+// PlayCount is the PCNT counter, ok is false when there isn't one
+func (t *Tag) PlayCount() (count uint64, ok bool, err error) {
+	frames := t.find("PCNT", "CNT")
+	if len(frames) == 0 {
+		return 0, false, nil
+	}
+	count, err = parseCounter(frames[0].Data)
+	if err != nil {
+		return 0, false, err
+	}
+	return count, true, nil
+}
+
+// UniqueFileIDs returns all the UFID frames
+func (t *Tag) UniqueFileIDs() []UniqueFileID {
+	var ids []UniqueFileID
+	for _, f := range t.find("UFID", "UFI") {
+		ids = append(ids, parseUniqueFileID(f.Data))
+	}
+	return ids
+}
+
+// MusicBrainzRecordingID is the identifier of the MusicBrainz UFID frame or
+// empty when there isn't one.
+func (t *Tag) MusicBrainzRecordingID() string {
+	for _, id := range t.UniqueFileIDs() {
+		if id.Owner == MusicBrainzOwner {
+			return string(id.Identifier)
+		}
+	}
+	return ""
+}
+
+// Private returns all the PRIV frames
+func (t *Tag) Private() []Private {
+	var private []Private
+	for _, f := range t.find("PRIV") {
+		private = append(private, parsePrivate(f.Data))
+	}
+	return private
+}
+
+// Objects returns all the GEOB frames
+func (t *Tag) Objects() []Object {
+	var objects []Object
+	for _, f := range t.find("GEOB", "GEO") {
+		objects = append(objects, parseObject(f.Data))
+	}
+	return objects
+}
+
+// Object is the first GEOB frame with the description or nil
+func (t *Tag) Object(description string) *Object {
+	for _, o := range t.Objects() {
+		if o.Description == description {
+			return &o
+		}
+	}
+	return nil
+}
+
+// Encryptions returns all the ENCR frames
+func (t *Tag) Encryptions() []Encryption {
+	var encryptions []Encryption
+	for _, f := range t.find("ENCR") {
+		encryptions = append(encryptions, parseEncryption(f.Data))
+	}
+	return encryptions
+}
+
+// Groups returns all the GRID frames
+func (t *Tag) Groups() []Group {
+	var groups []Group
+	for _, f := range t.find("GRID") {
+		groups = append(groups, parseGroup(f.Data))
+	}
+	return groups
+}
+
+// Group is the GRID frame registering the group symbol or nil, use it to
+// find the owner of a frame's GroupID.
+func (t *Tag) Group(symbol byte) *Group {
+	for _, g := range t.Groups() {
+		if g.Symbol == symbol {
+			return &g
+		}
+	}
+	return nil
+}
+
+// SkippedFrame is a frame that was left out of the tag, either because it
+// couldn't be decrypted or it was too big to read with
+// WithMaxInlineFrameSize. Offset is where the frame header starts counting
+// from the start of the tag header. Data is the still encrypted data, nil
+// for big frames, and Err is why they couldn't be decrypted.
+type SkippedFrame struct {
+	FrameID          string
+	Size             int
+	Offset           int64
+	EncryptionMethod byte
+	Data             []byte
+	Err              error
+}
+
+// Reinterpreted is the keys of the frames WithDetectUTF8 read as UTF-8
+// even though they said they were ISO-8859-1
+func (t *Tag) Reinterpreted() []string {
+	return t.header.reinterpreted
+}
+
+// BinaryData is the data of the binary frames with the ID exactly as it
+// is once the format flags are undone, for frames like MCDI, SIGN or AENC
+// the package doesn't decode
+func (t *Tag) BinaryData(id string) [][]byte {
+	var data [][]byte
+	for _, f := range t.find(id) {
+		if f.Kind() == KindBinary {
+			data = append(data, f.Data)
+		}
+	}
+	return data
+}
+
+// MusicCDID is the CD table of contents in MCDI, nil when there isn't one
+func (t *Tag) MusicCDID() []byte {
+	data := t.BinaryData(FrameMusicCDID)
+	if len(data) == 0 {
+		return nil
+	}
+	return data[0]
+}
+
+// Skipped returns the frames that were left out because they couldn't be
+// decrypted or were too big
+func (t *Tag) Skipped() []SkippedFrame {
+	return t.header.skipped
+}
+
+// find returns the frames matching any of the IDs
+func (t *Tag) find(ids ...string) []*Frame {
+	var found []*Frame
+	for _, f := range t.frames {
+		for _, id := range ids {
+			if f.FrameID == id {
+				found = append(found, f)
+				break
+			}
+		}
+	}
+	return found
+}
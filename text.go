@@ -0,0 +1,48 @@
+package easyid3
+
+import "bytes"
+
+// parseUserText splits a TXXX frame into its description and value, both
+// share the encoding byte at the front.
+func parseUserText(data []byte) (string, string) {
+	if len(data) == 0 {
+		return "", ""
+	}
+	desc, value := splitTerminated(data[0], data[1:])
+	return decodeText(data[0], desc), decodeText(data[0], value)
+}
+
+// splitValues decodes each of the terminated strings in b, empty ones at
+// the end are dropped
+func splitValues(enc byte, b []byte) []string {
+	var values []string
+	for len(b) > 0 {
+		var value []byte
+		value, b = splitTerminated(enc, b)
+		values = append(values, decodeText(enc, value))
+	}
+	for len(values) > 0 && values[len(values)-1] == "" {
+		values = values[:len(values)-1]
+	}
+	return values
+}
+
+// singleValue is the value when there's only one, ok is false when there
+// are more and splitValues is needed
+func singleValue(enc byte, b []byte) (string, bool) {
+	value, rest := splitTerminated(enc, b)
+	if len(bytes.Trim(rest, "\x00")) > 0 {
+		return "", false
+	}
+	return decodeText(enc, value), true
+}
+
+// parseUserURL splits a WXXX frame into its description and URL. The
+// description follows the encoding byte but the URL is always ISO-8859-1.
+func parseUserURL(data []byte) (string, string) {
+	if len(data) == 0 {
+		return "", ""
+	}
+	desc, url := splitTerminated(data[0], data[1:])
+	return decodeText(data[0], desc), decodeLatin1(trimNull(url))
+}
@@ -0,0 +1,117 @@
+package easyid3
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestTXXX(t *testing.T) {
+	utf16Gain := []byte{0x1, 0xff, 0xfe, 'G', 0x0, 'a', 0x0, 'i', 0x0, 'n', 0x0, 0x0, 0x0, 0xff, 0xfe, '-', 0x0, '6', 0x0, '.', 0x0, '2', 0x0, ' ', 0x0, 'd', 0x0, 'B', 0x0, 0x0, 0x0}
+	tag := tagBytes(4, 0,
+		frameBytes(4, "TXXX", []byte("\x03replaygain_track_gain\x00-6.2 dB\x00")),
+		frameBytes(4, "TXXX", []byte("\x03MusicBrainz Album Id\x00e7a8a1a0-0e9b-4c65-9a2b-0d4c4f3b2d1a")),
+		frameBytes(4, "TXXX", []byte("\x00Acoustid Id\x00c9f1\x00")),
+		frameBytes(4, "TXXX", utf16Gain),
+		frameBytes(4, "TXXX", []byte("\x03\x00no description")),
+	)
+	vals, err := ReadID3(bytes.NewReader(tag))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	expected := map[string]string{
+		"TXXX:replaygain_track_gain": "-6.2 dB",
+		"TXXX:MusicBrainz Album Id":  "e7a8a1a0-0e9b-4c65-9a2b-0d4c4f3b2d1a",
+		"TXXX:Acoustid Id":           "c9f1",
+		"TXXX:Gain":                  "-6.2 dB",
+		"TXXX:":                      "no description",
+	}
+	for k, v := range expected {
+		if vals[k] != v {
+			t.Errorf("Wrong value for %s expected %q got %q", k, v, vals[k])
+		}
+	}
+	if len(vals) != len(expected) {
+		t.Errorf("Expected %d keys got %q", len(expected), vals)
+	}
+}
+
+func TestTXXXSameDescription(t *testing.T) {
+	tag := tagBytes(3, 0,
+		frameBytes(3, "TXXX", []byte("\x03Artists\x00One\x00")),
+		frameBytes(3, "TXXX", []byte("\x03Artists\x00Two\x00")),
+	)
+	vals, err := ReadID3All(bytes.NewReader(tag))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	artists := vals["TXXX:Artists"]
+	if len(artists) != 2 || artists[0] != "One" || artists[1] != "Two" {
+		t.Fatalf("Wrong values %q", vals)
+	}
+}
+
+func TestURLs(t *testing.T) {
+	tag := tagBytes(4, 0,
+		frameBytes(4, "WOAR", []byte("https://artist.example.com/")),
+		frameBytes(4, "WCOM", []byte("https://shop.example.com/buy?id=1\x00")),
+		frameBytes(4, "WXXX", []byte("\x03podcast\x00https://feeds.example.com/show.xml")),
+		frameBytes(4, "WXXX", append(append(append([]byte{1}, utf16LE("Ünterwegs")...), 0, 0), "http://x.example/\xfc\x00"...)),
+	)
+	vals, err := ReadID3(bytes.NewReader(tag))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	expected := map[string]string{
+		"WOAR":           "https://artist.example.com/",
+		"WCOM":           "https://shop.example.com/buy?id=1",
+		"WXXX:podcast":   "https://feeds.example.com/show.xml",
+		"WXXX:Ünterwegs": "http://x.example/ü",
+	}
+	for k, v := range expected {
+		if vals[k] != v {
+			t.Errorf("Wrong value for %s expected %q got %q", k, v, vals[k])
+		}
+	}
+	if len(vals) != len(expected) {
+		t.Errorf("Expected %d keys got %q", len(expected), vals)
+	}
+}
+
+func TestMultipleValues(t *testing.T) {
+	utf16Artists := []byte{0x1, 0xff, 0xfe, 'A', 0x0, 0x0, 0x0, 0xff, 0xfe, 'B', 0x0, 0x0, 0x0}
+	tag := tagBytes(4, 0,
+		frameBytes(4, "TPE1", []byte("\x03Artist A\x00Artist B\x00")),
+		frameBytes(4, "TCOM", utf16Artists),
+		frameBytes(4, "TXXX", []byte("\x03Artists\x00One\x00Two")),
+		frameBytes(4, "TIT2", []byte("\x03Title\x00\x00")),
+		frameBytes(4, "COMM", []byte("\x03eng\x00Comment")),
+	)
+	parsed, err := ReadTag(bytes.NewReader(tag))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	for id, want := range map[string][]string{
+		"TPE1":         {"Artist A", "Artist B"},
+		"TCOM":         {"A", "B"},
+		"TXXX:Artists": {"One", "Two"},
+		"TIT2":         {"Title"},
+		"COMM":         {"Comment"},
+		"TALB":         nil,
+	} {
+		if got := parsed.Texts(id); !reflect.DeepEqual(got, want) {
+			t.Errorf("%s got %q want %q", id, got, want)
+		}
+	}
+	if parsed.Artist() != "Artist A; Artist B" {
+		t.Errorf("Wrong artist %q", parsed.Artist())
+	}
+
+	vals, err := ReadID3(bytes.NewReader(tag), WithTextSeparator("/"))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	if vals["TPE1"] != "Artist A/Artist B" || vals["TXXX:Artists"] != "One/Two" || vals["TIT2"] != "Title" {
+		t.Errorf("Wrong values %q", vals)
+	}
+}
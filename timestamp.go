@@ -0,0 +1,116 @@
+package easyid3
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Precision is how much of a timestamp the tag gave
+type Precision int
+
+// The precisions in the order they get more precise
+const (
+	PrecisionYear Precision = iota + 1
+	PrecisionMonth
+	PrecisionDay
+	PrecisionHour
+	PrecisionMinute
+	PrecisionSecond
+)
+
+// timestampLayouts are the layouts the v2.4 timestamps use, one for each
+// precision
+var timestampLayouts = []string{
+	"2006",
+	"2006-01",
+	"2006-01-02",
+	"2006-01-02T15",
+	"2006-01-02T15:04",
+	"2006-01-02T15:04:05",
+}
+
+// Timestamp is a v2.4 timestamp like TDRC. Time is UTC with the parts past
+// Precision left at their zero values, "2021" is 2021 and not midnight on
+// January 1st.
+type Timestamp struct {
+	Time      time.Time
+	Precision Precision
+}
+
+// String is the timestamp the way the tag writes it
+func (ts Timestamp) String() string {
+	if ts.Precision < PrecisionYear || ts.Precision > PrecisionSecond {
+		return ""
+	}
+	return ts.Time.UTC().Format(timestampLayouts[ts.Precision-1])
+}
+
+// TimestampError is a timestamp frame that isn't one of the v2.4 formats
+type TimestampError struct {
+	FrameID string
+	Value   string
+}
+
+func (e *TimestampError) Error() string {
+	return fmt.Sprintf("frame %s timestamp %q isn't yyyy-MM-ddTHH:mm:ss or a shorter part of it", e.FrameID, e.Value)
+}
+
+// ParseTimestamp reads a v2.4 timestamp of any precision. A space in place
+// of the T is let through as taggers write that too.
+func ParseTimestamp(s string) (Timestamp, error) {
+	s = strings.TrimSpace(s)
+	if len(s) > 10 && s[10] == ' ' {
+		s = s[:10] + "T" + s[11:]
+	}
+	for i, layout := range timestampLayouts {
+		if len(s) != len(layout) {
+			continue
+		}
+		t, err := time.ParseInLocation(layout, s, time.UTC)
+		if err != nil {
+			break
+		}
+		return Timestamp{Time: t, Precision: Precision(i + 1)}, nil
+	}
+	return Timestamp{}, &TimestampError{Value: s}
+}
+
+// firstTimestamp parses the first of the frames, ok is false when there
+// aren't any
+func firstTimestamp(frames []*Frame) (ts Timestamp, ok bool, err error) {
+	if len(frames) == 0 {
+		return Timestamp{}, false, nil
+	}
+	ts, err = ParseTimestamp(frames[0].Decoded())
+	if err != nil {
+		err.(*TimestampError).FrameID = frames[0].FrameID
+		return Timestamp{}, false, err
+	}
+	return ts, true, nil
+}
+
+// Date is when it was recorded from TDRC. A tag read with
+// WithOriginalFrameIDs has its v2.3 TYER, TDAT and TIME used instead.
+func (t *Tag) Date() (ts Timestamp, ok bool, err error) {
+	frames := t.find("TDRC")
+	if len(frames) == 0 {
+		for _, f := range mergeDates(t.find("TYER", "TDAT", "TIME")) {
+			if f.FrameID == "TDRC" {
+				frames = append(frames, f)
+			}
+		}
+	}
+	return firstTimestamp(frames)
+}
+
+// ReleaseDate is when it was released from TDRL
+func (t *Tag) ReleaseDate() (ts Timestamp, ok bool, err error) {
+	return firstTimestamp(t.find("TDRL"))
+}
+
+// OriginalReleaseDate is when the original was released from TDOR, TORY
+// has the year before v2.4
+func (t *Tag) OriginalReleaseDate() (ts Timestamp, ok bool, err error) {
+	return firstTimestamp(t.find("TDOR", "TORY", "TOR"))
+}
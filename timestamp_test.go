@@ -0,0 +1,75 @@
+package easyid3
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseTimestamp(t *testing.T) {
+	for s, want := range map[string]Timestamp{
+		"2021":                {time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), PrecisionYear},
+		"2021-07":             {time.Date(2021, 7, 1, 0, 0, 0, 0, time.UTC), PrecisionMonth},
+		"2021-07-04":          {time.Date(2021, 7, 4, 0, 0, 0, 0, time.UTC), PrecisionDay},
+		"2021-07-04T12":       {time.Date(2021, 7, 4, 12, 0, 0, 0, time.UTC), PrecisionHour},
+		"2021-07-04T12:30":    {time.Date(2021, 7, 4, 12, 30, 0, 0, time.UTC), PrecisionMinute},
+		"2021-07-04T12:30:15": {time.Date(2021, 7, 4, 12, 30, 15, 0, time.UTC), PrecisionSecond},
+		"2021-07-04 12:30":    {time.Date(2021, 7, 4, 12, 30, 0, 0, time.UTC), PrecisionMinute},
+		" 2021 ":              {time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), PrecisionYear},
+	} {
+		ts, err := ParseTimestamp(s)
+		if err != nil || !ts.Time.Equal(want.Time) || ts.Precision != want.Precision {
+			t.Errorf("%q got %v %v", s, ts, err)
+		}
+	}
+	for _, s := range []string{"", "21", "2021-13", "2021-7-4", "July 2021", "2021-07-04T25:00"} {
+		var te *TimestampError
+		if _, err := ParseTimestamp(s); !errors.As(err, &te) {
+			t.Errorf("%q expected a TimestampError got %v", s, err)
+		}
+	}
+	ts, _ := ParseTimestamp("2021-07-04T12:30")
+	if ts.String() != "2021-07-04T12:30" {
+		t.Errorf("Wrong string %q", ts)
+	}
+}
+
+func TestDates(t *testing.T) {
+	tag := tagBytes(4, 0,
+		frameBytes(4, "TDRC", []byte("\x032021-07-04\x00")),
+		frameBytes(4, "TDRL", []byte("\x03July\x00")),
+	)
+	parsed, err := ReadTag(bytes.NewReader(tag))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	if ts, ok, err := parsed.Date(); !ok || err != nil || ts.String() != "2021-07-04" || ts.Precision != PrecisionDay {
+		t.Errorf("Wrong date %v %v %v", ts, ok, err)
+	}
+	var te *TimestampError
+	if _, ok, err := parsed.ReleaseDate(); ok || !errors.As(err, &te) || te.FrameID != "TDRL" || te.Value != "July" {
+		t.Errorf("Expected a TimestampError got %v", err)
+	}
+	if _, ok, err := parsed.OriginalReleaseDate(); ok || err != nil {
+		t.Errorf("Expected no original release date got %v", err)
+	}
+
+	v23 := tagBytes(3, 0,
+		frameBytes(3, "TDAT", []byte("\x000407")),
+		frameBytes(3, "TYER", []byte("\x002021")),
+		frameBytes(3, "TORY", []byte("\x001999")),
+	)
+	for _, opts := range [][]Option{nil, {WithOriginalFrameIDs()}} {
+		parsed, err = ReadTag(bytes.NewReader(v23), opts...)
+		if err != nil {
+			t.Fatalf("Failed read: %v", err)
+		}
+		if ts, ok, err := parsed.Date(); !ok || err != nil || ts.String() != "2021-07-04" {
+			t.Errorf("Wrong v2.3 date %v %v %v", ts, ok, err)
+		}
+		if ts, ok, err := parsed.OriginalReleaseDate(); !ok || err != nil || ts.String() != "1999" || ts.Precision != PrecisionYear {
+			t.Errorf("Wrong original release date %v %v %v", ts, ok, err)
+		}
+	}
+}
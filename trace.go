@@ -0,0 +1,79 @@
+package easyid3
+
+import (
+	"fmt"
+	"io"
+)
+
+// TraceKind is what a TraceEvent is about
+type TraceKind int
+
+// The trace events in about the order they happen
+const (
+	// TraceHeader is the tag header, Header is set
+	TraceHeader TraceKind = iota + 1
+	// TraceExtendedHeader is the extended header, Size is how much of
+	// the body it took
+	TraceExtendedHeader
+	// TraceFrame is a frame header, Frame has the ID, size and flags but
+	// no data yet
+	TraceFrame
+	// TraceSkip is a frame that was read past, Reason says why
+	TraceSkip
+	// TracePadding is where the padding was found, Size is how much
+	// there is
+	TracePadding
+	// TraceStop is where the frames stopped, Reason says why or Err has
+	// the error the read failed with
+	TraceStop
+)
+
+// TraceEvent is one decision made reading a tag. Offset counts from the
+// start of the tag.
+type TraceEvent struct {
+	Kind   TraceKind
+	Offset int64
+	Header *Header
+	Frame  *Frame
+	Size   int
+	Reason string
+	Err    error
+}
+
+// String is the event the way TraceWriter writes it
+func (e TraceEvent) String() string {
+	switch e.Kind {
+	case TraceHeader:
+		h := e.Header
+		return fmt.Sprintf("%s v2.%d.%d header, flags 0x%02x, %d bytes", h.ID3, h.Version[0], h.Version[1], h.Flags, h.Size)
+	case TraceExtendedHeader:
+		return fmt.Sprintf("extended header, %d bytes", e.Size)
+	case TraceFrame:
+		f := e.Frame
+		return fmt.Sprintf("frame %s at offset %d, %d bytes, flags 0x%x", f.FrameID, e.Offset, f.Size, f.Flags)
+	case TraceSkip:
+		return fmt.Sprintf("skipped frame %s at offset %d: %s", e.Frame.FrameID, e.Offset, e.Reason)
+	case TracePadding:
+		return fmt.Sprintf("padding at offset %d, %d bytes", e.Offset, e.Size)
+	case TraceStop:
+		if e.Err != nil {
+			return fmt.Sprintf("stopped at offset %d: %v", e.Offset, e.Err)
+		}
+		return fmt.Sprintf("stopped at offset %d: %s", e.Offset, e.Reason)
+	}
+	return fmt.Sprintf("unknown trace event %d", e.Kind)
+}
+
+// TraceWriter is a WithTrace hook writing each event on its own line
+func TraceWriter(w io.Writer) func(TraceEvent) {
+	return func(e TraceEvent) {
+		fmt.Fprintln(w, e)
+	}
+}
+
+// trace passes the event on to the WithTrace hook when there is one
+func (o *options) trace(e TraceEvent) {
+	if o.traceFn != nil {
+		o.traceFn(e)
+	}
+}
@@ -0,0 +1,43 @@
+package easyid3
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestWithTrace(t *testing.T) {
+	tag := tagBytes(4, 0,
+		frameBytes(4, "TIT2", []byte("\x03Title")),
+		frameBytes(4, "APIC", make([]byte, 64)),
+		make([]byte, 32),
+	)
+	var buf bytes.Buffer
+	_, err := ReadTag(bytes.NewReader(tag), WithMaxInlineFrameSize(16), WithTrace(TraceWriter(&buf)))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	want := strings.Join([]string{
+		"ID3 v2.4.0 header, flags 0x00, 122 bytes",
+		"frame TIT2 at offset 10, 6 bytes, flags 0x0000",
+		"frame APIC at offset 26, 64 bytes, flags 0x0000",
+		"skipped frame APIC at offset 26: over the inline size",
+		"padding at offset 100, 32 bytes",
+		"stopped at offset 100: padding",
+		"",
+	}, "\n")
+	if buf.String() != want {
+		t.Errorf("Wrong trace\ngot\n%s\nwant\n%s", buf.String(), want)
+	}
+
+	// the error the read stopped with
+	var events []TraceEvent
+	_, err = ReadTag(bytes.NewReader(tag[:40]), WithTrace(func(e TraceEvent) {
+		events = append(events, e)
+	}))
+	last := events[len(events)-1]
+	if err == nil || last.Kind != TraceStop || !errors.Is(last.Err, ErrTruncated) {
+		t.Errorf("Wrong last event %v for %v", last, err)
+	}
+}
@@ -0,0 +1,47 @@
+package easyid3
+
+import "io"
+
+// unsyncReader undoes unsynchronisation, every 0xFF 0x00 pair coming
+// from the underlying reader goes back to 0xFF.
+type unsyncReader struct {
+	r  io.Reader
+	ff bool
+}
+
+func (u *unsyncReader) Read(p []byte) (int, error) {
+	for {
+		n, err := u.r.Read(p)
+		out := 0
+		for _, b := range p[:n] {
+			if u.ff && b == 0 {
+				u.ff = false
+				continue
+			}
+			u.ff = b == 0xff
+			p[out] = b
+			out++
+		}
+		// everything read was a dropped 0x00, go again rather than
+		// returning nothing
+		if out > 0 || err != nil || n == 0 {
+			return out, err
+		}
+	}
+}
+
+// deunsync undoes unsynchronisation of a whole payload in place
+func deunsync(b []byte) []byte {
+	out := 0
+	ff := false
+	for _, c := range b {
+		if ff && c == 0 {
+			ff = false
+			continue
+		}
+		ff = c == 0xff
+		b[out] = c
+		out++
+	}
+	return b[:out]
+}
@@ -0,0 +1,123 @@
+package easyid3
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+// unsyncBytes escapes b the way a tagger would
+func unsyncBytes(b []byte) []byte {
+	var out []byte
+	for i, c := range b {
+		out = append(out, c)
+		if c == 0xff && (i+1 == len(b) || b[i+1] == 0 || b[i+1]&0xe0 == 0xe0) {
+			out = append(out, 0)
+		}
+	}
+	return out
+}
+
+// a JPEG with plenty of false syncs in it
+var syncyJPEG = []byte{0xff, 0xd8, 0xff, 0xe0, 0x0, 0x10, 'J', 'F', 'I', 'F', 0x0, 0xff, 0x0, 0xff, 0xff, 0xe1, 0x12, 0xff, 0xf0, 0xff, 0xd9, 0xff}
+
+func TestUnsyncReader(t *testing.T) {
+	escaped := unsyncBytes(syncyJPEG)
+	if bytes.Equal(escaped, syncyJPEG) {
+		t.Fatal("expected escaping to change the image")
+	}
+	// one byte at a time to make sure a pair split across reads works
+	got, err := io.ReadAll(&unsyncReader{r: &oneByteReader{bytes.NewReader(escaped)}})
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	if !bytes.Equal(got, syncyJPEG) {
+		t.Fatalf("Expected %x got %x", syncyJPEG, got)
+	}
+	if got := deunsync(append([]byte{}, escaped...)); !bytes.Equal(got, syncyJPEG) {
+		t.Fatalf("Expected %x got %x", syncyJPEG, got)
+	}
+}
+
+type oneByteReader struct {
+	r io.Reader
+}
+
+func (o *oneByteReader) Read(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	return o.r.Read(p[:1])
+}
+
+func TestUnsynchronisedV23(t *testing.T) {
+	var body []byte
+	body = append(body, frameBytes(3, "TIT2", []byte("\x00Cover\x00"))...)
+	body = append(body, frameBytes(3, "APIC", apicData(0, "image/jpeg", 3, []byte("\x00"), syncyJPEG))...)
+	escaped := unsyncBytes(body)
+	tag := []byte{'I', 'D', '3', 3, 0, 0x80}
+	tag = append(tag, synsafeBytes(len(escaped))...)
+	tag = append(tag, escaped...)
+	// followed by audio which has to be left alone
+	tag = append(tag, 0xff, 0xfb, 0x90, 0x0)
+	checkUnsyncedPicture(t, tag)
+}
+
+func TestUnsynchronisedV24(t *testing.T) {
+	tag := tagBytes(4, 0x80,
+		frameBytes(4, "TIT2", []byte("\x03Cover\x00")),
+		frameBytes(4, "APIC", unsyncBytes(apicData(0, "image/jpeg", 3, []byte("\x00"), syncyJPEG))),
+	)
+	checkUnsyncedPicture(t, tag)
+}
+
+func checkUnsyncedPicture(t *testing.T, tag []byte) {
+	t.Helper()
+	parsed, err := ReadTag(bytes.NewReader(tag))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	pictures := parsed.Pictures()
+	if len(pictures) != 1 || !bytes.Equal(pictures[0].Data, syncyJPEG) {
+		t.Fatalf("Expected identical image got %+v", pictures)
+	}
+	vals, err := ReadID3(bytes.NewReader(tag))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	if vals["TIT2"] != "Cover" {
+		t.Fatalf("Wrong title %q", vals["TIT2"])
+	}
+}
+
+func TestFrameUnsynchronisation(t *testing.T) {
+	picture := apicData(0, "image/jpeg", 3, []byte("\x00"), syncyJPEG)
+	withLength := append(synsafeBytes(len(picture)), unsyncBytes(picture)...)
+	tag := tagBytes(4, 0,
+		frameBytes(4, "TIT2", []byte("\x03Cover\x00")),
+		flaggedFrameBytes(4, "APIC", 0, 0x02, unsyncBytes(picture)),
+		// a title that looks escaped but isn't marked so has to stay as is
+		frameBytes(4, "TPE1", []byte("\x00\xff\x00a\x00")),
+		flaggedFrameBytes(4, "APIC", 0, 0x03, withLength),
+	)
+	parsed, err := ReadTag(bytes.NewReader(tag))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	pictures := parsed.Pictures()
+	if len(pictures) != 2 {
+		t.Fatalf("Expected 2 pictures got %d", len(pictures))
+	}
+	for _, p := range pictures {
+		if !bytes.Equal(p.Data, syncyJPEG) {
+			t.Errorf("Expected identical image got %x", p.Data)
+		}
+	}
+	vals, err := ReadID3(bytes.NewReader(tag))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	if vals["TIT2"] != "Cover" || vals["TPE1"] != "ÿ; a" {
+		t.Fatalf("Wrong values %q", vals)
+	}
+}
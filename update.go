@@ -0,0 +1,185 @@
+package easyid3
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// UpdateTag writes the tag over the one at the start of rws when it fits in
+// the space the old one takes up, frames and padding, so the audio doesn't
+// have to move. Whatever is left over becomes padding. When it doesn't fit
+// nothing is written and inPlace is false, the file needs rewriting with
+// SaveTag instead. The extended header is kept like WriteTag keeps it, a
+// tag the left over padding would take past its restricted size doesn't
+// fit either.
+func UpdateTag(rws io.ReadWriteSeeker, t *Tag) (inPlace bool, err error) {
+	return updateTag(rws, t, newOptions(nil))
+}
+
+func updateTag(rws io.ReadWriteSeeker, t *Tag, o *options) (bool, error) {
+	space, err := tagSpace(rws)
+	if err != nil {
+		return false, err
+	}
+	frames := o.writeFrames(t.frames)
+	tag, err := encodeTag(frames, 0, t.header.extended)
+	if err != nil {
+		return false, err
+	}
+	if len(tag) > space {
+		return false, nil
+	}
+	// encoded again with the padding so the CRC covers it
+	tag, err = encodeTag(frames, space-len(tag), t.header.extended)
+	if errors.Is(err, ErrRestricted) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	_, err = rws.Seek(0, io.SeekStart)
+	if err != nil {
+		return false, err
+	}
+	_, err = rws.Write(tag)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// SaveTag writes the tag to the file at path. It's updated in place when
+// the tag fits, otherwise the tag and the audio are written to a temporary
+// file next to it which is renamed over the original once it's all on
+// disk, so a crash part way through leaves the original alone. Use
+// WithPadding to leave room for the next edit to go in place and
+// WithID3v1 to keep an ID3v1 tag at the end up to date too.
+func SaveTag(path string, t *Tag, opts ...Option) error {
+	o := newOptions(opts)
+	f, err := os.OpenFile(path, os.O_RDWR, 0)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	inPlace, err := updateTag(f, t, o)
+	if err != nil {
+		return err
+	}
+	if inPlace {
+		if o.id3v1 {
+			err = WriteID3v1(f, t)
+			if err != nil {
+				return err
+			}
+		}
+		err = f.Sync()
+		if err != nil {
+			return err
+		}
+		return f.Close()
+	}
+	tmp, err := rewriteFile(f, t, o)
+	if err != nil {
+		return err
+	}
+	f.Close()
+	err = os.Rename(tmp, path)
+	if err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+// rewriteFile writes the tag and the audio after the old tag to a temporary
+// file in the same directory so the rename doesn't cross filesystems, it
+// returns the temporary file's path
+func rewriteFile(f *os.File, t *Tag, o *options) (string, error) {
+	info, err := f.Stat()
+	if err != nil {
+		return "", err
+	}
+	space, err := tagSpace(f)
+	if err != nil {
+		return "", err
+	}
+	tag, err := encodeTag(o.writeFrames(t.frames), o.padding, t.header.extended)
+	if err != nil {
+		return "", err
+	}
+	_, err = f.Seek(int64(space), io.SeekStart)
+	if err != nil {
+		return "", err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(f.Name()), "."+filepath.Base(f.Name())+".*")
+	if err != nil {
+		return "", err
+	}
+	var v1 *Tag
+	if o.id3v1 {
+		v1 = t
+	}
+	err = writeFile(tmp, tag, f, info.Mode().Perm(), v1)
+	if err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return "", err
+	}
+	return tmp.Name(), nil
+}
+
+// writeFile writes the tag and audio, makes sure it's on disk and closes it.
+// The ID3v1 tag is written from v1 unless it's nil.
+func writeFile(tmp *os.File, tag []byte, audio io.Reader, perm os.FileMode, v1 *Tag) error {
+	_, err := tmp.Write(tag)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(tmp, audio)
+	if err != nil {
+		return err
+	}
+	if v1 != nil {
+		err = WriteID3v1(tmp, v1)
+		if err != nil {
+			return err
+		}
+	}
+	// CreateTemp makes it 0600, keep what the original had
+	err = tmp.Chmod(perm)
+	if err != nil {
+		return err
+	}
+	err = tmp.Sync()
+	if err != nil {
+		return err
+	}
+	return tmp.Close()
+}
+
+// tagSpace is the size of the tag at the start of rs including the header
+// and footer, 0 when there isn't one
+func tagSpace(rs io.ReadSeeker) (int, error) {
+	_, err := rs.Seek(0, io.SeekStart)
+	if err != nil {
+		return 0, err
+	}
+	buf := make([]byte, 10)
+	_, err = io.ReadFull(rs, buf)
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	if string(buf[:3]) != "ID3" {
+		return 0, nil
+	}
+	header, err := newID3(buf)
+	if err != nil {
+		return 0, err
+	}
+	return header.TotalSize(), nil
+}
@@ -0,0 +1,160 @@
+package easyid3
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func tempFile(t *testing.T, contents []byte) *os.File {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "test.mp3")
+	if err := os.WriteFile(path, contents, 0644); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.OpenFile(path, os.O_RDWR, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { f.Close() })
+	return f
+}
+
+func readAll(t *testing.T, f *os.File) []byte {
+	t.Helper()
+	b, err := os.ReadFile(f.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestUpdateTagInPlace(t *testing.T) {
+	var old bytes.Buffer
+	_, err := WriteID3(&old, map[string]string{"TIT2": "A fairly long old title", "TPE1": "Artist"}, WithPadding(50))
+	if err != nil {
+		t.Fatal(err)
+	}
+	oldSize := old.Len()
+	f := tempFile(t, append(old.Bytes(), fakeAudio...))
+
+	tag, err := ReadTag(f)
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	if err := tag.SetText("TIT2", "A much longer new title that still fits in the padding"); err != nil {
+		t.Fatal(err)
+	}
+	inPlace, err := UpdateTag(f, tag)
+	if err != nil || !inPlace {
+		t.Fatalf("Expected an in place update got %v %v", inPlace, err)
+	}
+	contents := readAll(t, f)
+	if len(contents) != oldSize+len(fakeAudio) || !bytes.Equal(contents[oldSize:], fakeAudio) {
+		t.Fatal("Audio changed")
+	}
+	read, err := ReadTag(bytes.NewReader(contents))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	if read.Title() != "A much longer new title that still fits in the padding" || read.Artist() != "Artist" {
+		t.Errorf("Wrong tag %v", read.frames)
+	}
+	if read.header.TotalSize() != oldSize {
+		t.Errorf("Tag size changed from %d to %d", oldSize, read.header.TotalSize())
+	}
+}
+
+func TestUpdateTagDoesNotFit(t *testing.T) {
+	orig := append(tagBytes(4, 0, frameBytes(4, "TIT2", []byte("\x03Title\x00"))), fakeAudio...)
+	f := tempFile(t, orig)
+	tag, err := ReadTag(f)
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	if err := tag.SetText("TIT2", "Too long to fit"); err != nil {
+		t.Fatal(err)
+	}
+	inPlace, err := UpdateTag(f, tag)
+	if err != nil || inPlace {
+		t.Fatalf("Expected no in place update got %v %v", inPlace, err)
+	}
+	if !bytes.Equal(readAll(t, f), orig) {
+		t.Error("File changed")
+	}
+
+	// no tag at all has no room
+	f = tempFile(t, fakeAudio)
+	inPlace, err = UpdateTag(f, tag)
+	if err != nil || inPlace {
+		t.Fatalf("Expected no in place update got %v %v", inPlace, err)
+	}
+	if !bytes.Equal(readAll(t, f), fakeAudio) {
+		t.Error("File changed")
+	}
+}
+
+func TestSaveTag(t *testing.T) {
+	orig := append(tagBytes(4, 0, frameBytes(4, "TIT2", []byte("\x03Title\x00"))), fakeAudio...)
+	path := filepath.Join(t.TempDir(), "test.mp3")
+	if err := os.WriteFile(path, orig, 0640); err != nil {
+		t.Fatal(err)
+	}
+	tag, err := ReadTag(bytes.NewReader(orig))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	if err := tag.SetText("TIT2", "Too long to fit in place"); err != nil {
+		t.Fatal(err)
+	}
+	if err := SaveTag(path, tag, WithPadding(100)); err != nil {
+		t.Fatalf("Failed save: %v", err)
+	}
+	checkSaved := func(title string, size int) {
+		t.Helper()
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		read, err := ReadTag(bytes.NewReader(contents))
+		if err != nil {
+			t.Fatalf("Failed read: %v", err)
+		}
+		if read.Title() != title {
+			t.Errorf("Wrong title %q", read.Title())
+		}
+		if read.header.TotalSize() != size {
+			t.Errorf("Wrong tag size %d", read.header.TotalSize())
+		}
+		if !bytes.Equal(contents[size:], fakeAudio) {
+			t.Error("Audio changed")
+		}
+	}
+	size := 10 + 10 + len("\x03Too long to fit in place\x00") + 100
+	checkSaved("Too long to fit in place", size)
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0640 {
+		t.Errorf("Lost the file mode, got %v", info.Mode())
+	}
+
+	// the padding has room for the next one
+	if err := tag.SetText("TIT2", "Now it fits in place with the padding"); err != nil {
+		t.Fatal(err)
+	}
+	if err := SaveTag(path, tag, WithPadding(100)); err != nil {
+		t.Fatalf("Failed save: %v", err)
+	}
+	checkSaved("Now it fits in place with the padding", size)
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("Left files behind %v", entries)
+	}
+}
@@ -0,0 +1,55 @@
+package easyid3
+
+import "strings"
+
+// v22FrameIDs are the v2.3 and v2.4 IDs for the three character v2.2 ones.
+// CRM and LNK are left alone, there's nothing to turn CRM into and LNK
+// frames have a three character ID in them.
+var v22FrameIDs = map[string]string{
+	"BUF": "RBUF", "CNT": "PCNT", "COM": "COMM", "CRA": "AENC",
+	"EQU": "EQUA", "ETC": "ETCO", "GEO": "GEOB", "IPL": "IPLS",
+	"MCI": "MCDI", "MLL": "MLLT", "PIC": "APIC", "POP": "POPM",
+	"REV": "RVRB", "RVA": "RVAD", "SLT": "SYLT", "STC": "SYTC",
+	"TAL": "TALB", "TBP": "TBPM", "TCM": "TCOM", "TCO": "TCON",
+	"TCR": "TCOP", "TDA": "TDAT", "TDY": "TDLY", "TEN": "TENC",
+	"TFT": "TFLT", "TIM": "TIME", "TKE": "TKEY", "TLA": "TLAN",
+	"TLE": "TLEN", "TMT": "TMED", "TOA": "TOPE", "TOF": "TOFN",
+	"TOL": "TOLY", "TOR": "TORY", "TOT": "TOAL", "TP1": "TPE1",
+	"TP2": "TPE2", "TP3": "TPE3", "TP4": "TPE4", "TPA": "TPOS",
+	"TPB": "TPUB", "TRC": "TSRC", "TRD": "TRDA", "TRK": "TRCK",
+	"TSI": "TSIZ", "TSS": "TSSE", "TT1": "TIT1", "TT2": "TIT2",
+	"TT3": "TIT3", "TXT": "TEXT", "TXX": "TXXX", "TYE": "TYER",
+	"UFI": "UFID", "ULT": "USLT", "WAF": "WOAF", "WAR": "WOAR",
+	"WAS": "WOAS", "WCM": "WCOM", "WCP": "WCOP", "WPB": "WPUB",
+	"WXX": "WXXX",
+	// iTunes ones that were never in the spec
+	"TCP": "TCMP", "TS2": "TSO2", "TSA": "TSOA", "TSC": "TSOC",
+	"TSP": "TSOP", "TST": "TSOT",
+}
+
+// v22ImageFormats are the MIME types for the PIC image formats
+var v22ImageFormats = map[string]string{
+	"JPG": "image/jpeg",
+	"PNG": "image/png",
+	"GIF": "image/gif",
+	"BMP": "image/bmp",
+}
+
+// picToAPIC swaps the 3 character image format after the encoding for a
+// terminated MIME type
+func picToAPIC(data []byte) []byte {
+	if len(data) < 4 {
+		return data
+	}
+	format := string(data[1:4])
+	mime, ok := v22ImageFormats[strings.ToUpper(format)]
+	if !ok {
+		// "-->" is a link, anything else is kept as it was
+		mime = format
+	}
+	out := make([]byte, 0, len(data)+len(mime)-2)
+	out = append(out, data[0])
+	out = append(out, mime...)
+	out = append(out, 0)
+	return append(out, data[4:]...)
+}
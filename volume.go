@@ -0,0 +1,94 @@
+package easyid3
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+// RVA2 channel types
+const (
+	ChannelOther byte = iota
+	ChannelMaster
+	ChannelFrontRight
+	ChannelFrontLeft
+	ChannelBackRight
+	ChannelBackLeft
+	ChannelFrontCentre
+	ChannelBackCentre
+	ChannelSubwoofer
+)
+
+// VolumeAdjustment is an RVA2 frame, there's one for each Identification
+// like "track" and "album"
+type VolumeAdjustment struct {
+	Identification string
+	Channels       []ChannelAdjustment
+}
+
+// ChannelAdjustment is the adjustment for one channel. Gain is in dB and
+// Peak is a fraction of full scale, PeakBits is 0 when there's no peak.
+type ChannelAdjustment struct {
+	Channel  byte
+	Gain     float64
+	PeakBits byte
+	Peak     float64
+}
+
+// Channel is the adjustment for the channel type, nil when there isn't one
+func (v VolumeAdjustment) Channel(channel byte) *ChannelAdjustment {
+	for i := range v.Channels {
+		if v.Channels[i].Channel == channel {
+			return &v.Channels[i]
+		}
+	}
+	return nil
+}
+
+// parseVolumeAdjustment reads the terminated identification and then each
+// channel's type, 16 bit adjustment in 1/512 dB, bits in the peak and the
+// peak itself. A broken channel at the end is dropped.
+func parseVolumeAdjustment(data []byte) VolumeAdjustment {
+	ident, data := splitTerminated(encodingISO88591, data)
+	v := VolumeAdjustment{Identification: decodeLatin1(ident)}
+	for len(data) >= 4 {
+		c := ChannelAdjustment{
+			Channel:  data[0],
+			Gain:     float64(int16(binary.BigEndian.Uint16(data[1:3]))) / 512,
+			PeakBits: data[3],
+		}
+		size := (int(c.PeakBits) + 7) / 8
+		if len(data) < 4+size {
+			break
+		}
+		c.Peak = peakRatio(data[4:4+size], int(c.PeakBits))
+		v.Channels = append(v.Channels, c)
+		data = data[4+size:]
+	}
+	return v
+}
+
+// peakRatio scales the peak to 32 bits and then to a fraction of full
+// scale the way mutagen does, only the top 32 bits of a longer one count
+func peakRatio(b []byte, bits int) float64 {
+	if bits == 0 {
+		return 0
+	}
+	if len(b) > 4 {
+		b, bits = b[:4], 32
+	}
+	var p uint64
+	for _, c := range b {
+		p = p<<8 | uint64(c)
+	}
+	p <<= uint(32 - bits)
+	return float64(p) / math.MaxInt32
+}
+
+// VolumeAdjustments returns all the RVA2 frames
+func (t *Tag) VolumeAdjustments() []VolumeAdjustment {
+	var adjustments []VolumeAdjustment
+	for _, f := range t.find("RVA2") {
+		adjustments = append(adjustments, parseVolumeAdjustment(f.Data))
+	}
+	return adjustments
+}
@@ -0,0 +1,50 @@
+package easyid3
+
+import (
+	"bytes"
+	"math"
+	"testing"
+)
+
+func TestVolumeAdjustments(t *testing.T) {
+	tag := tagBytes(4, 0,
+		frameBytes(4, "RVA2", []byte("track\x00"+
+			"\x01\xfc\x00\x00"+ // -2 dB no peak
+			"\x03\x04\x00\x08\x80"+ // +2 dB 8 bit
+			"\x02\xff\x00\x10\x40\x00"+ // -0.5 dB 16 bit
+			"\x08\x00\x01\x18\x20\x00\x00"+ // 1/512 dB 24 bit
+			"\x06\x00\x00\x20\x7f\xff\xff\xff"+ // 32 bit
+			"\x05\x00\x00\x20\x7f")), // cut short
+		frameBytes(4, "RVA2", []byte("album\x00\x01\x02\x00\x00")),
+	)
+	parsed, err := ReadTag(bytes.NewReader(tag))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	adjustments := parsed.VolumeAdjustments()
+	if len(adjustments) != 2 || adjustments[0].Identification != "track" || adjustments[1].Identification != "album" {
+		t.Fatalf("Wrong adjustments %+v", adjustments)
+	}
+	want := []ChannelAdjustment{
+		{ChannelMaster, -2, 0, 0},
+		{ChannelFrontLeft, 2, 8, 1},
+		{ChannelFrontRight, -0.5, 16, 0.5},
+		{ChannelSubwoofer, 1.0 / 512, 24, 0.25},
+		{ChannelFrontCentre, 0, 32, 1},
+	}
+	got := adjustments[0].Channels
+	if len(got) != len(want) {
+		t.Fatalf("Expected %d channels got %+v", len(want), got)
+	}
+	for i := range want {
+		if got[i].Channel != want[i].Channel || got[i].Gain != want[i].Gain || got[i].PeakBits != want[i].PeakBits || math.Abs(got[i].Peak-want[i].Peak) > 1e-6 {
+			t.Errorf("Channel %d got %+v want %+v", i, got[i], want[i])
+		}
+	}
+	if c := adjustments[1].Channel(ChannelMaster); c == nil || c.Gain != 1 {
+		t.Errorf("Wrong album master %+v", c)
+	}
+	if c := adjustments[1].Channel(ChannelBackLeft); c != nil {
+		t.Errorf("Expected no back left got %+v", c)
+	}
+}
@@ -0,0 +1,52 @@
+package easyid3
+
+import (
+	"errors"
+	"io"
+)
+
+// WalkFrames calls fn with each frame as it's read instead of reading the
+// whole tag first. Returning ErrStopWalk stops reading without an error,
+// any other error stops it and comes back from WalkFrames. Encrypted frames
+// are decrypted with the ENCR frames seen so far and skipped when they
+// can't be. WithDetectUTF8 and WithLegacyEncoding decode the text the same
+// as they do for ReadTag. The CRC isn't checked and SEEK frames aren't
+// followed.
+func WalkFrames(r io.Reader, fn func(f *Frame) error, opts ...Option) error {
+	o := newOptions(opts)
+	br := bufferedReader(r)
+	defer releaseReader(br, r)
+	header, err := readHeader(br)
+	if err != nil {
+		return err
+	}
+	body, err := bodyReader(newStreamReader(br, r), header, o)
+	if err != nil {
+		return err
+	}
+	owners := map[byte]string{}
+	err = walkFrames(body, header, o, func(f *Frame) error {
+		if f.FrameID == "ENCR" {
+			e := parseEncryption(f.Data)
+			owners[e.Method] = e.Owner
+		}
+		if f.Encrypted() && f.decrypt(owners[f.EncryptionMethod], o.maxFrameSize) != nil {
+			return nil
+		}
+		// the text is decoded the same as ReadTag would
+		if o.detectUTF8 {
+			detectUTF8([]*Frame{f})
+		}
+		if o.legacyEncoding != nil {
+			decodeLegacy([]*Frame{f}, o.legacyEncoding)
+		}
+		return fn(f)
+	})
+	if errors.Is(err, ErrStopWalk) {
+		return header.frameErrors()
+	}
+	if err != nil {
+		return err
+	}
+	return header.frameErrors()
+}
@@ -0,0 +1,94 @@
+package easyid3
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestWalkFrames(t *testing.T) {
+	want, err := ReadID3All(bytes.NewReader(ivsID3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := map[string][]string{}
+	err = WalkFrames(bytes.NewReader(ivsID3), func(f *Frame) error {
+		got[f.Key()] = append(got[f.Key()], f.Decoded())
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Failed walk: %v", err)
+	}
+	if len(got) != len(want) {
+		t.Errorf("Got %v want %v", got, want)
+	}
+	for k, v := range want {
+		if len(got[k]) != len(v) || got[k][0] != v[0] {
+			t.Errorf("Wrong %s got %q want %q", k, got[k], v)
+		}
+	}
+}
+
+func TestWalkFramesStop(t *testing.T) {
+	tag := tagBytes(4, 0,
+		frameBytes(4, "TIT2", []byte("\x03Title\x00")),
+		frameBytes(4, "APIC", apicData(0, "image/jpeg", PictureTypeFrontCover, []byte("\x00"), tinyJPEG)),
+		frameBytes(4, "TPE1", []byte("\x03Artist\x00")),
+	)
+	var ids []string
+	err := WalkFrames(bytes.NewReader(tag), func(f *Frame) error {
+		ids = append(ids, f.FrameID)
+		if f.FrameID == "APIC" {
+			if !bytes.HasSuffix(f.Data, tinyJPEG) {
+				t.Error("Missing the picture data")
+			}
+			return ErrStopWalk
+		}
+		return nil
+	})
+	if err != nil || len(ids) != 2 {
+		t.Errorf("Walked %v: %v", ids, err)
+	}
+
+	boom := errors.New("boom")
+	err = WalkFrames(bytes.NewReader(tag), func(f *Frame) error { return boom })
+	if !errors.Is(err, boom) {
+		t.Errorf("Expected the callback's error got %v", err)
+	}
+	if err := WalkFrames(bytes.NewReader(fakeAudio), func(f *Frame) error { return nil }); !errors.Is(err, ErrNoTag) {
+		t.Errorf("Expected ErrNoTag got %v", err)
+	}
+}
+
+func TestWalkFramesEncrypted(t *testing.T) {
+	RegisterDecryptor(0x80, func(owner string, data []byte) ([]byte, error) {
+		return xor(data, 0x5a), nil
+	})
+	defer RegisterDecryptor(0x80, nil)
+	tag := tagBytes(4, 0,
+		frameBytes(4, "ENCR", []byte("owner\x00\x80")),
+		flaggedFrameBytes(4, "TIT2", 0, 0x04, append([]byte{0x80}, xor([]byte("\x03Title\x00"), 0x5a)...)),
+		flaggedFrameBytes(4, "TPE1", 0, 0x04, append([]byte{0x81}, "\x03Artist\x00"...)),
+	)
+	var got []string
+	err := WalkFrames(bytes.NewReader(tag), func(f *Frame) error {
+		got = append(got, f.String())
+		return nil
+	})
+	if err != nil || len(got) != 2 || got[1] != "TIT2:Title" {
+		t.Errorf("Walked %v: %v", got, err)
+	}
+}
+
+func BenchmarkWalkFrames(b *testing.B) {
+	tag := typicalTag()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		err := WalkFrames(bytes.NewReader(tag), func(f *Frame) error {
+			return nil
+		})
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
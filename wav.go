@@ -0,0 +1,17 @@
+package easyid3
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+// ReadID3FromWAV is ReadID3 for the tag in the "id3 " chunk of a WAV file.
+// A file without one is ErrNoTag and one with a chunk that runs past the
+// end of the file is ErrTruncated.
+func ReadID3FromWAV(r io.ReadSeeker, opts ...Option) (map[string]string, error) {
+	s, err := newIFFScanner(r, binary.LittleEndian, "RIFF", "WAVE")
+	if err != nil {
+		return nil, err
+	}
+	return readID3Chunk(s, opts, "id3 ", "ID3 ")
+}
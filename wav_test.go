@@ -0,0 +1,73 @@
+package easyid3
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"os"
+	"testing"
+)
+
+// riffChunk is a little endian chunk with its padding byte
+func riffChunk(id string, data []byte) []byte {
+	out := append([]byte(id), 0, 0, 0, 0)
+	binary.LittleEndian.PutUint32(out[4:], uint32(len(data)))
+	out = append(out, data...)
+	if len(data)%2 == 1 {
+		out = append(out, 0)
+	}
+	return out
+}
+
+func wavBytes(chunks ...[]byte) []byte {
+	wave := []byte("WAVE")
+	for _, c := range chunks {
+		wave = append(wave, c...)
+	}
+	return riffChunk("RIFF", wave)
+}
+
+func TestReadID3FromWAV(t *testing.T) {
+	data, err := os.ReadFile("testdata/tagged.wav")
+	if err != nil {
+		t.Fatal(err)
+	}
+	props, err := ReadID3FromWAV(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	if props["TIT2"] != "WAV Title" || props["TPE1"] != "WAV Artist" || props["TALB"] != "Broadcast" {
+		t.Errorf("Wrong tag %v", props)
+	}
+	props, err = ReadID3FromWAV(bytes.NewReader(data), WithFrames("TPE1"))
+	if err != nil || len(props) != 1 {
+		t.Errorf("Expected the options used got %v %v", props, err)
+	}
+
+	// the upper case ID after an odd sized chunk
+	tag := tagBytes(3, 0, frameBytes(3, "TIT2", []byte("\x00Upper")))
+	fmtChunk := riffChunk("fmt ", make([]byte, 16))
+	props, err = ReadID3FromWAV(bytes.NewReader(wavBytes(fmtChunk, riffChunk("data", []byte{1}), riffChunk("ID3 ", tag))))
+	if err != nil || props["TIT2"] != "Upper" {
+		t.Errorf("Wrong tag %v %v", props, err)
+	}
+
+	for name, file := range map[string][]byte{
+		"no chunk": wavBytes(fmtChunk, riffChunk("data", make([]byte, 7))),
+		"not wav":  append([]byte("RIFF\x04\x00\x00\x00AVI "), fmtChunk...),
+		"empty":    {},
+	} {
+		if _, err := ReadID3FromWAV(bytes.NewReader(file)); !errors.Is(err, ErrNoTag) {
+			t.Errorf("%s: expected ErrNoTag got %v", name, err)
+		}
+	}
+	for name, file := range map[string][]byte{
+		"cut short":     wavBytes(fmtChunk)[:20],
+		"tag cut short": wavBytes(fmtChunk, riffChunk("id3 ", tag))[:60],
+		"chunk header":  wavBytes(fmtChunk)[:16],
+	} {
+		if _, err := ReadID3FromWAV(bytes.NewReader(file)); !errors.Is(err, ErrTruncated) {
+			t.Errorf("%s: expected ErrTruncated got %v", name, err)
+		}
+	}
+}
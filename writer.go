@@ -0,0 +1,221 @@
+package easyid3
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// maxSynsafe is the biggest size 4 syncsafe bytes can hold
+const maxSynsafe = 1<<28 - 1
+
+// WriteID3 writes the frames as an ID3v2.4 tag. They're keyed the same way
+// ReadID3 returns them so what it reads can be written back, TIT2 for text
+// frames, TXXX:description, WXXX:description, COMM:lang:description and
+// USLT:lang:description, a bare TXXX or WXXX has no description. Text is
+// written as UTF-8 and PCST whatever its value is. It returns the number of
+// bytes written.
+func WriteID3(w io.Writer, frames map[string]string, opts ...Option) (int, error) {
+	keys := make([]string, 0, len(frames))
+	for key := range frames {
+		keys = append(keys, key)
+	}
+	// map order is random, keep the output the same every time
+	sort.Strings(keys)
+	var fs []*Frame
+	for _, key := range keys {
+		f, err := textFrame(key, frames[key])
+		if err != nil {
+			return 0, err
+		}
+		fs = append(fs, f)
+	}
+	return writeTag(w, fs, nil, newOptions(opts))
+}
+
+// WriteTag writes the tag as ID3v2.4 with whatever changes have been made
+// to it. Frames read from a v2.2 tag can't be written. An extended header
+// is kept, its CRC worked out again, and when it has restrictions a tag
+// that breaks them isn't written and the error is ErrRestricted. Image
+// sizes in pixels aren't checked.
+func WriteTag(w io.Writer, t *Tag, opts ...Option) (int, error) {
+	return writeTag(w, t.frames, t.header.extended, newOptions(opts))
+}
+
+// textFrame builds the v2.4 frame for a ReadID3 key and value
+func textFrame(key, value string) (*Frame, error) {
+	parts := strings.SplitN(key, ":", 3)
+	id := parts[0]
+	if len(id) != 4 || !validFrameID(id) {
+		return nil, fmt.Errorf("can't write %q, %q isn't a v2.4 frame ID", key, id)
+	}
+	var data []byte
+	switch {
+	case id == "TXXX":
+		desc := userDescription(parts)
+		data = append(append(utf8Terminated(desc), value...), 0)
+	case id == "WXXX":
+		desc := userDescription(parts)
+		url, err := encodeLatin1(value)
+		if err != nil {
+			return nil, fmt.Errorf("frame %s: %w", key, err)
+		}
+		data = append(utf8Terminated(desc), url...)
+	case id == "COMM" || id == "USLT":
+		var lang, desc string
+		if len(parts) > 1 {
+			lang = parts[1]
+		}
+		if len(parts) > 2 {
+			desc = parts[2]
+		}
+		if lang == "" {
+			lang = string(LanguageUndetermined)
+		}
+		if !validLanguage(lang) {
+			return nil, fmt.Errorf("frame %s language %q isn't 3 letters", key, lang)
+		}
+		data = append([]byte{encodingUTF8}, strings.ToLower(lang)...)
+		data = append(data, desc...)
+		data = append(data, 0)
+		data = append(data, value...)
+	case id[0] == 'T' || id == FramePodcastFeed:
+		// iTunes wants WFED as a text frame
+		data = utf8Terminated(value)
+	case id == FramePodcast:
+		data = append([]byte(nil), pcstData...)
+	case id[0] == 'W':
+		url, err := encodeLatin1(value)
+		if err != nil {
+			return nil, fmt.Errorf("frame %s: %w", key, err)
+		}
+		data = url
+	default:
+		return nil, fmt.Errorf("can't write %s from text, it isn't a text or URL frame", key)
+	}
+	return newFrame(id, data), nil
+}
+
+// userDescription is the TXXX or WXXX description in the key split on ":",
+// a bare TXXX has none and the description can have colons of its own
+func userDescription(parts []string) string {
+	if len(parts) < 2 {
+		return ""
+	}
+	return strings.Join(parts[1:], ":")
+}
+
+// utf8Terminated is the UTF-8 encoding byte then the terminated text
+func utf8Terminated(s string) []byte {
+	return append(append([]byte{encodingUTF8}, s...), 0)
+}
+
+// encodeLatin1 is the reverse of decodeLatin1, it errors on characters
+// ISO-8859-1 doesn't have
+func encodeLatin1(s string) ([]byte, error) {
+	b := make([]byte, 0, len(s))
+	for _, r := range s {
+		if r > 0xff {
+			return nil, fmt.Errorf("%q can't be written as ISO-8859-1", r)
+		}
+		b = append(b, byte(r))
+	}
+	return b, nil
+}
+
+// writeTag writes the header, the frames and the padding
+func writeTag(w io.Writer, frames []*Frame, ext *ExtendedHeader, o *options) (int, error) {
+	tag, err := encodeTag(o.writeFrames(frames), o.padding, ext)
+	if err != nil {
+		return 0, err
+	}
+	return w.Write(tag)
+}
+
+// encodeTag is the whole tag with the padding on the end. ext is the
+// extended header to write between the header and the frames, or nil.
+func encodeTag(frames []*Frame, padding int, ext *ExtendedHeader) ([]byte, error) {
+	if padding < 0 {
+		return nil, fmt.Errorf("padding can't be negative, got %d", padding)
+	}
+	var restrictions *Restrictions
+	if ext != nil && ext.HasRestrictions {
+		r := ParseRestrictions(ext.Restrictions)
+		restrictions = &r
+		err := checkRestrictions(r, frames)
+		if err != nil {
+			return nil, err
+		}
+	}
+	var framesData bytes.Buffer
+	for _, f := range frames {
+		err := writeFrame(&framesData, f)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if framesData.Len()+padding > maxSynsafe {
+		return nil, fmt.Errorf("tag is %d bytes, too big for a tag", framesData.Len()+padding)
+	}
+	// the v2.4 CRC covers the padding as well
+	framesData.Write(make([]byte, padding))
+	var extData []byte
+	var flags byte
+	if ext != nil {
+		extData = encodeExtendedHeader(ext, framesData.Bytes())
+	}
+	if extData != nil {
+		flags |= 0x40
+	}
+	size := len(extData) + framesData.Len()
+	if size > maxSynsafe {
+		return nil, fmt.Errorf("tag is %d bytes, too big for a tag", size)
+	}
+	if restrictions != nil && 10+size > restrictions.MaxTagSize {
+		return nil, fmt.Errorf("tag is %d bytes, restricted to %d: %w", 10+size, restrictions.MaxTagSize, ErrRestricted)
+	}
+	tag := make([]byte, 0, 10+size)
+	tag = append(tag, 'I', 'D', '3', 4, 0, flags)
+	tag = append(tag, synsafeBytes(size)...)
+	tag = append(tag, extData...)
+	return append(tag, framesData.Bytes()...), nil
+}
+
+// writeFrame writes the v2.4 frame header and data. The data has already
+// had the format flags undone when it was read so only the status flags
+// and the group carry over.
+func writeFrame(w *bytes.Buffer, f *Frame) error {
+	if len(f.FrameID) != 4 {
+		return fmt.Errorf("can't write %s, it isn't a v2.4 frame ID", f.FrameID)
+	}
+	var status, format byte
+	if !f.TagAlterPreserve() {
+		status |= flagTagAlter.v24
+	}
+	if !f.FileAlterPreserve() {
+		status |= flagFileAlter.v24
+	}
+	if f.ReadOnly() {
+		status |= flagReadOnly.v24
+	}
+	data := f.Data
+	if f.Grouped() {
+		format |= flagGrouped.v24
+		data = append([]byte{f.GroupID}, data...)
+	}
+	if len(data) > maxSynsafe {
+		return fmt.Errorf("frame %s is %d bytes, too big for a frame", f.FrameID, len(data))
+	}
+	w.WriteString(f.FrameID)
+	w.Write(synsafeBytes(len(data)))
+	w.Write([]byte{status, format})
+	w.Write(data)
+	return nil
+}
+
+// synsafeBytes is the 4 byte syncsafe encoding of n
+func synsafeBytes(n int) []byte {
+	return []byte{byte(n>>21) & 0x7f, byte(n>>14) & 0x7f, byte(n>>7) & 0x7f, byte(n) & 0x7f}
+}
@@ -0,0 +1,102 @@
+package easyid3
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWriteID3(t *testing.T) {
+	props := map[string]string{
+		"TIT2":                  "Title ünïcode 标题",
+		"TPE1":                  "Artist",
+		"TRCK":                  "3/12",
+		"TXXX:segmentmetadata":  "{\"a\": 1}",
+		"WXXX:homepage":         "http://example.com/ü",
+		"WOAR":                  "http://example.com/artist",
+		"COMM:eng:":             "A comment",
+		"COMM:und::with colons": "No language",
+		"USLT:eng:verse":        "La la la",
+	}
+	var buf bytes.Buffer
+	n, err := WriteID3(&buf, props, WithPadding(100))
+	if err != nil {
+		t.Fatalf("Failed write: %v", err)
+	}
+	if n != buf.Len() {
+		t.Errorf("Wrote %d bytes but said %d", buf.Len(), n)
+	}
+	out := buf.Bytes()
+	if string(out[:6]) != "ID3\x04\x00\x00" || synsafeInt(out[6:10]) != len(out)-10 {
+		t.Fatalf("Wrong header %x", out[:10])
+	}
+	if !bytes.HasSuffix(out, make([]byte, 100)) {
+		t.Error("Missing the padding")
+	}
+	read, err := ReadID3(bytes.NewReader(out))
+	if err != nil {
+		t.Fatalf("Failed read: %v", err)
+	}
+	if !reflect.DeepEqual(read, props) {
+		t.Errorf("Round trip got %v", read)
+	}
+
+	var again bytes.Buffer
+	_, err = WriteID3(&again, props, WithPadding(100))
+	if err != nil || !bytes.Equal(again.Bytes(), out) {
+		t.Error("Writing the same frames twice came out different")
+	}
+}
+
+func TestWriteID3TextFrames(t *testing.T) {
+	var buf bytes.Buffer
+	_, err := WriteID3(&buf, map[string]string{"TIT2": "Title"})
+	if err != nil {
+		t.Fatalf("Failed write: %v", err)
+	}
+	want := tagBytes(4, 0, frameBytes(4, "TIT2", []byte("\x03Title\x00")))
+	if !bytes.Equal(buf.Bytes(), want) {
+		t.Errorf("Got %x want %x", buf.Bytes(), want)
+	}
+
+	// a bare TXXX or WXXX has no description, not its own ID
+	buf.Reset()
+	_, err = WriteID3(&buf, map[string]string{"TXXX": "text", "WXXX": "http://example.com", "TXXX:a:b": "colons"})
+	if err != nil {
+		t.Fatalf("Failed write: %v", err)
+	}
+	want = tagBytes(4, 0,
+		frameBytes(4, "TXXX", []byte("\x03\x00text\x00")),
+		frameBytes(4, "TXXX", []byte("\x03a:b\x00colons\x00")),
+		frameBytes(4, "WXXX", []byte("\x03\x00http://example.com")),
+	)
+	if !bytes.Equal(buf.Bytes(), want) {
+		t.Errorf("Got %x want %x", buf.Bytes(), want)
+	}
+	vals, err := ReadID3(bytes.NewReader(buf.Bytes()))
+	if err != nil || vals["TXXX:"] != "text" || vals["WXXX:"] != "http://example.com" || vals["TXXX:a:b"] != "colons" {
+		t.Errorf("Wrong values %q %v", vals, err)
+	}
+}
+
+func TestWriteID3Errors(t *testing.T) {
+	for key, value := range map[string]string{
+		"TIT":            "v2.2 ID",
+		"tit2":           "lower case",
+		"APIC":           "not text",
+		"WOAR":           "http://example.com/标",
+		"COMM:english:x": "long language",
+		"COMM:en:x":      "short language",
+		"USLT:e1g:x":     "not letters",
+	} {
+		_, err := WriteID3(&bytes.Buffer{}, map[string]string{key: value})
+		if err == nil {
+			t.Errorf("Expected an error writing %s", key)
+		}
+	}
+	_, err := WriteID3(&bytes.Buffer{}, map[string]string{"TIT2": "x"}, WithPadding(-1))
+	if err == nil || !strings.Contains(err.Error(), "padding") {
+		t.Errorf("Expected a padding error got %v", err)
+	}
+}